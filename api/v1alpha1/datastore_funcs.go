@@ -6,8 +6,10 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -37,3 +39,78 @@ func (in *ContentRef) GetContent(ctx context.Context, client client.Client) ([]b
 
 	return v, nil
 }
+
+// HasCapacity returns true when the DataStore can accept a further Tenant Control Plane,
+// according to the maxTenants value, and the number of Tenant Control Planes assigned to it.
+func (in *DataStore) HasCapacity(tenants int) bool {
+	if in.Spec.MaxTenants == nil {
+		return true
+	}
+
+	return tenants < int(*in.Spec.MaxTenants)
+}
+
+// IsUsedBy returns true when the given namespaced name of a Tenant Control Plane is using the DataStore.
+func (in *DataStore) IsUsedBy(namespacedName string) bool {
+	return slices.Contains(in.Status.UsedBy, namespacedName)
+}
+
+// ActiveEndpoints returns the endpoints handed to the Tenant Control Planes, skipping the ones reported as unhealthy:
+// all the endpoints are returned when none has been probed healthy, avoiding an empty configuration.
+func (in *DataStore) ActiveEndpoints() Endpoints {
+	unhealthy := make(map[string]struct{}, len(in.Status.Endpoints))
+
+	for _, status := range in.Status.Endpoints {
+		if !status.Healthy {
+			unhealthy[status.Endpoint] = struct{}{}
+		}
+	}
+
+	active := make(Endpoints, 0, len(in.Spec.Endpoints))
+
+	for _, endpoint := range in.Spec.Endpoints {
+		if _, ok := unhealthy[endpoint]; !ok {
+			active = append(active, endpoint)
+		}
+	}
+
+	if len(active) == 0 {
+		return in.Spec.Endpoints
+	}
+
+	return active
+}
+
+// PostgreSQLSharedDatabase returns the database storing the tenant schemas,
+// or an empty string when each Tenant Control Plane gets its own database.
+func (in *DataStore) PostgreSQLSharedDatabase() string {
+	if in.Spec.Driver != KinePostgreSQLDriver || in.Spec.PostgreSQL == nil || in.Spec.PostgreSQL.Layout != PostgreSQLLayoutSchema {
+		return ""
+	}
+
+	return in.Spec.PostgreSQL.Database
+}
+
+// UsesTenantCertificates returns true when the tenant users authenticate with a client certificate issued by Kamaji.
+func (in *DataStore) UsesTenantCertificates() bool {
+	return in.Spec.TenantAuthentication == DataStoreTenantAuthenticationCertificate
+}
+
+// KineTLSServerName returns the TLS server name kine verifies the data store certificate against,
+// passed to it as an endpoint parameter: an empty string is returned when unset, or for the etcd and NATS drivers.
+func (in *DataStore) KineTLSServerName() string {
+	if in.Spec.Driver != KineMySQLDriver && in.Spec.Driver != KinePostgreSQLDriver || in.Spec.TLSConfig == nil {
+		return ""
+	}
+
+	return in.Spec.TLSConfig.ServerName
+}
+
+// SoftLimit returns the threshold below which the write block is lifted, capped to the hard one.
+func (in *StorageQuota) SoftLimit() resource.Quantity {
+	if in.Soft == nil || in.Soft.Cmp(in.Hard) > 0 {
+		return in.Hard
+	}
+
+	return *in.Soft
+}
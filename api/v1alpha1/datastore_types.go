@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,26 +26,231 @@ var (
 type Endpoints []string
 
 // DataStoreSpec defines the desired state of DataStore.
-// +kubebuilder:validation:XValidation:rule="(self.driver == \"etcd\") ? (self.tlsConfig != null && (has(self.tlsConfig.certificateAuthority.privateKey.secretReference) || has(self.tlsConfig.certificateAuthority.privateKey.content))) : true", message="certificateAuthority privateKey must have secretReference or content when driver is etcd"
-// +kubebuilder:validation:XValidation:rule="(self.driver == \"etcd\") ? (self.tlsConfig != null && (has(self.tlsConfig.clientCertificate.certificate.secretReference) || has(self.tlsConfig.clientCertificate.certificate.content))) : true", message="clientCertificate must have secretReference or content when driver is etcd"
-// +kubebuilder:validation:XValidation:rule="(self.driver == \"etcd\") ? (self.tlsConfig != null && (has(self.tlsConfig.clientCertificate.privateKey.secretReference) || has(self.tlsConfig.clientCertificate.privateKey.content))) : true", message="clientCertificate privateKey must have secretReference or content when driver is etcd"
+// +kubebuilder:validation:XValidation:rule="(self.driver == \"etcd\" && !has(self.managed)) ? (self.tlsConfig != null && (has(self.tlsConfig.certificateAuthority.privateKey.secretReference) || has(self.tlsConfig.certificateAuthority.privateKey.content))) : true", message="certificateAuthority privateKey must have secretReference or content when driver is etcd"
+// +kubebuilder:validation:XValidation:rule="(self.driver == \"etcd\" && !has(self.managed)) ? (self.tlsConfig != null && (has(self.tlsConfig.clientCertificate.certificate.secretReference) || has(self.tlsConfig.clientCertificate.certificate.content))) : true", message="clientCertificate must have secretReference or content when driver is etcd"
+// +kubebuilder:validation:XValidation:rule="(self.driver == \"etcd\" && !has(self.managed)) ? (self.tlsConfig != null && (has(self.tlsConfig.clientCertificate.privateKey.secretReference) || has(self.tlsConfig.clientCertificate.privateKey.content))) : true", message="clientCertificate privateKey must have secretReference or content when driver is etcd"
 // +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\" && has(self.tlsConfig) && has(self.tlsConfig.clientCertificate)) ? (((has(self.tlsConfig.clientCertificate.certificate.secretReference) || has(self.tlsConfig.clientCertificate.certificate.content)))) : true", message="When driver is not etcd and tlsConfig exists, clientCertificate must be null or contain valid content"
 // +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\" && has(self.basicAuth)) ? ((has(self.basicAuth.username.secretReference) || has(self.basicAuth.username.content))) : true", message="When driver is not etcd and basicAuth exists, username must have secretReference or content"
 // +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\" && has(self.basicAuth)) ? ((has(self.basicAuth.password.secretReference) || has(self.basicAuth.password.content))) : true", message="When driver is not etcd and basicAuth exists, password must have secretReference or content"
-// +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\") ? (has(self.tlsConfig) || has(self.basicAuth)) : true", message="When driver is not etcd, either tlsConfig or basicAuth must be provided"
+// +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\") ? (has(self.tlsConfig) || has(self.basicAuth) || has(self.natsAccount)) : true", message="When driver is not etcd, either tlsConfig, basicAuth, or natsAccount must be provided"
+// +kubebuilder:validation:XValidation:rule="has(self.natsAccount) ? self.driver == \"NATS\" : true", message="natsAccount is supported only by the NATS driver"
+// +kubebuilder:validation:XValidation:rule="has(self.natsAccount) ? (has(self.natsAccount.signingKey.secretReference) || has(self.natsAccount.signingKey.content)) : true", message="natsAccount signingKey must have secretReference or content"
+// +kubebuilder:validation:XValidation:rule="has(self.managed) ? self.driver == \"etcd\" : true", message="managed is supported only by the etcd driver"
+// +kubebuilder:validation:XValidation:rule="has(self.managed) || has(self.endpoints)", message="endpoints are required when the data store is not managed"
+// +kubebuilder:validation:XValidation:rule="has(self.managed) == has(oldSelf.managed)", message="managed cannot be added or removed after creation"
 // +kubebuilder:validation:XValidation:rule="oldSelf == null || self.driver == oldSelf.driver", message="driver is immutable and cannot be changed after creation"
+// +kubebuilder:validation:XValidation:rule="!has(self.drain) || (has(self.cordoned) && self.cordoned)", message="drain requires the data store to be cordoned"
+// +kubebuilder:validation:XValidation:rule="has(self.postgreSQL) ? self.driver == \"PostgreSQL\" : true", message="postgreSQL is supported only by the PostgreSQL driver"
+// +kubebuilder:validation:XValidation:rule="has(self.kine) ? self.driver != \"etcd\" : true", message="kine is not supported by the etcd driver"
+// +kubebuilder:validation:XValidation:rule="(has(self.tenantAuthentication) && self.tenantAuthentication == \"Certificate\") ? ((self.driver == \"MySQL\" || self.driver == \"PostgreSQL\") && has(self.tlsConfig) && has(self.tlsConfig.certificateAuthority.privateKey)) : true", message="Certificate tenant authentication is supported only by the MySQL and PostgreSQL drivers, and requires the certificateAuthority privateKey"
+// +kubebuilder:validation:XValidation:rule="(has(self.tenantAuthentication) ? self.tenantAuthentication : \"Password\") == (has(oldSelf.tenantAuthentication) ? oldSelf.tenantAuthentication : \"Password\")", message="tenantAuthentication is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.postgreSQL) == has(oldSelf.postgreSQL)", message="postgreSQL cannot be added or removed after creation"
+// +kubebuilder:validation:XValidation:rule="has(self.credentialsRotationInterval) ? self.driver != \"NATS\" : true", message="the NATS credentials cannot be rotated, since Kamaji cannot revoke the previous user"
+// +kubebuilder:validation:XValidation:rule="has(self.parameters) ? (self.driver == \"MySQL\" || self.driver == \"PostgreSQL\") : true", message="parameters are supported only by the MySQL and PostgreSQL drivers"
+// +kubebuilder:validation:XValidation:rule="has(self.parameters) ? !(\"tls\" in self.parameters) && !(\"sslmode\" in self.parameters) : true", message="the TLS parameters are derived from tlsConfig, and cannot be set"
+// +kubebuilder:validation:XValidation:rule="has(self.parameters) && self.driver == \"PostgreSQL\" ? self.parameters.all(k, k in [\"connect_timeout\", \"application_name\"]) : true", message="the PostgreSQL parameters supported by both Kamaji and kine are connect_timeout and application_name"
 type DataStoreSpec struct {
 	// The driver to use to connect to the shared datastore.
 	Driver Driver `json:"driver"`
 	// List of the endpoints to connect to the shared datastore.
 	// No need for protocol, just bare IP/FQDN and port.
-	Endpoints Endpoints `json:"endpoints"`
+	// It's populated by Kamaji when the data store is managed.
+	Endpoints Endpoints `json:"endpoints,omitempty"`
 	// In case of authentication enabled for the given data store, specifies the username and password pair.
 	// This value is optional.
 	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
 	// Defines the TLS/SSL configuration required to connect to the data store in a secure way.
+	// It's populated by Kamaji when the data store is managed, referring to the generated certificates.
 	// This value is optional.
 	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+	// NATSAccount enables the multi-tenancy for the NATS driver, when the server uses the decentralized JWT authentication:
+	// each Tenant Control Plane gets its own user, issued by the given account, and allowed to access only its own KV bucket.
+	// When unset, a NATS data store can be used by a single Tenant Control Plane, sharing its credentials.
+	// This value is optional.
+	NATSAccount *NATSAccount `json:"natsAccount,omitempty"`
+	// MaxTenants is the maximum number of Tenant Control Planes that can be placed on the given data store:
+	// when reached, the data store is no more taken in consideration for the automatic placement,
+	// and Tenant Control Planes referring to it are rejected.
+	// This value is optional, and no limit is enforced when unset.
+	//+kubebuilder:validation:Minimum=1
+	MaxTenants *int32 `json:"maxTenants,omitempty"`
+	// DefaultStorageQuota is the storage quota enforced on the Tenant Control Planes using the data store
+	// which don't declare their own one.
+	// This value is optional, and no quota is enforced when unset.
+	DefaultStorageQuota *StorageQuota `json:"defaultStorageQuota,omitempty"`
+	// DefaultLimits are the limits enforced on the users of the Tenant Control Planes using the data store
+	// which don't declare their own ones.
+	// This value is optional, and no limit is enforced when unset.
+	DefaultLimits *DataStoreLimits `json:"defaultLimits,omitempty"`
+	// CredentialsRotationInterval is the interval after which the credentials of the Tenant Control Planes
+	// using the data store are rotated, such as 720h for 30 days.
+	// This value is optional, and the credentials are rotated only on demand when unset.
+	// It's not supported by the NATS driver, since the revocation of the previous user requires the account JWT to be updated.
+	CredentialsRotationInterval *metav1.Duration `json:"credentialsRotationInterval,omitempty"`
+	// Managed enables the provisioning of a dedicated etcd cluster by Kamaji, in its own namespace:
+	// the certificates are generated by Kamaji, and the data store is ready once the cluster has quorum.
+	// It's supported only by the etcd driver.
+	// This value is optional.
+	Managed *ManagedDataStore `json:"managed,omitempty"`
+	// Cordoned excludes the data store from the automatic placement, and rejects the Tenant Control Planes
+	// referring to it, except the ones already using it.
+	// This value is optional.
+	Cordoned bool `json:"cordoned,omitempty"`
+	// Drain migrates the Tenant Control Planes using the cordoned data store to other ones,
+	// using the same flow of a change of their dataStore field.
+	// This value is optional.
+	Drain *DataStoreDrain `json:"drain,omitempty"`
+	// Orphans defines the handling of the tenant databases and users left behind on the data store,
+	// such as by an interrupted Tenant Control Plane deletion, or a migration:
+	// they're reported in the status, and deleted only if the Delete policy is set.
+	// This value is optional.
+	Orphans *DataStoreOrphans `json:"orphans,omitempty"`
+	// PostgreSQL defines how the Tenant Control Planes are laid out on a PostgreSQL data store.
+	// This value is optional, and each Tenant Control Plane gets its own database when unset.
+	PostgreSQL *PostgreSQLDataStore `json:"postgreSQL,omitempty"`
+	// TenantAuthentication is Password to let kine authenticate with the credentials generated for the Tenant Control Plane,
+	// or Certificate to issue it a client certificate, signed by the data store Certificate Authority, having the tenant user
+	// as Common Name: no password is stored for the tenant, and the user is created requiring the certificate.
+	// The Certificate authentication is supported only by the MySQL and PostgreSQL drivers, and requires the Certificate Authority
+	// private key: PostgreSQL must be configured to authenticate the tenant users with the cert method in its pg_hba.conf file.
+	//+kubebuilder:default=Password
+	TenantAuthentication DataStoreTenantAuthentication `json:"tenantAuthentication,omitempty"`
+	// Kine defines the defaults of the kine sidecar for the Tenant Control Planes using the data store:
+	// the arguments and the resources of the Tenant Control Plane take precedence.
+	// It's not supported by the etcd driver.
+	// This value is optional.
+	Kine *DataStoreKine `json:"kine,omitempty"`
+	// Parameters are the driver connection parameters, such as the timeouts, used by Kamaji and appended to the kine endpoint
+	// as query parameters: the TLS ones (tls for MySQL, sslmode for PostgreSQL) are derived from tlsConfig, and cannot be set.
+	// PostgreSQL accepts connect_timeout and application_name only, the ones Kamaji connections can honour as kine does.
+	// It's supported only by the MySQL and PostgreSQL drivers.
+	// This value is optional.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// DataStoreKine defines the kine sidecar defaults, allowing to roll a new kine build,
+// or a different tuning, to all the Tenant Control Planes using the data store.
+type DataStoreKine struct {
+	// Image of the kine container, overriding the one of Kamaji set with the --kine-image flag:
+	// the kine additional container of the Tenant Control Plane, if any, takes precedence.
+	Image string `json:"image,omitempty"`
+	// CompactInterval is the interval between the compactions of the revisions history, set as --compact-interval.
+	CompactInterval *metav1.Duration `json:"compactInterval,omitempty"`
+	// ExtraArgs are the additional kine arguments, merged with the Kamaji ones,
+	// and overridden by the kine extra arguments of the Tenant Control Plane.
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// Resources of the kine container, when not set by the Tenant Control Plane.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Password;Certificate
+type DataStoreTenantAuthentication string
+
+const (
+	DataStoreTenantAuthenticationPassword    DataStoreTenantAuthentication = "Password"
+	DataStoreTenantAuthenticationCertificate DataStoreTenantAuthentication = "Certificate"
+)
+
+// +kubebuilder:validation:Enum=Database;Schema
+type PostgreSQLLayout string
+
+const (
+	PostgreSQLLayoutDatabase PostgreSQLLayout = "Database"
+	PostgreSQLLayoutSchema   PostgreSQLLayout = "Schema"
+)
+
+// PostgreSQLDataStore defines the layout of the Tenant Control Planes on a PostgreSQL data store, it cannot be changed after creation.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="postgreSQL is immutable"
+type PostgreSQLDataStore struct {
+	// Layout is Database to store each Tenant Control Plane in its own database, or Schema to store it in its own schema,
+	// owned by the tenant role, of a database shared by all the tenants: the latter avoids the per-database overhead
+	// of PostgreSQL when hosting many small Tenant Control Planes.
+	//+kubebuilder:default=Database
+	Layout PostgreSQLLayout `json:"layout,omitempty"`
+	// Database is the shared database storing the tenant schemas with the Schema layout, created if missing.
+	//+kubebuilder:default=kamaji
+	//+kubebuilder:validation:MinLength=1
+	Database string `json:"database,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Retain;Delete
+type DataStoreOrphanPolicy string
+
+const (
+	DataStoreOrphanPolicyRetain DataStoreOrphanPolicy = "Retain"
+	DataStoreOrphanPolicyDelete DataStoreOrphanPolicy = "Delete"
+)
+
+// DataStoreOrphans defines how the tenant databases and users not used by any Tenant Control Plane are handled.
+type DataStoreOrphans struct {
+	// Policy is Retain to only report the orphans, or Delete to delete them once the grace period elapsed.
+	//+kubebuilder:default=Retain
+	Policy DataStoreOrphanPolicy `json:"policy,omitempty"`
+	// GracePeriod is the time an orphan must be detected for before being deleted.
+	//+kubebuilder:default="24h"
+	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// DataStoreDrain defines how the Tenant Control Planes are migrated away from a cordoned data store.
+type DataStoreDrain struct {
+	// DataStorePool restricts the target data stores to the ones selected by the given pool, placing the Tenant Control Planes
+	// according to its policy: when unset, they're placed on the least used data store backed by the same driver.
+	DataStorePool string `json:"dataStorePool,omitempty"`
+	// MaxConcurrentMigrations is the number of Tenant Control Planes migrated at the same time.
+	//+kubebuilder:default=1
+	//+kubebuilder:validation:Minimum=1
+	MaxConcurrentMigrations int32 `json:"maxConcurrentMigrations,omitempty"`
+}
+
+// ManagedDataStore defines the etcd cluster provisioned by Kamaji.
+type ManagedDataStore struct {
+	// Replicas is the number of the etcd cluster members:
+	// an odd number is required to tolerate the failure of a minority of them.
+	//+kubebuilder:default=3
+	//+kubebuilder:validation:Enum=1;3;5;7
+	Replicas int32 `json:"replicas,omitempty"`
+	// Storage defines the persistent volume claimed by each member.
+	//+kubebuilder:default={}
+	Storage ManagedDataStoreStorage `json:"storage,omitempty"`
+	// Version of etcd, used as the container image tag.
+	//+kubebuilder:default="v3.6.13"
+	Version string `json:"version,omitempty"`
+}
+
+// ManagedDataStoreStorage defines the persistent volume of the etcd members, it cannot be changed after creation.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="storage is immutable"
+type ManagedDataStoreStorage struct {
+	// Size of the persistent volume.
+	//+kubebuilder:default="8Gi"
+	Size resource.Quantity `json:"size,omitempty"`
+	// StorageClassName of the persistent volume: the default one is used when unset.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// DataStoreLimits defines the resources a Tenant Control Plane can consume on a SQL data store,
+// enforced on its user to prevent a single tenant from exhausting the resources shared with the other ones.
+type DataStoreLimits struct {
+	// MaxConnections caps the concurrent connections of the tenant user:
+	// it's enforced with the CONNECTION LIMIT on PostgreSQL, and the MAX_USER_CONNECTIONS on MySQL.
+	//+kubebuilder:validation:Minimum=1
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+	// StatementTimeout aborts the statements of the tenant user lasting more than the given duration.
+	// It's supported only by PostgreSQL.
+	StatementTimeout *metav1.Duration `json:"statementTimeout,omitempty"`
+	// MaxQueriesPerHour caps the queries issued by the tenant user every hour.
+	// It's supported only by MySQL.
+	//+kubebuilder:validation:Minimum=1
+	MaxQueriesPerHour *int32 `json:"maxQueriesPerHour,omitempty"`
+}
+
+// StorageQuota defines the storage thresholds enforced on a Tenant Control Plane, according to its data store usage.
+type StorageQuota struct {
+	// Hard is the storage threshold which, once reached, switches the Tenant Control Plane in the WriteLimited status:
+	// creation and update operations are blocked, while deletions are still allowed to reclaim space.
+	Hard resource.Quantity `json:"hard"`
+	// Soft is the storage threshold below which the write block is lifted.
+	// When unset, or greater than the hard threshold, it matches the hard one.
+	Soft *resource.Quantity `json:"soft,omitempty"`
 }
 
 // TLSConfig contains the information used to connect to the data store using a secured connection.
@@ -54,6 +260,14 @@ type TLSConfig struct {
 	CertificateAuthority CertKeyPair `json:"certificateAuthority"`
 	// Specifies the SSL/TLS key and private key pair used to connect to the data store.
 	ClientCertificate *ClientCertificate `json:"clientCertificate,omitempty"`
+	// InsecureSkipVerify states the verification policy of the data store certificate, which can't be disabled:
+	// both Kamaji and kine always verify it, against the server name, or the host of the connected endpoint.
+	//+kubebuilder:validation:XValidation:rule="!self",message="the data store certificate is always verified, insecureSkipVerify cannot be enabled"
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// ServerName is the name used to verify the certificate of the data store, when it differs from the endpoints host,
+	// such as when connecting by IP address: it's passed to kine as the tls-server-name endpoint parameter.
+	// The server certificate is always verified, and the host of the connected endpoint is used when unset.
+	ServerName string `json:"serverName,omitempty"`
 }
 
 type ClientCertificate struct {
@@ -72,6 +286,16 @@ type BasicAuth struct {
 	Password ContentRef `json:"password"`
 }
 
+// NATSAccount contains the NATS account used to issue the users of the Tenant Control Planes.
+type NATSAccount struct {
+	// SigningKey is the NKey seed of the account, or of one of its signing keys, used to sign the user JWTs:
+	// Kamaji issues its own user with the same key to manage the KV buckets.
+	SigningKey ContentRef `json:"signingKey"`
+	// PublicKey of the account: it's required when the SigningKey is one of the account signing keys,
+	// rather than its identity one.
+	PublicKey string `json:"publicKey,omitempty"`
+}
+
 type ContentRef struct {
 	// Bare content of the file, base64 encoded.
 	// It has precedence over the SecretReference value.
@@ -94,8 +318,86 @@ const (
 
 	DataStoreConditionValidType           = "kamaji.clastix.io/DataStoreValidation"
 	DataStoreConditionAllowedDeletionType = "kamaji.clastix.io/DataStoreAllowedDeletion"
+	DataStoreConditionManagedType         = "kamaji.clastix.io/DataStoreManaged"
+	// DataStoreConditionHealthyType is true when at least one of the endpoints is serving requests,
+	// while DataStoreConditionDegradedType is true when some of them are not.
+	DataStoreConditionHealthyType  = "kamaji.clastix.io/DataStoreHealthy"
+	DataStoreConditionDegradedType = "kamaji.clastix.io/DataStoreDegraded"
+	// DataStoreConditionDrainedType is true once all the Tenant Control Planes have been migrated away from the drained data store.
+	DataStoreConditionDrainedType = "kamaji.clastix.io/DataStoreDrained"
 )
 
+// +kubebuilder:validation:Enum=Pending;Migrating;Completed;Failed
+type DataStoreDrainPhase string
+
+const (
+	DataStoreDrainPhasePending   DataStoreDrainPhase = "Pending"
+	DataStoreDrainPhaseMigrating DataStoreDrainPhase = "Migrating"
+	DataStoreDrainPhaseCompleted DataStoreDrainPhase = "Completed"
+	DataStoreDrainPhaseFailed    DataStoreDrainPhase = "Failed"
+)
+
+// DataStoreDrainTenantStatus reports the migration progress of a Tenant Control Plane using the drained data store.
+type DataStoreDrainTenantStatus struct {
+	// Name is the namespaced name of the Tenant Control Plane.
+	Name string `json:"name"`
+	// Target is the data store the Tenant Control Plane is migrated to, once placed.
+	Target string              `json:"target,omitempty"`
+	Phase  DataStoreDrainPhase `json:"phase"`
+	// Message reports the reason of a pending or failed migration.
+	Message string `json:"message,omitempty"`
+	// StartTime is when the migration has been started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the Tenant Control Plane started using the target data store.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Database;User
+type DataStoreOrphanKind string
+
+const (
+	DataStoreOrphanKindDatabase DataStoreOrphanKind = "Database"
+	DataStoreOrphanKindUser     DataStoreOrphanKind = "User"
+)
+
+// DataStoreOrphanStatus reports a tenant database, or user, not used by any Tenant Control Plane.
+type DataStoreOrphanStatus struct {
+	Kind DataStoreOrphanKind `json:"kind"`
+	Name string              `json:"name"`
+	// DetectionTime is when the orphan has been detected for the first time.
+	DetectionTime metav1.Time `json:"detectionTime"`
+}
+
+// DataStoreInventoryItem identifies a tenant database, or user, provisioned by Kamaji on the data store.
+type DataStoreInventoryItem struct {
+	Kind DataStoreOrphanKind `json:"kind"`
+	Name string              `json:"name"`
+}
+
+// DataStoreEndpointStatus reports the outcome of the health probes of a DataStore endpoint.
+type DataStoreEndpointStatus struct {
+	// Endpoint is the probed endpoint, as declared in the DataStore specification.
+	Endpoint string `json:"endpoint"`
+	// Healthy is false when the endpoint failed the consecutive probes:
+	// it's excluded from the configuration handed to the Tenant Control Planes, as long as a healthy one is available.
+	Healthy bool `json:"healthy"`
+	// LastTransitionTime is the last time the endpoint changed its health.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Message is the error reported by the last failed probe.
+	Message string `json:"message,omitempty"`
+}
+
+// ManagedDataStoreStatus defines the observed state of the etcd cluster provisioned by Kamaji.
+type ManagedDataStoreStatus struct {
+	// Bootstrapped is true once the etcd cluster reached its quorum for the first time, and its authentication got enabled:
+	// from then on, the new members join the existing cluster.
+	Bootstrapped bool `json:"bootstrapped,omitempty"`
+	// Members are the names of the etcd cluster members.
+	Members []string `json:"members,omitempty"`
+	// HealthyMembers is the number of the etcd cluster members serving requests.
+	HealthyMembers int32 `json:"healthyMembers,omitempty"`
+}
+
 // DataStoreStatus defines the observed state of DataStore.
 type DataStoreStatus struct {
 	// ObservedGeneration represents the .metadata.generation that was last reconciled.
@@ -103,6 +405,17 @@ type DataStoreStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// List of the Tenant Control Planes, namespaced named, using this data store.
 	UsedBy []string `json:"usedBy,omitempty"`
+	// Managed reports the status of the etcd cluster provisioned by Kamaji, if any.
+	Managed *ManagedDataStoreStatus `json:"managed,omitempty"`
+	// Endpoints reports the health of each DataStore endpoint, when the probing is enabled.
+	Endpoints []DataStoreEndpointStatus `json:"endpoints,omitempty"`
+	// Drain reports the migration progress of each Tenant Control Plane, when the data store is drained.
+	Drain []DataStoreDrainTenantStatus `json:"drain,omitempty"`
+	// Inventory lists the tenant databases and users provisioned by Kamaji on the data store, when the orphans detection is enabled:
+	// these are recorded while used by a Tenant Control Plane, and are the only ones eligible as orphans.
+	Inventory []DataStoreInventoryItem `json:"inventory,omitempty"`
+	// Orphans lists the tenant databases and users not used by any Tenant Control Plane, when the detection is enabled.
+	Orphans []DataStoreOrphanStatus `json:"orphans,omitempty"`
 	// Conditions contains the validation conditions for the given Datastore.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// Ready returns if the DataStore is accepted and ready to get used:
@@ -115,6 +428,7 @@ type DataStoreStatus struct {
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Driver",type="string",JSONPath=".spec.driver",description="Kamaji data store driver"
 //+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready",description="DataStore validated and ready for use"
+//+kubebuilder:printcolumn:name="Cordoned",type="boolean",JSONPath=".spec.cordoned",description="DataStore excluded from the placement",priority=1
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 //+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
 
@@ -0,0 +1,60 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=LeastUsed;Spread;BinPacking
+type PlacementPolicy string
+
+var (
+	// LeastUsedPlacementPolicy picks the DataStore serving the lowest number of Tenant Control Planes.
+	LeastUsedPlacementPolicy PlacementPolicy = "LeastUsed"
+	// SpreadPlacementPolicy distributes the Tenant Control Planes across the DataStore groups sharing
+	// the same value for the spread label, such as the availability zone.
+	SpreadPlacementPolicy PlacementPolicy = "Spread"
+	// BinPackingPlacementPolicy picks the most used DataStore which still has capacity,
+	// filling DataStores before scheduling Tenant Control Planes on the empty ones.
+	BinPackingPlacementPolicy PlacementPolicy = "BinPacking"
+)
+
+// DataStorePoolSpec defines the desired state of DataStorePool.
+// +kubebuilder:validation:XValidation:rule="self.policy != 'Spread' || (has(self.spreadLabel) && size(self.spreadLabel) > 0)",message="spreadLabel is required when policy is Spread"
+type DataStorePoolSpec struct {
+	// DataStoreSelector is used to select the DataStore objects belonging to the pool.
+	DataStoreSelector metav1.LabelSelector `json:"dataStoreSelector"`
+	// Policy defines how the DataStore is picked among the pool ones when placing a Tenant Control Plane:
+	// only ready DataStores with available capacity, according to their maxTenants value, are taken in consideration.
+	//+kubebuilder:default=LeastUsed
+	Policy PlacementPolicy `json:"policy,omitempty"`
+	// SpreadLabel is the DataStore label key used to group DataStores when the Spread policy is used:
+	// the group with the lowest number of Tenant Control Planes is picked first.
+	SpreadLabel string `json:"spreadLabel,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=dsp,categories=kamaji
+//+kubebuilder:printcolumn:name="Policy",type="string",JSONPath=".spec.policy",description="Placement policy"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+//+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
+
+// DataStorePool is the Schema for the datastorepools API: it groups DataStore objects,
+// allowing Kamaji to automatically place Tenant Control Planes according to a capacity-aware policy.
+type DataStorePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DataStorePoolSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DataStorePoolList contains a list of DataStorePool.
+type DataStorePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataStorePool `json:"items"`
+}
@@ -20,8 +20,12 @@ var (
 	SchemeBuilder = runtime.NewSchemeBuilder(func(scheme *runtime.Scheme) error {
 		scheme.AddKnownTypes(GroupVersion,
 			&DataStore{}, &DataStoreList{},
+			&DataStorePool{}, &DataStorePoolList{},
 			&TenantControlPlane{}, &TenantControlPlaneList{},
 			&KubeconfigGenerator{}, &KubeconfigGeneratorList{},
+			&TenantControlPlaneBackup{}, &TenantControlPlaneBackupList{},
+			&TenantControlPlaneClone{}, &TenantControlPlaneCloneList{},
+			&TenantControlPlaneRestore{}, &TenantControlPlaneRestoreList{},
 		)
 
 		metav1.AddToGroupVersion(scheme, GroupVersion)
@@ -43,6 +43,10 @@ func (d *DatastoreUsedSecret) ExtractValue() client.IndexerFunc {
 			}
 		}
 
+		if ds.Spec.NATSAccount != nil && ds.Spec.NATSAccount.SigningKey.SecretRef != nil {
+			res = append(res, d.namespacedName(*ds.Spec.NATSAccount.SigningKey.SecretRef))
+		}
+
 		if ds.Spec.TLSConfig != nil {
 			if ds.Spec.TLSConfig.CertificateAuthority.Certificate.SecretRef != nil {
 				res = append(res, d.namespacedName(*ds.Spec.TLSConfig.CertificateAuthority.Certificate.SecretRef))
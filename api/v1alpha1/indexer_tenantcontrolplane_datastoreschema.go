@@ -0,0 +1,78 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TenantControlPlaneDataStoreSchemaKey   = "spec.dataStoreSchema"
+	TenantControlPlaneDataStoreUsernameKey = "spec.dataStoreUsername"
+)
+
+// DataStoreScopedIndexValue returns the value indexed for the given schema, or username, on the given DataStore.
+func DataStoreScopedIndexValue(dataStoreName, value string) string {
+	return dataStoreName + "/" + value
+}
+
+// TenantControlPlaneDataStoreSchema indexes the effective schema of the Tenant Control Planes for each DataStore storing their data.
+type TenantControlPlaneDataStoreSchema struct{}
+
+func (t *TenantControlPlaneDataStoreSchema) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneDataStoreSchema) Field() string {
+	return TenantControlPlaneDataStoreSchemaKey
+}
+
+func (t *TenantControlPlaneDataStoreSchema) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp := object.(*TenantControlPlane) //nolint:forcetypeassert
+
+		return dataStoreScopedIndexValues(tcp, tcp.EffectiveDataStoreSchema())
+	}
+}
+
+func (t *TenantControlPlaneDataStoreSchema) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
+
+// TenantControlPlaneDataStoreUsername indexes the effective username of the Tenant Control Planes for each DataStore storing their data.
+type TenantControlPlaneDataStoreUsername struct{}
+
+func (t *TenantControlPlaneDataStoreUsername) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneDataStoreUsername) Field() string {
+	return TenantControlPlaneDataStoreUsernameKey
+}
+
+func (t *TenantControlPlaneDataStoreUsername) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp := object.(*TenantControlPlane) //nolint:forcetypeassert
+
+		return dataStoreScopedIndexValues(tcp, tcp.EffectiveDataStoreUsername())
+	}
+}
+
+func (t *TenantControlPlaneDataStoreUsername) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
+
+func dataStoreScopedIndexValues(tcp *TenantControlPlane, value string) []string {
+	dataStores := tcp.UsedDataStores()
+
+	values := make([]string, 0, len(dataStores))
+	for _, dataStoreName := range dataStores {
+		values = append(values, DataStoreScopedIndexValue(dataStoreName, value))
+	}
+
+	return values
+}
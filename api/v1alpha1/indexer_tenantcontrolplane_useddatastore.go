@@ -11,7 +11,8 @@ import (
 )
 
 const (
-	TenantControlPlaneUsedDataStoreKey = "status.storage.dataStoreName"
+	TenantControlPlaneUsedDataStoreKey     = "status.storage.dataStoreName"
+	TenantControlPlaneAssignedDataStoreKey = "spec.dataStore"
 )
 
 type TenantControlPlaneStatusDataStore struct{}
@@ -35,3 +36,27 @@ func (t *TenantControlPlaneStatusDataStore) ExtractValue() client.IndexerFunc {
 func (t *TenantControlPlaneStatusDataStore) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
 	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
 }
+
+// TenantControlPlaneSpecDataStore indexes the DataStore the Tenant Control Planes are assigned to,
+// including the ones not provisioned yet, thus not using it.
+type TenantControlPlaneSpecDataStore struct{}
+
+func (t *TenantControlPlaneSpecDataStore) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneSpecDataStore) Field() string {
+	return TenantControlPlaneAssignedDataStoreKey
+}
+
+func (t *TenantControlPlaneSpecDataStore) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp := object.(*TenantControlPlane) //nolint:forcetypeassert
+
+		return []string{tcp.Spec.DataStore}
+	}
+}
+
+func (t *TenantControlPlaneSpecDataStore) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
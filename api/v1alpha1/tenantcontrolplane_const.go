@@ -7,6 +7,26 @@ const (
 	// PausedReconciliationAnnotation is an annotation that can be applied to
 	// Tenant Control Plane objects to prevent the controller from processing such a resource.
 	PausedReconciliationAnnotation = "kamaji.clastix.io/paused"
+	// LiveMigrationAnnotation is an annotation that can be applied to Tenant Control Plane objects
+	// to perform DataStore migrations without freezing the tenant for the whole data copy:
+	// changes are replicated to the target DataStore, and writes are blocked only for the cutover.
+	LiveMigrationAnnotation = "kamaji.clastix.io/live-migration"
+	// MigrationCutoverAnnotation is set by the migration job once the replication lag is zero,
+	// requesting to freeze the Tenant Control Plane: the value is the UID of the migration job.
+	MigrationCutoverAnnotation = "kamaji.clastix.io/migration-cutover"
+	// DataStorePlacementAnnotation records the reason why the DataStore has been automatically
+	// chosen for the Tenant Control Plane by the placement policy.
+	DataStorePlacementAnnotation = "kamaji.clastix.io/datastore-placement"
+	// CloneAnnotation is set on the Tenant Control Plane objects created by a TenantControlPlaneClone,
+	// referencing it by name in the same namespace.
+	CloneAnnotation = "kamaji.clastix.io/clone"
+
+	// TenantControlPlaneConditionStorageQuotaExceededType reports whether the storage quota has been exceeded:
+	// when true, creation and update operations are blocked.
+	TenantControlPlaneConditionStorageQuotaExceededType = "kamaji.clastix.io/StorageQuotaExceeded"
+	// TenantControlPlaneConditionFrozenType reports whether the freeze webhook, blocking the changes during a DataStore migration,
+	// is enforced by the Tenant Control Plane: the live migration job drains the remaining changes only once it's true.
+	TenantControlPlaneConditionFrozenType = "kamaji.clastix.io/Frozen"
 
 	// DefaultKubernetesVersion is the default Kubernetes version used in e2e tests.
 	DefaultKubernetesVersion = "v1.35.7"
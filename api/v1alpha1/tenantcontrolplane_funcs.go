@@ -8,9 +8,13 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
@@ -118,3 +122,126 @@ func (in *TenantControlPlane) GetDefaultDatastoreUsername() string {
 func (in *TenantControlPlane) GetDefaultDatastoreSchema() string {
 	return string(in.UID)
 }
+
+// EffectiveDataStoreSchema returns the schema in use, falling back to the declared one, or to the default one.
+func (in *TenantControlPlane) EffectiveDataStoreSchema() string {
+	switch {
+	case len(in.Status.Storage.Setup.Schema) > 0:
+		return in.Status.Storage.Setup.Schema
+	case len(in.Spec.DataStoreSchema) > 0:
+		return in.Spec.DataStoreSchema
+	default:
+		return in.GetDefaultDatastoreSchema()
+	}
+}
+
+// EffectiveDataStoreUsername returns the username in use, falling back to the declared one, or to the default one.
+func (in *TenantControlPlane) EffectiveDataStoreUsername() string {
+	switch {
+	case len(in.Status.Storage.Setup.User) > 0:
+		return in.Status.Storage.Setup.User
+	case len(in.Spec.DataStoreUsername) > 0:
+		return in.Spec.DataStoreUsername
+	default:
+		return in.GetDefaultDatastoreUsername()
+	}
+}
+
+// UsedDataStores returns the names of the DataStore objects storing the Tenant Control Plane data:
+// the current one, the target of a migration, and the ones of the overrides.
+func (in *TenantControlPlane) UsedDataStores() []string {
+	names := sets.New[string](in.Status.Storage.DataStoreName, in.Spec.DataStore)
+
+	for _, override := range in.Spec.DataStoreOverrides {
+		names.Insert(override.DataStore)
+	}
+
+	names.Delete("")
+
+	return sets.List(names)
+}
+
+// EffectiveWritePermissions returns the write permissions enforced on the Tenant Control Plane:
+// along with the declared ones, creation and update operations are blocked when the storage quota is exceeded.
+func (in *TenantControlPlane) EffectiveWritePermissions() Permissions {
+	permissions := in.Spec.WritePermissions
+
+	if meta.IsStatusConditionTrue(in.Status.Conditions, TenantControlPlaneConditionStorageQuotaExceededType) {
+		permissions.BlockCreate, permissions.BlockUpdate = true, true
+	}
+
+	return permissions
+}
+
+// KeyAlgorithm returns the algorithm of the generated keys, or an empty string when not declared.
+func (in *TenantControlPlane) KeyAlgorithm() string {
+	if in.Spec.PKI == nil {
+		return ""
+	}
+
+	return string(in.Spec.PKI.KeyAlgorithm)
+}
+
+// ExternalCertificateAuthority returns the external Certificate Authority the tenant one is chained to, if any.
+func (in *TenantControlPlane) ExternalCertificateAuthority() *ExternalCertificateAuthority {
+	if in.Spec.PKI == nil {
+		return nil
+	}
+
+	return in.Spec.PKI.CertificateAuthority
+}
+
+// CertificateAuthorityRotationSoakPeriod returns the minimum duration of each phase of the Certificate Authority rotation,
+// and false when it's not Graceful.
+func (in *TenantControlPlane) CertificateAuthorityRotationSoakPeriod() (time.Duration, bool) {
+	if in.Spec.PKI == nil || in.Spec.PKI.CertificateAuthorityRotation == nil ||
+		in.Spec.PKI.CertificateAuthorityRotation.Strategy != CertificateAuthorityRotationGraceful {
+		return 0, false
+	}
+
+	return in.Spec.PKI.CertificateAuthorityRotation.SoakPeriod.Duration, true
+}
+
+// ServiceAccountKeyRotation returns the rotation interval of the Service Account signing key, zero when it's rotated only upon request,
+// along with the retention period of the replaced keys, and false when they're not retained.
+func (in *TenantControlPlane) ServiceAccountKeyRotation() (time.Duration, time.Duration, bool) {
+	if in.Spec.PKI == nil || in.Spec.PKI.ServiceAccountKeyRotation == nil {
+		return 0, 0, false
+	}
+
+	var interval time.Duration
+	if in.Spec.PKI.ServiceAccountKeyRotation.Interval != nil {
+		interval = in.Spec.PKI.ServiceAccountKeyRotation.Interval.Duration
+	}
+
+	return interval, in.Spec.PKI.ServiceAccountKeyRotation.RetentionPeriod.Duration, true
+}
+
+// EarliestCertificateExpiry returns the earliest expiration among the certificates, the DataStore client certificate,
+// and the client certificates of the kubeconfigs.
+func (in *TenantControlPlane) EarliestCertificateExpiry() *metav1.Time {
+	var earliest *metav1.Time
+
+	inventories := []CertificateInventory{
+		in.Status.Certificates.CA.CertificateInventory,
+		in.Status.Certificates.APIServer.CertificateInventory,
+		in.Status.Certificates.APIServerKubeletClient.CertificateInventory,
+		in.Status.Certificates.FrontProxyCA.CertificateInventory,
+		in.Status.Certificates.FrontProxyClient.CertificateInventory,
+		in.Status.KubeConfig.Admin.CertificateInventory,
+		in.Status.KubeConfig.ControllerManager.CertificateInventory,
+		in.Status.KubeConfig.Scheduler.CertificateInventory,
+	}
+
+	if in.Status.Certificates.ETCD != nil {
+		inventories = append(inventories, in.Status.Certificates.ETCD.APIServer.CertificateInventory)
+	}
+
+	for _, inventory := range inventories {
+		if inventory.NotAfter != nil && (earliest == nil || inventory.NotAfter.Before(earliest)) {
+			earliest = inventory.NotAfter.DeepCopy()
+		}
+	}
+
+	return earliest
+}
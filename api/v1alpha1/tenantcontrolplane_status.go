@@ -16,6 +16,8 @@ type APIServerCertificatesStatus struct {
 	SecretName string      `json:"secretName,omitempty"`
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 	Checksum   string      `json:"checksum,omitempty"`
+	// CertificateInventory refers to the client certificate used to connect to the DataStore.
+	CertificateInventory `json:",inline"`
 }
 
 // ETCDCertificateStatus defines the observed state of ETCD Certificate for API server.
@@ -31,11 +33,24 @@ type ETCDCertificatesStatus struct {
 	CA        ETCDCertificateStatus       `json:"ca,omitempty"`
 }
 
+// CertificateInventory exposes the validity of a certificate, along with the time it's scheduled to be renewed at.
+type CertificateInventory struct {
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+	NotAfter  *metav1.Time `json:"notAfter,omitempty"`
+	Issuer    string       `json:"issuer,omitempty"`
+	// SANs are the DNS names and the IP addresses of the Subject Alternative Names extension.
+	SANs []string `json:"sans,omitempty"`
+	// NextRenewal is the time the certificate is renewed at, once it's within the expiration threshold:
+	// it's unset when the certificate is not checked by the certificate lifecycle controller.
+	NextRenewal *metav1.Time `json:"nextRenewal,omitempty"`
+}
+
 // CertificatePrivateKeyPairStatus defines the status.
 type CertificatePrivateKeyPairStatus struct {
-	SecretName string      `json:"secretName,omitempty"`
-	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
-	Checksum   string      `json:"checksum,omitempty"`
+	SecretName           string      `json:"secretName,omitempty"`
+	LastUpdate           metav1.Time `json:"lastUpdate,omitempty"`
+	Checksum             string      `json:"checksum,omitempty"`
+	CertificateInventory `json:",inline"`
 }
 
 // PublicKeyPrivateKeyPairStatus defines the status.
@@ -45,6 +60,23 @@ type PublicKeyPrivateKeyPairStatus struct {
 	Checksum   string      `json:"checksum,omitempty"`
 }
 
+// ServiceAccountKeysStatus defines the status of the Service Account signing key, and of the keys verifying the tokens.
+type ServiceAccountKeysStatus struct {
+	PublicKeyPrivateKeyPairStatus `json:",inline"`
+	// LastRotation is the time the signing key has been rotated at, either on schedule or upon request.
+	LastRotation *metav1.Time `json:"lastRotation,omitempty"`
+	// VerificationKeys are the public keys trusted by the API Server to verify the tokens, as published in the JWKS document:
+	// the current signing key, followed by the replaced ones until their retention period elapsed.
+	VerificationKeys []ServiceAccountVerificationKeyStatus `json:"verificationKeys,omitempty"`
+}
+
+type ServiceAccountVerificationKeyStatus struct {
+	// KeyID is the identifier of the key in the JWKS document, and in the header of the tokens it signed.
+	KeyID string `json:"keyID"`
+	// RetiredAt is the time the key has been replaced as the signing one, unset for the current signing key.
+	RetiredAt *metav1.Time `json:"retiredAt,omitempty"`
+}
+
 // CertificatesStatus defines the observed state of ETCD TLSConfig.
 type CertificatesStatus struct {
 	CA                     CertificatePrivateKeyPairStatus `json:"ca,omitempty"`
@@ -52,8 +84,33 @@ type CertificatesStatus struct {
 	APIServerKubeletClient CertificatePrivateKeyPairStatus `json:"apiServerKubeletClient,omitempty"`
 	FrontProxyCA           CertificatePrivateKeyPairStatus `json:"frontProxyCA,omitempty"`
 	FrontProxyClient       CertificatePrivateKeyPairStatus `json:"frontProxyClient,omitempty"`
-	SA                     PublicKeyPrivateKeyPairStatus   `json:"sa,omitempty"`
+	SA                     ServiceAccountKeysStatus        `json:"sa,omitempty"`
 	ETCD                   *ETCDCertificatesStatus         `json:"etcd,omitempty"`
+	// CARotation reports the progress of the Graceful rotation of the Certificate Authority.
+	CARotation *CertificateAuthorityRotationStatus `json:"caRotation,omitempty"`
+	// EarliestExpiry is the earliest expiration among the certificates, the DataStore client certificate,
+	// and the client certificates of the kubeconfigs.
+	EarliestExpiry *metav1.Time `json:"earliestExpiry,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=TrustBundlePublished;CertificatesReissued;Completed
+type CertificateAuthorityRotationPhase string
+
+const (
+	// CARotationPhaseTrustBundlePublished is the phase where the trust bundle contains both the current and the new Certificate Authority,
+	// the certificates being still issued by the current one.
+	CARotationPhaseTrustBundlePublished CertificateAuthorityRotationPhase = "TrustBundlePublished"
+	// CARotationPhaseCertificatesReissued is the phase where the certificates are issued by the new Certificate Authority,
+	// the trust bundle still containing the previous one.
+	CARotationPhaseCertificatesReissued CertificateAuthorityRotationPhase = "CertificatesReissued"
+	// CARotationPhaseCompleted is the phase where the previous Certificate Authority has been dropped from the trust bundle.
+	CARotationPhaseCompleted CertificateAuthorityRotationPhase = "Completed"
+)
+
+type CertificateAuthorityRotationStatus struct {
+	Phase CertificateAuthorityRotationPhase `json:"phase"`
+	// LastTransitionTime is the time the rotation entered the current phase.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 }
 
 type DataStoreCertificateStatus struct {
@@ -65,6 +122,8 @@ type DataStoreCertificateStatus struct {
 type DataStoreConfigStatus struct {
 	SecretName string `json:"secretName,omitempty"`
 	Checksum   string `json:"checksum,omitempty"`
+	// LastCredentialsRotation is the last time the DataStore credentials have been rotated.
+	LastCredentialsRotation *metav1.Time `json:"lastCredentialsRotation,omitempty"`
 }
 
 type DataStoreSetupStatus struct {
@@ -72,6 +131,26 @@ type DataStoreSetupStatus struct {
 	User       string      `json:"user,omitempty"`
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 	Checksum   string      `json:"checksum,omitempty"`
+	// PreviousPasswordRetained is true when the password replaced by the last rotation is still accepted by the DataStore,
+	// until the Tenant Control Plane has been rolled out with the new one.
+	PreviousPasswordRetained bool `json:"previousPasswordRetained,omitempty"`
+	// LastCredentialsRotation is the last rotation of the DataStore credentials applied to the DataStore user.
+	LastCredentialsRotation *metav1.Time `json:"lastCredentialsRotation,omitempty"`
+	// Limits are the ones enforced on the DataStore user.
+	Limits *DataStoreLimits `json:"limits,omitempty"`
+}
+
+// DataStoreUsageStatus reports the storage consumed by the Tenant Control Plane on its DataStore.
+type DataStoreUsageStatus struct {
+	// Bytes is the size of the tenant data, including the indexes for SQL-based drivers.
+	Bytes int64 `json:"bytes,omitempty"`
+	// Keys is the number of keys for etcd and NATS, or the number of kine rows for SQL-based drivers,
+	// including the revisions history not yet compacted.
+	Keys int64 `json:"keys,omitempty"`
+	// LiveBytes is the size of the keys and values at their latest revision, excluding the deleted ones,
+	// and the revisions history: the storage quota is enforced on it, since it drops as soon as keys are deleted.
+	LiveBytes  int64       `json:"liveBytes,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 // StorageStatus defines the observed state of StorageStatus.
@@ -81,6 +160,8 @@ type StorageStatus struct {
 	Config        DataStoreConfigStatus      `json:"config,omitempty"`
 	Setup         DataStoreSetupStatus       `json:"setup,omitempty"`
 	Certificate   DataStoreCertificateStatus `json:"certificate,omitempty"`
+	// Usage reports the storage consumed on the DataStore, periodically refreshed by Kamaji.
+	Usage DataStoreUsageStatus `json:"usage,omitempty"`
 }
 
 // KubeconfigStatus contains information about the generated kubeconfig.
@@ -88,6 +169,8 @@ type KubeconfigStatus struct {
 	SecretName string      `json:"secretName,omitempty"`
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 	Checksum   string      `json:"checksum,omitempty"`
+	// CertificateInventory refers to the client certificate of the kubeconfig.
+	CertificateInventory `json:",inline"`
 }
 
 // KubeconfigsStatus stores information about all the generated kubeconfig resources.
@@ -182,6 +265,10 @@ type TenantControlPlaneStatus struct {
 	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
 	// Addons contains the status of the different Addons
 	Addons AddonsStatus `json:"addons,omitempty"`
+	// Conditions contains the conditions of the Tenant Control Plane, such as the storage quota enforcement.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // KubernetesStatus defines the status of the resources deployed in the management cluster,
@@ -505,22 +505,42 @@ type TenantControlPlaneSpec struct {
 	// this phase can be used to prevent Datastore quota exhaustion or for your own business logic
 	// (e.g.: blocking creation and update, but allowing deletion to "clean up" space).
 	WritePermissions Permissions `json:"writePermissions,omitempty"`
+	// StorageQuota defines the storage thresholds enforced on the Tenant Control Plane according to its DataStore usage:
+	// once the hard threshold is reached, creation and update operations are automatically blocked,
+	// and lifted when the usage drops below the soft threshold.
+	// When unset, the default storage quota of the DataStore, if any, is used.
+	StorageQuota *StorageQuota `json:"storageQuota,omitempty"`
+	// DataStoreLimits defines the limits enforced on the DataStore user of the Tenant Control Plane,
+	// such as its maximum number of connections, preventing it from exhausting the resources of a shared SQL DataStore.
+	// When unset, the default limits of the DataStore, if any, are used.
+	DataStoreLimits *DataStoreLimits `json:"dataStoreLimits,omitempty"`
+	// DataStoreCredentialsRotationInterval is the interval after which the DataStore credentials of the Tenant Control Plane
+	// are rotated, such as 720h for 30 days.
+	// When unset, the credentials rotation interval of the DataStore, if any, is used.
+	// The credentials can be rotated on demand as well, with the storage.kamaji.clastix.io/rotate annotation.
+	DataStoreCredentialsRotationInterval *metav1.Duration `json:"dataStoreCredentialsRotationInterval,omitempty"`
 	// DataStore specifies the DataStore that should be used to store the Kubernetes data for the given Tenant Control Plane.
 	// When Kamaji runs with the default DataStore flag, all empty values will inherit the default value.
 	// By leaving it empty and running Kamaji with no default DataStore flag, it is possible to achieve automatic assignment to a specific DataStore object.
 	//
 	// Migration from one DataStore to another backed by the same Driver is possible. See: https://kamaji.clastix.io/guides/datastore-migration/
-	// Migration from one DataStore to another backed by a different Driver is not supported.
+	// Migration from one DataStore to another backed by a different Driver is performed with a driver-neutral copy of the keyspace.
 	DataStore string `json:"dataStore,omitempty"`
+	// DataStorePool specifies the DataStorePool used to automatically place the Tenant Control Plane upon creation,
+	// when no DataStore has been specified: the chosen DataStore, along with the reason, is recorded in the
+	// kamaji.clastix.io/datastore-placement annotation.
+	// When no DataStorePool is specified, the Tenant Control Plane is not placed automatically, and it keeps waiting
+	// for a DataStore, unless Kamaji runs with the default DataStore flag.
+	DataStorePool string `json:"dataStorePool,omitempty"`
 	// DataStoreSchema allows to specify the name of the database (for relational DataStores) or the key prefix (for etcd). This
-	// value is optional and immutable. Note that Kamaji currently doesn't ensure that DataStoreSchema values are unique. It's up
-	// to the user to avoid clashes between different TenantControlPlanes. If not set upon creation, Kamaji will default the
+	// value is optional and immutable. Kamaji rejects the TenantControlPlanes, and the migrations, clashing with the DataStoreSchema
+	// of another TenantControlPlane on the same DataStore. If not set upon creation, Kamaji will default the
 	// DataStoreSchema by concatenating the namespace and name of the TenantControlPlane.
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="changing the dataStoreSchema is not supported"
 	DataStoreSchema string `json:"dataStoreSchema,omitempty"`
 	// DataStoreUsername allows to specify the username of the database (for relational DataStores). This
-	// value is optional and immutable. Note that Kamaji currently doesn't ensure that DataStoreUsername values are unique. It's up
-	// to the user to avoid clashes between different TenantControlPlanes. If not set upon creation, Kamaji will default the
+	// value is optional and immutable. Kamaji rejects the TenantControlPlanes, and the migrations, clashing with the DataStoreUsername
+	// of another TenantControlPlane on the same DataStore. If not set upon creation, Kamaji will default the
 	// DataStoreUsername by concatenating the namespace and name of the TenantControlPlane.
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="changing the dataStoreUsername is not supported"
 	DataStoreUsername string `json:"dataStoreUsername,omitempty"`
@@ -533,8 +553,92 @@ type TenantControlPlaneSpec struct {
 	NetworkProfile NetworkProfileSpec `json:"networkProfile,omitempty"`
 	// Addons contain which addons are enabled
 	Addons AddonsSpec `json:"addons,omitempty"`
+	// PKI defines the Public Key Infrastructure of the Tenant Control Plane.
+	// This value is optional.
+	PKI *PKISpec `json:"pki,omitempty"`
 }
 
+// PKISpec defines how the keys of the Certificate Authorities, of the certificates, and of the Service Account, are generated.
+type PKISpec struct {
+	// KeyAlgorithm is the algorithm of the generated keys, passed to kubeadm as the encryption algorithm:
+	// changing it rotates the Certificate Authorities, and thus all the certificates, along with the Service Account keys.
+	// When unset, RSA-2048 keys are generated, and the existing keys are retained whatever their algorithm.
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+	// CertificateAuthority references the external Certificate Authority the tenant one is chained to,
+	// rather than generating a self-signed root: the chain is appended to the CA bundles handed to the clients.
+	// Changing it rotates the Certificate Authority.
+	CertificateAuthority *ExternalCertificateAuthority `json:"certificateAuthority,omitempty"`
+	// CertificateAuthorityRotation defines how the generated Certificate Authority is rotated,
+	// such as upon its expiration, a change of the key algorithm, or when requested with the rotation annotation.
+	CertificateAuthorityRotation *CertificateAuthorityRotationSpec `json:"certificateAuthorityRotation,omitempty"`
+	// ServiceAccountKeyRotation defines how the Service Account signing key is rotated:
+	// when unset, the key is rotated only when requested with the rotation annotation, invalidating the issued tokens at once.
+	ServiceAccountKeyRotation *ServiceAccountKeyRotationSpec `json:"serviceAccountKeyRotation,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Immediate;Graceful
+type CertificateAuthorityRotationStrategy string
+
+const (
+	// CertificateAuthorityRotationImmediate replaces the Certificate Authority at once,
+	// invalidating the trust of the nodes and of the kubeconfig files until they're distributed the new one.
+	CertificateAuthorityRotationImmediate CertificateAuthorityRotationStrategy = "Immediate"
+	// CertificateAuthorityRotationGraceful publishes a trust bundle containing both the current and the new Certificate Authority,
+	// re-issues the certificates with the new one, and finally drops the previous one.
+	CertificateAuthorityRotationGraceful CertificateAuthorityRotationStrategy = "Graceful"
+)
+
+type CertificateAuthorityRotationSpec struct {
+	//+kubebuilder:default=Immediate
+	Strategy CertificateAuthorityRotationStrategy `json:"strategy,omitempty"`
+	// SoakPeriod is the minimum duration of the TrustBundlePublished and CertificatesReissued phases of the Graceful rotation,
+	// giving time to distribute the trust bundle to the nodes: a phase can be ended earlier by confirming it,
+	// annotating the Certificate Authority Secret with certs.kamaji.clastix.io/confirm-rotation-phase set to the phase name.
+	//+kubebuilder:default="24h"
+	SoakPeriod metav1.Duration `json:"soakPeriod,omitempty"`
+}
+
+type ServiceAccountKeyRotationSpec struct {
+	// Interval is the duration after which the Service Account signing key is rotated, starting from its last rotation:
+	// when unset, the key is rotated only when requested with the certs.kamaji.clastix.io/rotate annotation on its Secret.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// RetentionPeriod is how long the public keys of the replaced signing keys are still trusted to verify the tokens,
+	// and published in the JWKS document: it must be longer than the expiration of the tokens issued by the API Server.
+	//+kubebuilder:default="48h"
+	RetentionPeriod metav1.Duration `json:"retentionPeriod,omitempty"`
+}
+
+// ExternalCertificateAuthority is either a Secret holding an intermediate Certificate Authority, or a cert-manager issuer signing it.
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) != has(self.issuerRef)",message="exactly one of secretRef or issuerRef must be set"
+type ExternalCertificateAuthority struct {
+	// SecretRef is the Secret, in the Tenant Control Plane namespace, holding the intermediate Certificate Authority:
+	// the tls.crt key contains its certificate followed by the chain up to the root, the tls.key one its private key.
+	// The Secret is owned by the user, who's in charge of renewing it.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// IssuerRef is the cert-manager Issuer, or ClusterIssuer, signing the generated tenant Certificate Authority as an intermediate:
+	// the certificate is requested with a CertificateRequest, and renewed before its expiration.
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+}
+
+type CertManagerIssuerReference struct {
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	//+kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	//+kubebuilder:default=Issuer
+	Kind string `json:"kind,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=RSA-2048;RSA-3072;RSA-4096;ECDSA-P256;ECDSA-P384
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmRSA2048   KeyAlgorithm = "RSA-2048"
+	KeyAlgorithmRSA3072   KeyAlgorithm = "RSA-3072"
+	KeyAlgorithmRSA4096   KeyAlgorithm = "RSA-4096"
+	KeyAlgorithmECDSAP256 KeyAlgorithm = "ECDSA-P256"
+	KeyAlgorithmECDSAP384 KeyAlgorithm = "ECDSA-P384"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:subresource:scale:specpath=.spec.controlPlane.deployment.replicas,statuspath=.status.kubernetesResources.deployment.replicas,selectorpath=.status.kubernetesResources.deployment.selector
@@ -545,6 +649,8 @@ type TenantControlPlaneSpec struct {
 //+kubebuilder:printcolumn:name="Control-Plane endpoint",type="string",JSONPath=".status.controlPlaneEndpoint",description="Tenant Control Plane Endpoint (API server)"
 //+kubebuilder:printcolumn:name="Kubeconfig",type="string",JSONPath=".status.kubeconfig.admin.secretName",description="Secret which contains admin kubeconfig"
 //+kubebuilder:printcolumn:name="Datastore",type="string",JSONPath=".status.storage.dataStoreName",description="DataStore actually used"
+//+kubebuilder:printcolumn:name="Certificates Expiry",type="string",JSONPath=".status.certificates.earliestExpiry",description="Earliest expiration among the certificates and the kubeconfigs"
+//+kubebuilder:printcolumn:name="CA Expiry",type="string",JSONPath=".status.certificates.ca.notAfter",description="Expiration of the Certificate Authority",priority=1
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 //+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
 
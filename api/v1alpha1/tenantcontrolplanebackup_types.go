@@ -0,0 +1,130 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TenantControlPlaneBackupConditionReadyType reports whether the backup is correctly scheduled,
+	// and the outcome of the last run.
+	TenantControlPlaneBackupConditionReadyType = "Ready"
+)
+
+// BackupTarget defines where the backup archives are stored.
+// Options are mutually exclusive, just one should be picked up.
+// +kubebuilder:validation:XValidation:rule="has(self.persistentVolumeClaim) != has(self.s3)",message="Either persistentVolumeClaim or s3 must be set, but not both."
+type BackupTarget struct {
+	PersistentVolumeClaim *PersistentVolumeClaimBackupTarget `json:"persistentVolumeClaim,omitempty"`
+	S3                    *S3BackupTarget                    `json:"s3,omitempty"`
+}
+
+// PersistentVolumeClaimBackupTarget stores the backup archives in a PersistentVolumeClaim.
+type PersistentVolumeClaimBackupTarget struct {
+	// ClaimName is the name of the PersistentVolumeClaim: backup and restore Jobs are running in the Kamaji namespace,
+	// as the migration ones, thus the claim must be available there, and allow the namespace of the referencing object
+	// with the backup.kamaji.clastix.io/allowed-namespaces annotation, a comma-separated list of namespaces.
+	// The archives of each namespace are stored in a directory named after it.
+	//+kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+	// SubPath is the path within the volume where the archives are stored, default to the volume root.
+	//+kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.matches('(^|/)[.][.](/|$)')",message="subPath must be a relative path, not escaping the volume"
+	SubPath string `json:"subPath,omitempty"`
+}
+
+// S3BackupTarget stores the backup archives in an S3-compatible object storage.
+type S3BackupTarget struct {
+	// Endpoint is the URL of the S3-compatible API, such as https://s3.eu-west-1.amazonaws.com,
+	// or http://minio.minio-system.svc:9000: buckets are addressed using the path-style.
+	//+kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+	// Region used to sign the requests.
+	//+kubebuilder:default="us-east-1"
+	Region string `json:"region,omitempty"`
+	//+kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the object keys of the archives.
+	Prefix string `json:"prefix,omitempty"`
+	// CredentialsSecret is the Secret, in the same namespace, containing the access keys
+	// with the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret"`
+}
+
+// BackupRetention defines how many archives are kept in the target.
+type BackupRetention struct {
+	// KeepLast is the number of the most recent archives to keep: older ones are deleted upon a successful backup.
+	//+kubebuilder:default=7
+	//+kubebuilder:validation:Minimum=1
+	KeepLast int32 `json:"keepLast,omitempty"`
+}
+
+// TenantControlPlaneBackupSpec defines the desired state of TenantControlPlaneBackup.
+type TenantControlPlaneBackupSpec struct {
+	// TenantControlPlane is the name of the Tenant Control Plane to back up, in the same namespace.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="tenantControlPlane is immutable"
+	TenantControlPlane string `json:"tenantControlPlane"`
+	// Target defines where the backup archives are stored.
+	Target BackupTarget `json:"target"`
+	// Schedule in Cron format, such as "0 */6 * * *", to take periodic backups:
+	// when empty, a single backup is taken.
+	Schedule string `json:"schedule,omitempty"`
+	//+kubebuilder:default={keepLast:7}
+	Retention BackupRetention `json:"retention,omitempty"`
+}
+
+// BackupArchive is a backup stored in the target.
+type BackupArchive struct {
+	// Name is the path of the archive, relative to the target.
+	Name              string      `json:"name"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+}
+
+// TenantControlPlaneBackupStatus defines the observed state of TenantControlPlaneBackup.
+type TenantControlPlaneBackupStatus struct {
+	// ObservedGeneration represents the .metadata.generation that was last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Archives is the list of the available archives, from the oldest to the most recent one, according to the retention.
+	Archives []BackupArchive `json:"archives,omitempty"`
+	// ActiveJob is the name of the Job taking the backup, if any.
+	ActiveJob string `json:"activeJob,omitempty"`
+	// LastScheduleTime is the last time a backup has been started.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is the last time a backup has been successfully completed.
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=tcpbackup,categories=kamaji
+//+kubebuilder:printcolumn:name="Tenant Control Plane",type="string",JSONPath=".spec.tenantControlPlane",description="Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule",description="Schedule"
+//+kubebuilder:printcolumn:name="Last Success",type="date",JSONPath=".status.lastSuccessfulTime",description="Last successful backup"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+//+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
+
+// TenantControlPlaneBackup is the Schema for the tenantcontrolplanebackups API: it takes logical backups
+// of a Tenant Control Plane, made of its DataStore keyspace and PKI, once or on a schedule.
+type TenantControlPlaneBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantControlPlaneBackupSpec   `json:"spec,omitempty"`
+	Status TenantControlPlaneBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TenantControlPlaneBackupList contains a list of TenantControlPlaneBackup.
+type TenantControlPlaneBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantControlPlaneBackup `json:"items"`
+}
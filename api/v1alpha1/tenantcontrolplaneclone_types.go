@@ -0,0 +1,111 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Pending;Provisioning;Copying;Completed;Failed
+type ClonePhase string
+
+var (
+	// ClonePhasePending is set when the source Tenant Control Plane is not yet available to be cloned.
+	ClonePhasePending ClonePhase = "Pending"
+	// ClonePhaseProvisioning is set when the clone Tenant Control Plane has been created, and its DataStore is being set up.
+	ClonePhaseProvisioning ClonePhase = "Provisioning"
+	// ClonePhaseCopying is set when the clone Job is copying the source keyspace.
+	ClonePhaseCopying ClonePhase = "Copying"
+	// ClonePhaseCompleted is set when the keyspace has been copied, and the clone has been scaled up.
+	ClonePhaseCompleted ClonePhase = "Completed"
+	// ClonePhaseFailed is set when the clone Job failed, or the clone would collide with the source.
+	ClonePhaseFailed ClonePhase = "Failed"
+)
+
+// +kubebuilder:validation:Enum=Reuse;Regenerate
+type ClonePKIPolicy string
+
+var (
+	// ClonePKIReuse copies the source Secrets to the clone.
+	ClonePKIReuse ClonePKIPolicy = "Reuse"
+	// ClonePKIRegenerate lets Kamaji generate new Secrets for the clone.
+	ClonePKIRegenerate ClonePKIPolicy = "Regenerate"
+)
+
+// ClonePKISpec defines which parts of the source PKI are reused by the clone.
+type ClonePKISpec struct {
+	// CertificateAuthorities refers to the cluster and front-proxy certificate authorities:
+	// reusing them, the clone trusts the client certificates issued for the source, such as the admin kubeconfig.
+	//+kubebuilder:default=Regenerate
+	CertificateAuthorities ClonePKIPolicy `json:"certificateAuthorities,omitempty"`
+	// ServiceAccountKeys refers to the key pair signing the Service Account tokens:
+	// reusing it, the tokens issued by the source are accepted by the clone.
+	//+kubebuilder:default=Regenerate
+	ServiceAccountKeys ClonePKIPolicy `json:"serviceAccountKeys,omitempty"`
+}
+
+// TenantControlPlaneCloneSpec defines the desired state of TenantControlPlaneClone.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+// +kubebuilder:validation:XValidation:rule="self.source != self.tenantControlPlane",message="the clone must have a different name than the source"
+type TenantControlPlaneCloneSpec struct {
+	// Source is the name of the Tenant Control Plane to clone, in the same namespace.
+	//+kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+	// TenantControlPlane is the name of the clone Tenant Control Plane, created by Kamaji in the same namespace:
+	// its specification is copied from the source one, except for the DataStore, and the API Server endpoint:
+	// the address is assigned from scratch, and the Ingress or Gateway hostname is the one specified.
+	// The DataStore schema and username are defaulted, and never collide with the source ones.
+	//+kubebuilder:validation:MinLength=1
+	TenantControlPlane string `json:"tenantControlPlane"`
+	// DataStore is the name of the DataStore storing the clone keyspace, default to the source one.
+	DataStore string `json:"dataStore,omitempty"`
+	// Hostname exposing the clone API Server through an Ingress, or a Gateway:
+	// it's required when the source is exposed this way, since its hostname cannot be shared.
+	Hostname string `json:"hostname,omitempty"`
+	// Replicas is the number of API Server replicas of the clone, once the keyspace has been copied:
+	// default to the source ones.
+	//+kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+	//+kubebuilder:default={}
+	PKI ClonePKISpec `json:"pki,omitempty"`
+}
+
+// TenantControlPlaneCloneStatus defines the observed state of TenantControlPlaneClone.
+type TenantControlPlaneCloneStatus struct {
+	Phase ClonePhase `json:"phase,omitempty"`
+	// Message explains the current phase.
+	Message string `json:"message,omitempty"`
+	// Replicas is the number of API Server replicas the clone is scaled to, once the keyspace has been copied.
+	Replicas       *int32       `json:"replicas,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=tcpclone,categories=kamaji
+//+kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source",description="Source Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Tenant Control Plane",type="string",JSONPath=".spec.tenantControlPlane",description="Clone Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+//+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
+
+// TenantControlPlaneClone is the Schema for the tenantcontrolplaneclones API: it creates a Tenant Control Plane
+// as a copy of an existing one, including its DataStore keyspace, without freezing the source.
+type TenantControlPlaneClone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantControlPlaneCloneSpec   `json:"spec,omitempty"`
+	Status TenantControlPlaneCloneStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TenantControlPlaneCloneList contains a list of TenantControlPlaneClone.
+type TenantControlPlaneCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantControlPlaneClone `json:"items"`
+}
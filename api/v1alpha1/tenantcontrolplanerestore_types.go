@@ -0,0 +1,86 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+type RestorePhase string
+
+var (
+	// RestorePhasePending is set when the restore is waiting for the Tenant Control Plane to be ready to be restored.
+	RestorePhasePending RestorePhase = "Pending"
+	// RestorePhaseRunning is set when the restore Job has been started.
+	RestorePhaseRunning RestorePhase = "Running"
+	// RestorePhaseCompleted is set when the archive has been restored successfully.
+	RestorePhaseCompleted RestorePhase = "Completed"
+	// RestorePhaseFailed is set when the restore Job failed, or the source archive cannot be resolved.
+	RestorePhaseFailed RestorePhase = "Failed"
+)
+
+// RestoreSource defines the archive to restore: it's either taken from a TenantControlPlaneBackup,
+// or from a target, such as when the original TenantControlPlaneBackup object is no longer available.
+// +kubebuilder:validation:XValidation:rule="has(self.backup) != has(self.target)",message="Either backup or target must be set, but not both."
+// +kubebuilder:validation:XValidation:rule="has(self.backup) || has(self.archive)",message="archive is required when restoring from a target."
+type RestoreSource struct {
+	// Backup is the name of the TenantControlPlaneBackup, in the same namespace, providing the archive.
+	Backup string `json:"backup,omitempty"`
+	// Target where the archive is stored.
+	Target *BackupTarget `json:"target,omitempty"`
+	// Archive is the path of the archive, relative to the target:
+	// when restoring from a TenantControlPlaneBackup, the most recent archive is used if empty.
+	Archive string `json:"archive,omitempty"`
+}
+
+// TenantControlPlaneRestoreSpec defines the desired state of TenantControlPlaneRestore.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type TenantControlPlaneRestoreSpec struct {
+	// TenantControlPlane is the name of the Tenant Control Plane to restore, in the same namespace:
+	// it can be the backed up one, or a different one.
+	// The Tenant Control Plane must exist and be scaled to zero replicas: the restore is started once it's Sleeping.
+	//+kubebuilder:validation:MinLength=1
+	TenantControlPlane string        `json:"tenantControlPlane"`
+	Source             RestoreSource `json:"source"`
+}
+
+// TenantControlPlaneRestoreStatus defines the observed state of TenantControlPlaneRestore.
+type TenantControlPlaneRestoreStatus struct {
+	Phase RestorePhase `json:"phase,omitempty"`
+	// Message explains the current phase.
+	Message string `json:"message,omitempty"`
+	// Archive is the path of the restored archive, relative to the target.
+	Archive        string       `json:"archive,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=tcprestore,categories=kamaji
+//+kubebuilder:printcolumn:name="Tenant Control Plane",type="string",JSONPath=".spec.tenantControlPlane",description="Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Archive",type="string",JSONPath=".status.archive",description="Restored archive"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+//+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
+
+// TenantControlPlaneRestore is the Schema for the tenantcontrolplanerestores API: it restores the DataStore keyspace
+// and the PKI of a Tenant Control Plane from an archive taken by a TenantControlPlaneBackup.
+type TenantControlPlaneRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantControlPlaneRestoreSpec   `json:"spec,omitempty"`
+	Status TenantControlPlaneRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TenantControlPlaneRestoreList contains a list of TenantControlPlaneRestore.
+type TenantControlPlaneRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantControlPlaneRestore `json:"items"`
+}
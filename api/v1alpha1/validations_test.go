@@ -173,5 +173,46 @@ var _ = Describe("Datastores validation test", func() {
 			err := k8sClient.Create(context.Background(), ds)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("datastores of type PostgreSQL must have the parameters honoured by Kamaji and kine", func() {
+			ds = &DataStore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "bad-pg-parameters",
+				},
+				Spec: DataStoreSpec{
+					Driver:    "PostgreSQL",
+					Endpoints: []string{"pg-server:5432"},
+					BasicAuth: &BasicAuth{
+						Username: ContentRef{Content: []byte("postgres")},
+						Password: ContentRef{Content: []byte("postgres")},
+					},
+					Parameters: map[string]string{"connect_timeout": "5", "pool_max_conns": "10"},
+				},
+			}
+
+			err := k8sClient.Create(context.Background(), ds)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the PostgreSQL parameters supported by both Kamaji and kine are connect_timeout and application_name"))
+		})
+
+		It("datastores must not disable the certificate verification", func() {
+			ds = &DataStore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "bad-pg-verification",
+				},
+				Spec: DataStoreSpec{
+					Driver:    "PostgreSQL",
+					Endpoints: []string{"pg-server:5432"},
+					TLSConfig: &TLSConfig{
+						CertificateAuthority: CertKeyPair{Certificate: ContentRef{Content: []byte("ca")}},
+						InsecureSkipVerify:   true,
+					},
+				},
+			}
+
+			err := k8sClient.Create(context.Background(), ds)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the data store certificate is always verified, insecureSkipVerify cannot be enabled"))
+		})
 	})
 })
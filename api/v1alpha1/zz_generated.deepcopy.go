@@ -19,6 +19,7 @@ import (
 func (in *APIServerCertificatesStatus) DeepCopyInto(out *APIServerCertificatesStatus) {
 	*out = *in
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.CertificateInventory.DeepCopyInto(&out.CertificateInventory)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerCertificatesStatus.
@@ -216,6 +217,62 @@ func (in AdmissionControllers) DeepCopy() AdmissionControllers {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupArchive) DeepCopyInto(out *BackupArchive) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupArchive.
+func (in *BackupArchive) DeepCopy() *BackupArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetention) DeepCopyInto(out *BackupRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetention.
+func (in *BackupRetention) DeepCopy() *BackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTarget) DeepCopyInto(out *BackupTarget) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimBackupTarget)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupTarget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTarget.
+func (in *BackupTarget) DeepCopy() *BackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
@@ -254,10 +311,90 @@ func (in *CertKeyPair) DeepCopy() *CertKeyPair {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthorityRotationSpec) DeepCopyInto(out *CertificateAuthorityRotationSpec) {
+	*out = *in
+	out.SoakPeriod = in.SoakPeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthorityRotationSpec.
+func (in *CertificateAuthorityRotationSpec) DeepCopy() *CertificateAuthorityRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthorityRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthorityRotationStatus) DeepCopyInto(out *CertificateAuthorityRotationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthorityRotationStatus.
+func (in *CertificateAuthorityRotationStatus) DeepCopy() *CertificateAuthorityRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthorityRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateInventory) DeepCopyInto(out *CertificateInventory) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.SANs != nil {
+		in, out := &in.SANs, &out.SANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextRenewal != nil {
+		in, out := &in.NextRenewal, &out.NextRenewal
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateInventory.
+func (in *CertificateInventory) DeepCopy() *CertificateInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificatePrivateKeyPairStatus) DeepCopyInto(out *CertificatePrivateKeyPairStatus) {
 	*out = *in
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.CertificateInventory.DeepCopyInto(&out.CertificateInventory)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePrivateKeyPairStatus.
@@ -284,6 +421,15 @@ func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
 		*out = new(ETCDCertificatesStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(CertificateAuthorityRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EarliestExpiry != nil {
+		in, out := &in.EarliestExpiry, &out.EarliestExpiry
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
@@ -313,6 +459,21 @@ func (in *ClientCertificate) DeepCopy() *ClientCertificate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClonePKISpec) DeepCopyInto(out *ClonePKISpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClonePKISpec.
+func (in *ClonePKISpec) DeepCopy() *ClonePKISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClonePKISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompoundValue) DeepCopyInto(out *CompoundValue) {
 	*out = *in
@@ -571,6 +732,10 @@ func (in *DataStoreCertificateStatus) DeepCopy() *DataStoreCertificateStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataStoreConfigStatus) DeepCopyInto(out *DataStoreConfigStatus) {
 	*out = *in
+	if in.LastCredentialsRotation != nil {
+		in, out := &in.LastCredentialsRotation, &out.LastCredentialsRotation
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreConfigStatus.
@@ -584,171 +749,519 @@ func (in *DataStoreConfigStatus) DeepCopy() *DataStoreConfigStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreList) DeepCopyInto(out *DataStoreList) {
+func (in *DataStoreDrain) DeepCopyInto(out *DataStoreDrain) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DataStore, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreList.
-func (in *DataStoreList) DeepCopy() *DataStoreList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreDrain.
+func (in *DataStoreDrain) DeepCopy() *DataStoreDrain {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreList)
+	out := new(DataStoreDrain)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataStoreList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreDrainTenantStatus) DeepCopyInto(out *DataStoreDrainTenantStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	return nil
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreDrainTenantStatus.
+func (in *DataStoreDrainTenantStatus) DeepCopy() *DataStoreDrainTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreDrainTenantStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreOverride) DeepCopyInto(out *DataStoreOverride) {
+func (in *DataStoreEndpointStatus) DeepCopyInto(out *DataStoreEndpointStatus) {
 	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreOverride.
-func (in *DataStoreOverride) DeepCopy() *DataStoreOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreEndpointStatus.
+func (in *DataStoreEndpointStatus) DeepCopy() *DataStoreEndpointStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreOverride)
+	out := new(DataStoreEndpointStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreSetupStatus) DeepCopyInto(out *DataStoreSetupStatus) {
+func (in *DataStoreInventoryItem) DeepCopyInto(out *DataStoreInventoryItem) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSetupStatus.
-func (in *DataStoreSetupStatus) DeepCopy() *DataStoreSetupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreInventoryItem.
+func (in *DataStoreInventoryItem) DeepCopy() *DataStoreInventoryItem {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreSetupStatus)
+	out := new(DataStoreInventoryItem)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreSpec) DeepCopyInto(out *DataStoreSpec) {
+func (in *DataStoreKine) DeepCopyInto(out *DataStoreKine) {
 	*out = *in
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
-		*out = make(Endpoints, len(*in))
-		copy(*out, *in)
+	if in.CompactInterval != nil {
+		in, out := &in.CompactInterval, &out.CompactInterval
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.BasicAuth != nil {
-		in, out := &in.BasicAuth, &out.BasicAuth
-		*out = new(BasicAuth)
-		(*in).DeepCopyInto(*out)
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.TLSConfig != nil {
-		in, out := &in.TLSConfig, &out.TLSConfig
-		*out = new(TLSConfig)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSpec.
-func (in *DataStoreSpec) DeepCopy() *DataStoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreKine.
+func (in *DataStoreKine) DeepCopy() *DataStoreKine {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreSpec)
+	out := new(DataStoreKine)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreStatus) DeepCopyInto(out *DataStoreStatus) {
+func (in *DataStoreLimits) DeepCopyInto(out *DataStoreLimits) {
 	*out = *in
-	if in.UsedBy != nil {
-		in, out := &in.UsedBy, &out.UsedBy
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int32)
+		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.StatementTimeout != nil {
+		in, out := &in.StatementTimeout, &out.StatementTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxQueriesPerHour != nil {
+		in, out := &in.MaxQueriesPerHour, &out.MaxQueriesPerHour
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreLimits.
+func (in *DataStoreLimits) DeepCopy() *DataStoreLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreList) DeepCopyInto(out *DataStoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataStore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreStatus.
-func (in *DataStoreStatus) DeepCopy() *DataStoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreList.
+func (in *DataStoreList) DeepCopy() *DataStoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreStatus)
+	out := new(DataStoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatastoreUsedSecret) DeepCopyInto(out *DatastoreUsedSecret) {
+func (in *DataStoreOrphanStatus) DeepCopyInto(out *DataStoreOrphanStatus) {
 	*out = *in
+	in.DetectionTime.DeepCopyInto(&out.DetectionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreUsedSecret.
-func (in *DatastoreUsedSecret) DeepCopy() *DatastoreUsedSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreOrphanStatus.
+func (in *DataStoreOrphanStatus) DeepCopy() *DataStoreOrphanStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DatastoreUsedSecret)
+	out := new(DataStoreOrphanStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
+func (in *DataStoreOrphans) DeepCopyInto(out *DataStoreOrphans) {
 	*out = *in
-	out.RegistrySettings = in.RegistrySettings
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Strategy.DeepCopyInto(&out.Strategy)
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreOrphans.
+func (in *DataStoreOrphans) DeepCopy() *DataStoreOrphans {
+	if in == nil {
+		return nil
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
+	out := new(DataStoreOrphans)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreOverride) DeepCopyInto(out *DataStoreOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreOverride.
+func (in *DataStoreOverride) DeepCopy() *DataStoreOverride {
+	if in == nil {
+		return nil
 	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+	out := new(DataStoreOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStorePool) DeepCopyInto(out *DataStorePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStorePool.
+func (in *DataStorePool) DeepCopy() *DataStorePool {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStorePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataStorePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStorePoolList) DeepCopyInto(out *DataStorePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataStorePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStorePoolList.
+func (in *DataStorePoolList) DeepCopy() *DataStorePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStorePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataStorePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStorePoolSpec) DeepCopyInto(out *DataStorePoolSpec) {
+	*out = *in
+	in.DataStoreSelector.DeepCopyInto(&out.DataStoreSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStorePoolSpec.
+func (in *DataStorePoolSpec) DeepCopy() *DataStorePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStorePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSetupStatus) DeepCopyInto(out *DataStoreSetupStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.LastCredentialsRotation != nil {
+		in, out := &in.LastCredentialsRotation, &out.LastCredentialsRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(DataStoreLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSetupStatus.
+func (in *DataStoreSetupStatus) DeepCopy() *DataStoreSetupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSetupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSpec) DeepCopyInto(out *DataStoreSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(Endpoints, len(*in))
+		copy(*out, *in)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NATSAccount != nil {
+		in, out := &in.NATSAccount, &out.NATSAccount
+		*out = new(NATSAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTenants != nil {
+		in, out := &in.MaxTenants, &out.MaxTenants
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultStorageQuota != nil {
+		in, out := &in.DefaultStorageQuota, &out.DefaultStorageQuota
+		*out = new(StorageQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultLimits != nil {
+		in, out := &in.DefaultLimits, &out.DefaultLimits
+		*out = new(DataStoreLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRotationInterval != nil {
+		in, out := &in.CredentialsRotationInterval, &out.CredentialsRotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(ManagedDataStore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DataStoreDrain)
+		**out = **in
+	}
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = new(DataStoreOrphans)
+		**out = **in
+	}
+	if in.PostgreSQL != nil {
+		in, out := &in.PostgreSQL, &out.PostgreSQL
+		*out = new(PostgreSQLDataStore)
+		**out = **in
+	}
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = new(DataStoreKine)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSpec.
+func (in *DataStoreSpec) DeepCopy() *DataStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreStatus) DeepCopyInto(out *DataStoreStatus) {
+	*out = *in
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(ManagedDataStoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]DataStoreEndpointStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = make([]DataStoreDrainTenantStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]DataStoreInventoryItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = make([]DataStoreOrphanStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreStatus.
+func (in *DataStoreStatus) DeepCopy() *DataStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreUsageStatus) DeepCopyInto(out *DataStoreUsageStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreUsageStatus.
+func (in *DataStoreUsageStatus) DeepCopy() *DataStoreUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreUsedSecret) DeepCopyInto(out *DatastoreUsedSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreUsedSecret.
+func (in *DatastoreUsedSecret) DeepCopy() *DatastoreUsedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(DatastoreUsedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
+	*out = *in
+	out.RegistrySettings = in.RegistrySettings
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
 		*out = make([]corev1.TopologySpreadConstraint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
@@ -877,24 +1390,49 @@ func (in Endpoints) DeepCopy() Endpoints {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalKubernetesObjectStatus) DeepCopyInto(out *ExternalKubernetesObjectStatus) {
+func (in *ExternalCertificateAuthority) DeepCopyInto(out *ExternalCertificateAuthority) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKubernetesObjectStatus.
-func (in *ExternalKubernetesObjectStatus) DeepCopy() *ExternalKubernetesObjectStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCertificateAuthority.
+func (in *ExternalCertificateAuthority) DeepCopy() *ExternalCertificateAuthority {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalKubernetesObjectStatus)
+	out := new(ExternalCertificateAuthority)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ExtraArgs) DeepCopyInto(out *ExtraArgs) {
-	{
+func (in *ExternalKubernetesObjectStatus) DeepCopyInto(out *ExternalKubernetesObjectStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKubernetesObjectStatus.
+func (in *ExternalKubernetesObjectStatus) DeepCopy() *ExternalKubernetesObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalKubernetesObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ExtraArgs) DeepCopyInto(out *ExtraArgs) {
+	{
 		in := &in
 		*out = make(ExtraArgs, len(*in))
 		copy(*out, *in)
@@ -926,877 +1464,1446 @@ func (in *GatewayAccessPoint) DeepCopyInto(out *GatewayAccessPoint) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAccessPoint.
-func (in *GatewayAccessPoint) DeepCopy() *GatewayAccessPoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAccessPoint.
+func (in *GatewayAccessPoint) DeepCopy() *GatewayAccessPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAccessPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayListener) DeepCopyInto(out *GatewayListener) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayListener.
+func (in *GatewayListener) DeepCopy() *GatewayListener {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
+	*out = *in
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	if in.GatewayParentRefs != nil {
+		in, out := &in.GatewayParentRefs, &out.GatewayParentRefs
+		*out = make([]apisv1.ParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
+func (in *GatewaySpec) DeepCopy() *GatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
+func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverrideTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONPatch) DeepCopyInto(out *JSONPatch) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatch.
+func (in *JSONPatch) DeepCopy() *JSONPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in JSONPatches) DeepCopyInto(out *JSONPatches) {
+	{
+		in := &in
+		*out = make(JSONPatches, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatches.
+func (in JSONPatches) DeepCopy() JSONPatches {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPatches)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityAgentSpec) DeepCopyInto(out *KonnectivityAgentSpec) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentSpec.
+func (in *KonnectivityAgentSpec) DeepCopy() *KonnectivityAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityAgentStatus) DeepCopyInto(out *KonnectivityAgentStatus) {
+	*out = *in
+	in.ExternalKubernetesObjectStatus.DeepCopyInto(&out.ExternalKubernetesObjectStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentStatus.
+func (in *KonnectivityAgentStatus) DeepCopy() *KonnectivityAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityConfigMap) DeepCopyInto(out *KonnectivityConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityConfigMap.
+func (in *KonnectivityConfigMap) DeepCopy() *KonnectivityConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServerSpec) DeepCopyInto(out *KonnectivityServerSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerSpec.
+func (in *KonnectivityServerSpec) DeepCopy() *KonnectivityServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivitySpec) DeepCopyInto(out *KonnectivitySpec) {
+	*out = *in
+	in.KonnectivityServerSpec.DeepCopyInto(&out.KonnectivityServerSpec)
+	in.KonnectivityAgentSpec.DeepCopyInto(&out.KonnectivityAgentSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivitySpec.
+func (in *KonnectivitySpec) DeepCopy() *KonnectivitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityStatus) DeepCopyInto(out *KonnectivityStatus) {
+	*out = *in
+	out.ConfigMap = in.ConfigMap
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.Kubeconfig.DeepCopyInto(&out.Kubeconfig)
+	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
+	in.ClusterRoleBinding.DeepCopyInto(&out.ClusterRoleBinding)
+	in.Agent.DeepCopyInto(&out.Agent)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(KubernetesGatewayStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityStatus.
+func (in *KonnectivityStatus) DeepCopy() *KonnectivityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmConfigStatus) DeepCopyInto(out *KubeadmConfigStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigStatus.
+func (in *KubeadmConfigStatus) DeepCopy() *KubeadmConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmPhaseStatus) DeepCopyInto(out *KubeadmPhaseStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhaseStatus.
+func (in *KubeadmPhaseStatus) DeepCopy() *KubeadmPhaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmPhaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmPhasesStatus) DeepCopyInto(out *KubeadmPhasesStatus) {
+	*out = *in
+	in.BootstrapToken.DeepCopyInto(&out.BootstrapToken)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhasesStatus.
+func (in *KubeadmPhasesStatus) DeepCopy() *KubeadmPhasesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmPhasesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigGenerator) DeepCopyInto(out *KubeconfigGenerator) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGenerator.
+func (in *KubeconfigGenerator) DeepCopy() *KubeconfigGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeconfigGenerator) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigGeneratorList) DeepCopyInto(out *KubeconfigGeneratorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubeconfigGenerator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorList.
+func (in *KubeconfigGeneratorList) DeepCopy() *KubeconfigGeneratorList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigGeneratorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeconfigGeneratorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigGeneratorSpec) DeepCopyInto(out *KubeconfigGeneratorSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.TenantControlPlaneSelector.DeepCopyInto(&out.TenantControlPlaneSelector)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]CompoundValue, len(*in))
+		copy(*out, *in)
+	}
+	out.User = in.User
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorSpec.
+func (in *KubeconfigGeneratorSpec) DeepCopy() *KubeconfigGeneratorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigGeneratorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigGeneratorStatus) DeepCopyInto(out *KubeconfigGeneratorStatus) {
+	*out = *in
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]KubeconfigGeneratorStatusError, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorStatus.
+func (in *KubeconfigGeneratorStatus) DeepCopy() *KubeconfigGeneratorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigGeneratorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigGeneratorStatusError) DeepCopyInto(out *KubeconfigGeneratorStatusError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorStatusError.
+func (in *KubeconfigGeneratorStatusError) DeepCopy() *KubeconfigGeneratorStatusError {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigGeneratorStatusError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigStatus) DeepCopyInto(out *KubeconfigStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.CertificateInventory.DeepCopyInto(&out.CertificateInventory)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigStatus.
+func (in *KubeconfigStatus) DeepCopy() *KubeconfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigsStatus) DeepCopyInto(out *KubeconfigsStatus) {
+	*out = *in
+	in.Admin.DeepCopyInto(&out.Admin)
+	in.ControllerManager.DeepCopyInto(&out.ControllerManager)
+	in.Scheduler.DeepCopyInto(&out.Scheduler)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigsStatus.
+func (in *KubeconfigsStatus) DeepCopy() *KubeconfigsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletSpec) DeepCopyInto(out *KubeletSpec) {
+	*out = *in
+	if in.ConfigurationJSONPatches != nil {
+		in, out := &in.ConfigurationJSONPatches, &out.ConfigurationJSONPatches
+		*out = make(JSONPatches, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreferredAddressTypes != nil {
+		in, out := &in.PreferredAddressTypes, &out.PreferredAddressTypes
+		*out = make([]KubeletPreferredAddressType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletSpec.
+func (in *KubeletSpec) DeepCopy() *KubeletSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesDeploymentStatus) DeepCopyInto(out *KubernetesDeploymentStatus) {
+	*out = *in
+	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeploymentStatus.
+func (in *KubernetesDeploymentStatus) DeepCopy() *KubernetesDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesGatewayStatus) DeepCopyInto(out *KubernetesGatewayStatus) {
+	*out = *in
+	in.RouteStatus.DeepCopyInto(&out.RouteStatus)
+	out.RouteRef = in.RouteRef
+	if in.AccessPoints != nil {
+		in, out := &in.AccessPoints, &out.AccessPoints
+		*out = make([]GatewayAccessPoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesGatewayStatus.
+func (in *KubernetesGatewayStatus) DeepCopy() *KubernetesGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesIngressStatus) DeepCopyInto(out *KubernetesIngressStatus) {
+	*out = *in
+	in.IngressStatus.DeepCopyInto(&out.IngressStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesIngressStatus.
+func (in *KubernetesIngressStatus) DeepCopy() *KubernetesIngressStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayAccessPoint)
+	out := new(KubernetesIngressStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayListener) DeepCopyInto(out *GatewayListener) {
+func (in *KubernetesServiceStatus) DeepCopyInto(out *KubernetesServiceStatus) {
 	*out = *in
+	in.ServiceStatus.DeepCopyInto(&out.ServiceStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayListener.
-func (in *GatewayListener) DeepCopy() *GatewayListener {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceStatus.
+func (in *KubernetesServiceStatus) DeepCopy() *KubernetesServiceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayListener)
+	out := new(KubernetesServiceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
+func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
-	if in.GatewayParentRefs != nil {
-		in, out := &in.GatewayParentRefs, &out.GatewayParentRefs
-		*out = make([]apisv1.ParentReference, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Kubelet.DeepCopyInto(&out.Kubelet)
+	if in.AdmissionControllers != nil {
+		in, out := &in.AdmissionControllers, &out.AdmissionControllers
+		*out = make(AdmissionControllers, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
-func (in *GatewaySpec) DeepCopy() *GatewaySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
+func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewaySpec)
+	out := new(KubernetesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
+func (in *KubernetesStatus) DeepCopyInto(out *KubernetesStatus) {
 	*out = *in
+	in.Version.DeepCopyInto(&out.Version)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(KubernetesIngressStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(KubernetesGatewayStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
-func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesStatus.
+func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageOverrideTrait)
+	out := new(KubernetesStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+func (in *KubernetesVersion) DeepCopyInto(out *KubernetesVersion) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(KubernetesVersionStatus)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
-func (in *IngressSpec) DeepCopy() *IngressSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersion.
+func (in *KubernetesVersion) DeepCopy() *KubernetesVersion {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressSpec)
+	out := new(KubernetesVersion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JSONPatch) DeepCopyInto(out *JSONPatch) {
+func (in *ManagedDataStore) DeepCopyInto(out *ManagedDataStore) {
 	*out = *in
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
+	in.Storage.DeepCopyInto(&out.Storage)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatch.
-func (in *JSONPatch) DeepCopy() *JSONPatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDataStore.
+func (in *ManagedDataStore) DeepCopy() *ManagedDataStore {
 	if in == nil {
 		return nil
 	}
-	out := new(JSONPatch)
+	out := new(ManagedDataStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in JSONPatches) DeepCopyInto(out *JSONPatches) {
-	{
-		in := &in
-		*out = make(JSONPatches, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+func (in *ManagedDataStoreStatus) DeepCopyInto(out *ManagedDataStoreStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatches.
-func (in JSONPatches) DeepCopy() JSONPatches {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDataStoreStatus.
+func (in *ManagedDataStoreStatus) DeepCopy() *ManagedDataStoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(JSONPatches)
+	out := new(ManagedDataStoreStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityAgentSpec) DeepCopyInto(out *KonnectivityAgentSpec) {
+func (in *ManagedDataStoreStorage) DeepCopyInto(out *ManagedDataStoreStorage) {
 	*out = *in
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = make(ExtraArgs, len(*in))
-		copy(*out, *in)
-	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentSpec.
-func (in *KonnectivityAgentSpec) DeepCopy() *KonnectivityAgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDataStoreStorage.
+func (in *ManagedDataStoreStorage) DeepCopy() *ManagedDataStoreStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityAgentSpec)
+	out := new(ManagedDataStoreStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityAgentStatus) DeepCopyInto(out *KonnectivityAgentStatus) {
+func (in *NATSAccount) DeepCopyInto(out *NATSAccount) {
 	*out = *in
-	in.ExternalKubernetesObjectStatus.DeepCopyInto(&out.ExternalKubernetesObjectStatus)
+	in.SigningKey.DeepCopyInto(&out.SigningKey)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentStatus.
-func (in *KonnectivityAgentStatus) DeepCopy() *KonnectivityAgentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSAccount.
+func (in *NATSAccount) DeepCopy() *NATSAccount {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityAgentStatus)
+	out := new(NATSAccount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityConfigMap) DeepCopyInto(out *KonnectivityConfigMap) {
+func (in *NetworkProfileSpec) DeepCopyInto(out *NetworkProfileSpec) {
 	*out = *in
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancerClass != nil {
+		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.CertSANs != nil {
+		in, out := &in.CertSANs, &out.CertSANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceCIDRs != nil {
+		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodCIDRs != nil {
+		in, out := &in.PodCIDRs, &out.PodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServiceIPs != nil {
+		in, out := &in.DNSServiceIPs, &out.DNSServiceIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityConfigMap.
-func (in *KonnectivityConfigMap) DeepCopy() *KonnectivityConfigMap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkProfileSpec.
+func (in *NetworkProfileSpec) DeepCopy() *NetworkProfileSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityConfigMap)
+	out := new(NetworkProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityServerSpec) DeepCopyInto(out *KonnectivityServerSpec) {
+func (in *PKISpec) DeepCopyInto(out *PKISpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
+	if in.CertificateAuthority != nil {
+		in, out := &in.CertificateAuthority, &out.CertificateAuthority
+		*out = new(ExternalCertificateAuthority)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = make(ExtraArgs, len(*in))
-		copy(*out, *in)
+	if in.CertificateAuthorityRotation != nil {
+		in, out := &in.CertificateAuthorityRotation, &out.CertificateAuthorityRotation
+		*out = new(CertificateAuthorityRotationSpec)
+		**out = **in
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(corev1.SecurityContext)
+	if in.ServiceAccountKeyRotation != nil {
+		in, out := &in.ServiceAccountKeyRotation, &out.ServiceAccountKeyRotation
+		*out = new(ServiceAccountKeyRotationSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerSpec.
-func (in *KonnectivityServerSpec) DeepCopy() *KonnectivityServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PKISpec.
+func (in *PKISpec) DeepCopy() *PKISpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityServerSpec)
+	out := new(PKISpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivitySpec) DeepCopyInto(out *KonnectivitySpec) {
+func (in *Permissions) DeepCopyInto(out *Permissions) {
 	*out = *in
-	in.KonnectivityServerSpec.DeepCopyInto(&out.KonnectivityServerSpec)
-	in.KonnectivityAgentSpec.DeepCopyInto(&out.KonnectivityAgentSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivitySpec.
-func (in *KonnectivitySpec) DeepCopy() *KonnectivitySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permissions.
+func (in *Permissions) DeepCopy() *Permissions {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivitySpec)
+	out := new(Permissions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityStatus) DeepCopyInto(out *KonnectivityStatus) {
+func (in *PersistentVolumeClaimBackupTarget) DeepCopyInto(out *PersistentVolumeClaimBackupTarget) {
 	*out = *in
-	out.ConfigMap = in.ConfigMap
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	in.Kubeconfig.DeepCopyInto(&out.Kubeconfig)
-	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
-	in.ClusterRoleBinding.DeepCopyInto(&out.ClusterRoleBinding)
-	in.Agent.DeepCopyInto(&out.Agent)
-	in.Service.DeepCopyInto(&out.Service)
-	if in.Gateway != nil {
-		in, out := &in.Gateway, &out.Gateway
-		*out = new(KubernetesGatewayStatus)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityStatus.
-func (in *KonnectivityStatus) DeepCopy() *KonnectivityStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimBackupTarget.
+func (in *PersistentVolumeClaimBackupTarget) DeepCopy() *PersistentVolumeClaimBackupTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityStatus)
+	out := new(PersistentVolumeClaimBackupTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmConfigStatus) DeepCopyInto(out *KubeadmConfigStatus) {
+func (in *PostgreSQLDataStore) DeepCopyInto(out *PostgreSQLDataStore) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigStatus.
-func (in *KubeadmConfigStatus) DeepCopy() *KubeadmConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLDataStore.
+func (in *PostgreSQLDataStore) DeepCopy() *PostgreSQLDataStore {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmConfigStatus)
+	out := new(PostgreSQLDataStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmPhaseStatus) DeepCopyInto(out *KubeadmPhaseStatus) {
+func (in *ProbeSet) DeepCopyInto(out *ProbeSet) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhaseStatus.
-func (in *KubeadmPhaseStatus) DeepCopy() *KubeadmPhaseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSet.
+func (in *ProbeSet) DeepCopy() *ProbeSet {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmPhaseStatus)
+	out := new(ProbeSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmPhasesStatus) DeepCopyInto(out *KubeadmPhasesStatus) {
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
 	*out = *in
-	in.BootstrapToken.DeepCopyInto(&out.BootstrapToken)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhasesStatus.
-func (in *KubeadmPhasesStatus) DeepCopy() *KubeadmPhasesStatus {
-	if in == nil {
-		return nil
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
 	}
-	out := new(KubeadmPhasesStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigGenerator) DeepCopyInto(out *KubeconfigGenerator) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGenerator.
-func (in *KubeconfigGenerator) DeepCopy() *KubeconfigGenerator {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigGenerator)
+	out := new(ProbeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeconfigGenerator) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigGeneratorList) DeepCopyInto(out *KubeconfigGeneratorList) {
+func (in *PublicKeyPrivateKeyPairStatus) DeepCopyInto(out *PublicKeyPrivateKeyPairStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KubeconfigGenerator, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorList.
-func (in *KubeconfigGeneratorList) DeepCopy() *KubeconfigGeneratorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyPrivateKeyPairStatus.
+func (in *PublicKeyPrivateKeyPairStatus) DeepCopy() *PublicKeyPrivateKeyPairStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigGeneratorList)
+	out := new(PublicKeyPrivateKeyPairStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeconfigGeneratorList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigGeneratorSpec) DeepCopyInto(out *KubeconfigGeneratorSpec) {
+func (in *RegistrySettings) DeepCopyInto(out *RegistrySettings) {
 	*out = *in
-	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
-	in.TenantControlPlaneSelector.DeepCopyInto(&out.TenantControlPlaneSelector)
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]CompoundValue, len(*in))
-		copy(*out, *in)
-	}
-	out.User = in.User
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorSpec.
-func (in *KubeconfigGeneratorSpec) DeepCopy() *KubeconfigGeneratorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrySettings.
+func (in *RegistrySettings) DeepCopy() *RegistrySettings {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigGeneratorSpec)
+	out := new(RegistrySettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigGeneratorStatus) DeepCopyInto(out *KubeconfigGeneratorStatus) {
+func (in *RestoreSource) DeepCopyInto(out *RestoreSource) {
 	*out = *in
-	if in.Errors != nil {
-		in, out := &in.Errors, &out.Errors
-		*out = make([]KubeconfigGeneratorStatusError, len(*in))
-		copy(*out, *in)
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(BackupTarget)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorStatus.
-func (in *KubeconfigGeneratorStatus) DeepCopy() *KubeconfigGeneratorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSource.
+func (in *RestoreSource) DeepCopy() *RestoreSource {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigGeneratorStatus)
+	out := new(RestoreSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigGeneratorStatusError) DeepCopyInto(out *KubeconfigGeneratorStatusError) {
+func (in *S3BackupTarget) DeepCopyInto(out *S3BackupTarget) {
 	*out = *in
+	out.CredentialsSecret = in.CredentialsSecret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigGeneratorStatusError.
-func (in *KubeconfigGeneratorStatusError) DeepCopy() *KubeconfigGeneratorStatusError {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupTarget.
+func (in *S3BackupTarget) DeepCopy() *S3BackupTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigGeneratorStatusError)
+	out := new(S3BackupTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigStatus) DeepCopyInto(out *KubeconfigStatus) {
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	out.SecretReference = in.SecretReference
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigStatus.
-func (in *KubeconfigStatus) DeepCopy() *KubeconfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigStatus)
+	out := new(SecretReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigsStatus) DeepCopyInto(out *KubeconfigsStatus) {
+func (in *ServiceAccountKeyRotationSpec) DeepCopyInto(out *ServiceAccountKeyRotationSpec) {
 	*out = *in
-	in.Admin.DeepCopyInto(&out.Admin)
-	in.ControllerManager.DeepCopyInto(&out.ControllerManager)
-	in.Scheduler.DeepCopyInto(&out.Scheduler)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.RetentionPeriod = in.RetentionPeriod
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigsStatus.
-func (in *KubeconfigsStatus) DeepCopy() *KubeconfigsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountKeyRotationSpec.
+func (in *ServiceAccountKeyRotationSpec) DeepCopy() *ServiceAccountKeyRotationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigsStatus)
+	out := new(ServiceAccountKeyRotationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeletSpec) DeepCopyInto(out *KubeletSpec) {
+func (in *ServiceAccountKeysStatus) DeepCopyInto(out *ServiceAccountKeysStatus) {
 	*out = *in
-	if in.ConfigurationJSONPatches != nil {
-		in, out := &in.ConfigurationJSONPatches, &out.ConfigurationJSONPatches
-		*out = make(JSONPatches, len(*in))
+	in.PublicKeyPrivateKeyPairStatus.DeepCopyInto(&out.PublicKeyPrivateKeyPairStatus)
+	if in.LastRotation != nil {
+		in, out := &in.LastRotation, &out.LastRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.VerificationKeys != nil {
+		in, out := &in.VerificationKeys, &out.VerificationKeys
+		*out = make([]ServiceAccountVerificationKeyStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.PreferredAddressTypes != nil {
-		in, out := &in.PreferredAddressTypes, &out.PreferredAddressTypes
-		*out = make([]KubeletPreferredAddressType, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletSpec.
-func (in *KubeletSpec) DeepCopy() *KubeletSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountKeysStatus.
+func (in *ServiceAccountKeysStatus) DeepCopy() *ServiceAccountKeysStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeletSpec)
+	out := new(ServiceAccountKeysStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesDeploymentStatus) DeepCopyInto(out *KubernetesDeploymentStatus) {
+func (in *ServiceAccountVerificationKeyStatus) DeepCopyInto(out *ServiceAccountVerificationKeyStatus) {
 	*out = *in
-	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.RetiredAt != nil {
+		in, out := &in.RetiredAt, &out.RetiredAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeploymentStatus.
-func (in *KubernetesDeploymentStatus) DeepCopy() *KubernetesDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountVerificationKeyStatus.
+func (in *ServiceAccountVerificationKeyStatus) DeepCopy() *ServiceAccountVerificationKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesDeploymentStatus)
+	out := new(ServiceAccountVerificationKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesGatewayStatus) DeepCopyInto(out *KubernetesGatewayStatus) {
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
-	in.RouteStatus.DeepCopyInto(&out.RouteStatus)
-	out.RouteRef = in.RouteRef
-	if in.AccessPoints != nil {
-		in, out := &in.AccessPoints, &out.AccessPoints
-		*out = make([]GatewayAccessPoint, len(*in))
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	if in.AdditionalPorts != nil {
+		in, out := &in.AdditionalPorts, &out.AdditionalPorts
+		*out = make([]AdditionalPort, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesGatewayStatus.
-func (in *KubernetesGatewayStatus) DeepCopy() *KubernetesGatewayStatus {
-	if in == nil {
-		return nil
+	if in.AllocateLoadBalancerNodePorts != nil {
+		in, out := &in.AllocateLoadBalancerNodePorts, &out.AllocateLoadBalancerNodePorts
+		*out = new(bool)
+		**out = **in
 	}
-	out := new(KubernetesGatewayStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesIngressStatus) DeepCopyInto(out *KubernetesIngressStatus) {
-	*out = *in
-	in.IngressStatus.DeepCopyInto(&out.IngressStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesIngressStatus.
-func (in *KubernetesIngressStatus) DeepCopy() *KubernetesIngressStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
+func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesIngressStatus)
+	out := new(ServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesServiceStatus) DeepCopyInto(out *KubernetesServiceStatus) {
+func (in *StorageQuota) DeepCopyInto(out *StorageQuota) {
 	*out = *in
-	in.ServiceStatus.DeepCopyInto(&out.ServiceStatus)
+	out.Hard = in.Hard.DeepCopy()
+	if in.Soft != nil {
+		in, out := &in.Soft, &out.Soft
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceStatus.
-func (in *KubernetesServiceStatus) DeepCopy() *KubernetesServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageQuota.
+func (in *StorageQuota) DeepCopy() *StorageQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesServiceStatus)
+	out := new(StorageQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
+func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
 	*out = *in
-	in.Kubelet.DeepCopyInto(&out.Kubelet)
-	if in.AdmissionControllers != nil {
-		in, out := &in.AdmissionControllers, &out.AdmissionControllers
-		*out = make(AdmissionControllers, len(*in))
-		copy(*out, *in)
-	}
+	in.Config.DeepCopyInto(&out.Config)
+	in.Setup.DeepCopyInto(&out.Setup)
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.Usage.DeepCopyInto(&out.Usage)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
-func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
+func (in *StorageStatus) DeepCopy() *StorageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesSpec)
+	out := new(StorageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesStatus) DeepCopyInto(out *KubernetesStatus) {
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
-	in.Version.DeepCopyInto(&out.Version)
-	in.Deployment.DeepCopyInto(&out.Deployment)
-	in.Service.DeepCopyInto(&out.Service)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(KubernetesIngressStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Gateway != nil {
-		in, out := &in.Gateway, &out.Gateway
-		*out = new(KubernetesGatewayStatus)
+	in.CertificateAuthority.DeepCopyInto(&out.CertificateAuthority)
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(ClientCertificate)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesStatus.
-func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesStatus)
+	out := new(TLSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesVersion) DeepCopyInto(out *KubernetesVersion) {
+func (in *TenantControlPlane) DeepCopyInto(out *TenantControlPlane) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(KubernetesVersionStatus)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersion.
-func (in *KubernetesVersion) DeepCopy() *KubernetesVersion {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlane.
+func (in *TenantControlPlane) DeepCopy() *TenantControlPlane {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesVersion)
+	out := new(TenantControlPlane)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkProfileSpec) DeepCopyInto(out *NetworkProfileSpec) {
-	*out = *in
-	if in.LoadBalancerSourceRanges != nil {
-		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.LoadBalancerClass != nil {
-		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
-		*out = new(string)
-		**out = **in
-	}
-	if in.CertSANs != nil {
-		in, out := &in.CertSANs, &out.CertSANs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ServiceCIDRs != nil {
-		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.PodCIDRs != nil {
-		in, out := &in.PodCIDRs, &out.PodCIDRs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.DNSServiceIPs != nil {
-		in, out := &in.DNSServiceIPs, &out.DNSServiceIPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlane) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkProfileSpec.
-func (in *NetworkProfileSpec) DeepCopy() *NetworkProfileSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneBackup) DeepCopyInto(out *TenantControlPlaneBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneBackup.
+func (in *TenantControlPlaneBackup) DeepCopy() *TenantControlPlaneBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkProfileSpec)
+	out := new(TenantControlPlaneBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Permissions) DeepCopyInto(out *Permissions) {
+func (in *TenantControlPlaneBackupList) DeepCopyInto(out *TenantControlPlaneBackupList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantControlPlaneBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permissions.
-func (in *Permissions) DeepCopy() *Permissions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneBackupList.
+func (in *TenantControlPlaneBackupList) DeepCopy() *TenantControlPlaneBackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(Permissions)
+	out := new(TenantControlPlaneBackupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProbeSet) DeepCopyInto(out *ProbeSet) {
+func (in *TenantControlPlaneBackupSpec) DeepCopyInto(out *TenantControlPlaneBackupSpec) {
 	*out = *in
-	if in.Liveness != nil {
-		in, out := &in.Liveness, &out.Liveness
-		*out = new(ProbeSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Readiness != nil {
-		in, out := &in.Readiness, &out.Readiness
-		*out = new(ProbeSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Startup != nil {
-		in, out := &in.Startup, &out.Startup
-		*out = new(ProbeSpec)
-		(*in).DeepCopyInto(*out)
-	}
+	in.Target.DeepCopyInto(&out.Target)
+	out.Retention = in.Retention
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSet.
-func (in *ProbeSet) DeepCopy() *ProbeSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneBackupSpec.
+func (in *TenantControlPlaneBackupSpec) DeepCopy() *TenantControlPlaneBackupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProbeSet)
+	out := new(TenantControlPlaneBackupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
+func (in *TenantControlPlaneBackupStatus) DeepCopyInto(out *TenantControlPlaneBackupStatus) {
 	*out = *in
-	if in.InitialDelaySeconds != nil {
-		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
-		*out = new(int32)
-		**out = **in
-	}
-	if in.TimeoutSeconds != nil {
-		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
-		*out = new(int32)
-		**out = **in
+	if in.Archives != nil {
+		in, out := &in.Archives, &out.Archives
+		*out = make([]BackupArchive, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.PeriodSeconds != nil {
-		in, out := &in.PeriodSeconds, &out.PeriodSeconds
-		*out = new(int32)
-		**out = **in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
 	}
-	if in.SuccessThreshold != nil {
-		in, out := &in.SuccessThreshold, &out.SuccessThreshold
-		*out = new(int32)
-		**out = **in
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
 	}
-	if in.FailureThreshold != nil {
-		in, out := &in.FailureThreshold, &out.FailureThreshold
-		*out = new(int32)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
-func (in *ProbeSpec) DeepCopy() *ProbeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneBackupStatus.
+func (in *TenantControlPlaneBackupStatus) DeepCopy() *TenantControlPlaneBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProbeSpec)
+	out := new(TenantControlPlaneBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicKeyPrivateKeyPairStatus) DeepCopyInto(out *PublicKeyPrivateKeyPairStatus) {
+func (in *TenantControlPlaneClone) DeepCopyInto(out *TenantControlPlaneClone) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyPrivateKeyPairStatus.
-func (in *PublicKeyPrivateKeyPairStatus) DeepCopy() *PublicKeyPrivateKeyPairStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneClone.
+func (in *TenantControlPlaneClone) DeepCopy() *TenantControlPlaneClone {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicKeyPrivateKeyPairStatus)
+	out := new(TenantControlPlaneClone)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneClone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RegistrySettings) DeepCopyInto(out *RegistrySettings) {
+func (in *TenantControlPlaneCloneList) DeepCopyInto(out *TenantControlPlaneCloneList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantControlPlaneClone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrySettings.
-func (in *RegistrySettings) DeepCopy() *RegistrySettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneCloneList.
+func (in *TenantControlPlaneCloneList) DeepCopy() *TenantControlPlaneCloneList {
 	if in == nil {
 		return nil
 	}
-	out := new(RegistrySettings)
+	out := new(TenantControlPlaneCloneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneCloneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+func (in *TenantControlPlaneCloneSpec) DeepCopyInto(out *TenantControlPlaneCloneSpec) {
 	*out = *in
-	out.SecretReference = in.SecretReference
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	out.PKI = in.PKI
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
-func (in *SecretReference) DeepCopy() *SecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneCloneSpec.
+func (in *TenantControlPlaneCloneSpec) DeepCopy() *TenantControlPlaneCloneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretReference)
+	out := new(TenantControlPlaneCloneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *TenantControlPlaneCloneStatus) DeepCopyInto(out *TenantControlPlaneCloneStatus) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
-	if in.AdditionalPorts != nil {
-		in, out := &in.AdditionalPorts, &out.AdditionalPorts
-		*out = make([]AdditionalPort, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AllocateLoadBalancerNodePorts != nil {
-		in, out := &in.AllocateLoadBalancerNodePorts, &out.AllocateLoadBalancerNodePorts
-		*out = new(bool)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
 		**out = **in
 	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
-func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneCloneStatus.
+func (in *TenantControlPlaneCloneStatus) DeepCopy() *TenantControlPlaneCloneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpec)
+	out := new(TenantControlPlaneCloneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
+func (in *TenantControlPlaneDataStoreSchema) DeepCopyInto(out *TenantControlPlaneDataStoreSchema) {
 	*out = *in
-	out.Config = in.Config
-	in.Setup.DeepCopyInto(&out.Setup)
-	in.Certificate.DeepCopyInto(&out.Certificate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
-func (in *StorageStatus) DeepCopy() *StorageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneDataStoreSchema.
+func (in *TenantControlPlaneDataStoreSchema) DeepCopy() *TenantControlPlaneDataStoreSchema {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageStatus)
+	out := new(TenantControlPlaneDataStoreSchema)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+func (in *TenantControlPlaneDataStoreUsername) DeepCopyInto(out *TenantControlPlaneDataStoreUsername) {
 	*out = *in
-	in.CertificateAuthority.DeepCopyInto(&out.CertificateAuthority)
-	if in.ClientCertificate != nil {
-		in, out := &in.ClientCertificate, &out.ClientCertificate
-		*out = new(ClientCertificate)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneDataStoreUsername.
+func (in *TenantControlPlaneDataStoreUsername) DeepCopy() *TenantControlPlaneDataStoreUsername {
+	if in == nil {
+		return nil
 	}
+	out := new(TenantControlPlaneDataStoreUsername)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
-func (in *TLSConfig) DeepCopy() *TLSConfig {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneList) DeepCopyInto(out *TenantControlPlaneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantControlPlane, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneList.
+func (in *TenantControlPlaneList) DeepCopy() *TenantControlPlaneList {
 	if in == nil {
 		return nil
 	}
-	out := new(TLSConfig)
+	out := new(TenantControlPlaneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TenantControlPlane) DeepCopyInto(out *TenantControlPlane) {
+func (in *TenantControlPlaneRestore) DeepCopyInto(out *TenantControlPlaneRestore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1804,18 +2911,18 @@ func (in *TenantControlPlane) DeepCopyInto(out *TenantControlPlane) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlane.
-func (in *TenantControlPlane) DeepCopy() *TenantControlPlane {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneRestore.
+func (in *TenantControlPlaneRestore) DeepCopy() *TenantControlPlaneRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(TenantControlPlane)
+	out := new(TenantControlPlaneRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TenantControlPlane) DeepCopyObject() runtime.Object {
+func (in *TenantControlPlaneRestore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1823,41 +2930,95 @@ func (in *TenantControlPlane) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TenantControlPlaneList) DeepCopyInto(out *TenantControlPlaneList) {
+func (in *TenantControlPlaneRestoreList) DeepCopyInto(out *TenantControlPlaneRestoreList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TenantControlPlane, len(*in))
+		*out = make([]TenantControlPlaneRestore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneList.
-func (in *TenantControlPlaneList) DeepCopy() *TenantControlPlaneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneRestoreList.
+func (in *TenantControlPlaneRestoreList) DeepCopy() *TenantControlPlaneRestoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(TenantControlPlaneList)
+	out := new(TenantControlPlaneRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TenantControlPlaneList) DeepCopyObject() runtime.Object {
+func (in *TenantControlPlaneRestoreList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneRestoreSpec) DeepCopyInto(out *TenantControlPlaneRestoreSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneRestoreSpec.
+func (in *TenantControlPlaneRestoreSpec) DeepCopy() *TenantControlPlaneRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlaneRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneRestoreStatus) DeepCopyInto(out *TenantControlPlaneRestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneRestoreStatus.
+func (in *TenantControlPlaneRestoreStatus) DeepCopy() *TenantControlPlaneRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlaneRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantControlPlaneSpec) DeepCopyInto(out *TenantControlPlaneSpec) {
 	*out = *in
 	out.WritePermissions = in.WritePermissions
+	if in.StorageQuota != nil {
+		in, out := &in.StorageQuota, &out.StorageQuota
+		*out = new(StorageQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataStoreLimits != nil {
+		in, out := &in.DataStoreLimits, &out.DataStoreLimits
+		*out = new(DataStoreLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataStoreCredentialsRotationInterval != nil {
+		in, out := &in.DataStoreCredentialsRotationInterval, &out.DataStoreCredentialsRotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.DataStoreOverrides != nil {
 		in, out := &in.DataStoreOverrides, &out.DataStoreOverrides
 		*out = make([]DataStoreOverride, len(*in))
@@ -1867,6 +3028,11 @@ func (in *TenantControlPlaneSpec) DeepCopyInto(out *TenantControlPlaneSpec) {
 	in.Kubernetes.DeepCopyInto(&out.Kubernetes)
 	in.NetworkProfile.DeepCopyInto(&out.NetworkProfile)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.PKI != nil {
+		in, out := &in.PKI, &out.PKI
+		*out = new(PKISpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneSpec.
@@ -1889,6 +3055,13 @@ func (in *TenantControlPlaneStatus) DeepCopyInto(out *TenantControlPlaneStatus)
 	in.KubeadmConfig.DeepCopyInto(&out.KubeadmConfig)
 	in.KubeadmPhase.DeepCopyInto(&out.KubeadmPhase)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneStatus.
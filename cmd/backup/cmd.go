@@ -0,0 +1,170 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/backup"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		tenantControlPlane string
+		archive            string
+		keepLast           int
+		timeout            time.Duration
+		storageOptions     backup.StorageOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:          "backup",
+		Short:        "Back up the DataStore keyspace and the PKI of a TenantControlPlane",
+		SilenceUsage: true,
+		RunE: func(*cobra.Command, []string) error {
+			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+			defer cancelFn()
+
+			log := ctrl.Log
+
+			log.Info("generating the controller-runtime client")
+
+			client, err := ctrlclient.New(ctrl.GetConfigOrDie(), ctrlclient.Options{
+				Scheme: scheme,
+			})
+			if err != nil {
+				return err
+			}
+
+			parts := strings.Split(tenantControlPlane, string(types.Separator))
+			if len(parts) != 2 {
+				return fmt.Errorf("non well-formed namespaced name for the tenant control plane, expected <NAMESPACE>/NAME, got %s", tenantControlPlane)
+			}
+
+			log.Info("retrieving the TenantControlPlane")
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, tcp); err != nil {
+				return err
+			}
+
+			if tcp.Status.Storage.Setup.Schema == "" {
+				return fmt.Errorf("the TenantControlPlane storage has not been provisioned yet")
+			}
+
+			log.Info("retrieving the TenantControlPlane used DataStore")
+
+			ds := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(ctx, types.NamespacedName{Name: tcp.Status.Storage.DataStoreName}, ds); err != nil {
+				return err
+			}
+
+			storage, err := storageOptions.Storage(ctx, client)
+			if err != nil {
+				return err
+			}
+
+			log.Info("retrieving the TenantControlPlane PKI")
+
+			secrets := make(map[string]map[string][]byte, len(backup.PKISecrets))
+
+			for _, name := range backup.PKISecrets {
+				var secret corev1.Secret
+				if err = client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: utilities.AddTenantPrefix(name, tcp)}, &secret); err != nil {
+					return fmt.Errorf("unable to retrieve the %s PKI secret: %w", name, err)
+				}
+
+				secrets[name] = secret.Data
+			}
+
+			log.Info("generating the storage connection")
+
+			connection, err := datastore.NewStorageConnection(ctx, client, *ds)
+			if err != nil {
+				return err
+			}
+			defer connection.Close()
+			// The archive is written to a temporary file first, since object storages require its size upfront.
+			tmp, err := os.CreateTemp("", "kamaji-backup-*"+backup.ArchiveExtension)
+			if err != nil {
+				return err
+			}
+			defer os.Remove(tmp.Name())
+			defer tmp.Close()
+
+			log.Info("exporting the TenantControlPlane keyspace")
+
+			if err = backup.WriteArchive(tmp, backup.Archive{
+				Metadata: backup.Metadata{
+					TenantControlPlane: tenantControlPlane,
+					KubernetesVersion:  tcp.Status.Kubernetes.Version.Version,
+					Driver:             string(ds.Spec.Driver),
+					CreationTimestamp:  time.Now().UTC(),
+				},
+				Secrets: secrets,
+			}, func(fn func(kv datastore.KeyValue) error) error {
+				return connection.Export(ctx, *tcp, fn)
+			}); err != nil {
+				return fmt.Errorf("unable to write the archive: %w", err)
+			}
+
+			if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+
+			log.Info("storing the archive", "archive", archive)
+
+			if err = storage.Put(ctx, archive, tmp); err != nil {
+				return fmt.Errorf("unable to store the archive: %w", err)
+			}
+
+			if keepLast > 0 {
+				prefix, pErr := backup.ArchivePrefixFromName(archive)
+				if pErr != nil {
+					return pErr
+				}
+
+				pruned, pErr := backup.Prune(ctx, storage, prefix, keepLast)
+				if pErr != nil {
+					return fmt.Errorf("unable to apply the retention: %w", pErr)
+				}
+
+				for _, name := range pruned {
+					log.Info("archive pruned according to the retention", "archive", name)
+				}
+			}
+
+			log.Info("backup completed")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantControlPlane, "tenant-control-plane", "", "Namespaced-name of the TenantControlPlane that must be backed up (e.g.: default/test)")
+	cmd.Flags().StringVar(&archive, "archive", "", "Name of the archive, relative to the target (e.g.: default/test/nightly-20060102150405.tar.gz)")
+	cmd.Flags().IntVar(&keepLast, "keep-last", 0, "Number of the most recent archives sharing the same prefix to keep, older ones are deleted: zero disables the retention.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Amount of time for the context timeout")
+	storageOptions.AddFlags(cmd.Flags())
+
+	_ = cmd.MarkFlagRequired("tenant-control-plane")
+	_ = cmd.MarkFlagRequired("archive")
+
+	return cmd
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		source             string
+		tenantControlPlane string
+		timeout            time.Duration
+		pollInterval       time.Duration
+		lagThreshold       int
+		maxRounds          int
+	)
+
+	cmd := &cobra.Command{
+		Use:          "clone",
+		Short:        "Copy the data of a TenantControlPlane to its clone",
+		SilenceUsage: true,
+		RunE: func(*cobra.Command, []string) error {
+			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+			defer cancelFn()
+
+			log := ctrl.Log
+
+			log.Info("generating the controller-runtime client")
+
+			client, err := ctrlclient.New(ctrl.GetConfigOrDie(), ctrlclient.Options{
+				Scheme: scheme,
+			})
+			if err != nil {
+				return err
+			}
+
+			log.Info("retrieving the source TenantControlPlane")
+
+			sourceTCP, err := getTenantControlPlane(ctx, client, source)
+			if err != nil {
+				return err
+			}
+
+			log.Info("retrieving the clone TenantControlPlane")
+
+			cloneTCP, err := getTenantControlPlane(ctx, client, tenantControlPlane)
+			if err != nil {
+				return err
+			}
+
+			sourceSetup, cloneSetup := sourceTCP.Status.Storage.Setup, cloneTCP.Status.Storage.Setup
+			if sourceSetup.Schema == "" || cloneSetup.Schema == "" {
+				return fmt.Errorf("the TenantControlPlane storage has not been provisioned yet")
+			}
+			// Dropping the clone keyspace must never affect the source one.
+			if sourceSetup.Schema == cloneSetup.Schema || (sourceSetup.User != "" && sourceSetup.User == cloneSetup.User) {
+				return fmt.Errorf("the clone DataStore schema or username collides with the source ones")
+			}
+
+			log.Info("retrieving the source DataStore")
+
+			originDs := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(ctx, types.NamespacedName{Name: sourceTCP.Status.Storage.DataStoreName}, originDs); err != nil {
+				return err
+			}
+
+			log.Info("retrieving the clone DataStore")
+
+			targetDs := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(ctx, types.NamespacedName{Name: cloneTCP.Status.Storage.DataStoreName}, targetDs); err != nil {
+				return err
+			}
+
+			log.Info("generating the origin storage connection")
+
+			originConnection, err := datastore.NewStorageConnection(ctx, client, *originDs)
+			if err != nil {
+				return err
+			}
+			defer originConnection.Close()
+
+			log.Info("generating the target storage connection")
+
+			targetConnection, err := datastore.NewStorageConnection(ctx, client, *targetDs)
+			if err != nil {
+				return err
+			}
+			defer targetConnection.Close()
+			// Starting from an empty keyspace, since the Job could be retried after a partial copy.
+			log.Info("cleaning up the clone keyspace")
+
+			if exists, _ := targetConnection.DBExists(ctx, cloneSetup.Schema); exists {
+				if err = targetConnection.DeleteDB(ctx, cloneSetup.Schema); err != nil {
+					return fmt.Errorf("unable to clean up the clone keyspace: %w", err)
+				}
+			}
+
+			if err = targetConnection.CreateDB(ctx, cloneSetup.Schema); err != nil {
+				return fmt.Errorf("unable to create the clone keyspace: %w", err)
+			}
+
+			log.Info("copy from source to clone started")
+
+			if originDs.Spec.Driver != targetDs.Spec.Driver {
+				log.Info("source and clone DataStore have different drivers, performing a driver-neutral copy", "origin", originDs.Spec.Driver, "target", targetDs.Spec.Driver)
+
+				err = datastore.CloneKeyValues(ctx, *sourceTCP, *cloneTCP, originConnection, targetConnection)
+			} else {
+				err = datastore.LiveClone(ctx, *sourceTCP, *cloneTCP, originConnection, targetConnection, datastore.LiveMigrationOptions{
+					PollInterval: pollInterval,
+					LagThreshold: lagThreshold,
+					MaxRounds:    maxRounds,
+					Logger:       log,
+				})
+			}
+
+			if err != nil {
+				return fmt.Errorf("unable to copy data from %s to %s: %w", sourceTCP.GetName(), cloneTCP.GetName(), err)
+			}
+			// Dropping the keyspace could have removed the privileges of the clone user.
+			if cloneSetup.User != "" {
+				if exists, _ := targetConnection.GrantPrivilegesExists(ctx, cloneSetup.User, cloneSetup.Schema); !exists {
+					if err = targetConnection.GrantPrivileges(ctx, cloneSetup.User, cloneSetup.Schema); err != nil {
+						return fmt.Errorf("unable to grant privileges to the clone user: %w", err)
+					}
+				}
+			}
+
+			log.Info("copy completed")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&source, "source", "", "Namespaced-name of the TenantControlPlane that must be cloned (e.g.: default/test)")
+	cmd.Flags().StringVar(&tenantControlPlane, "tenant-control-plane", "", "Namespaced-name of the clone TenantControlPlane (e.g.: default/test-clone)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Amount of time for the context timeout")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", time.Second, "Amount of time between two change replication rounds, used when source and clone DataStore share the same driver.")
+	cmd.Flags().IntVar(&lagThreshold, "lag-threshold", 100, "Number of changes replicated by a round below which the clone is considered completed, since the source keeps writing: used when source and clone DataStore share the same driver.")
+	cmd.Flags().IntVar(&maxRounds, "max-replication-rounds", 300, "Number of replication rounds after which the clone is considered completed regardless of the lag, zero meaning no limit: used when source and clone DataStore share the same driver.")
+
+	_ = cmd.MarkFlagRequired("source")
+	_ = cmd.MarkFlagRequired("tenant-control-plane")
+
+	return cmd
+}
+
+func getTenantControlPlane(ctx context.Context, client ctrlclient.Client, namespacedName string) (*kamajiv1alpha1.TenantControlPlane, error) {
+	parts := strings.Split(namespacedName, string(types.Separator))
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("non well-formed namespaced name for the tenant control plane, expected <NAMESPACE>/NAME, got %s", namespacedName)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, tcp); err != nil {
+		return nil, err
+	}
+
+	return tcp, nil
+}
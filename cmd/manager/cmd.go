@@ -16,6 +16,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
@@ -33,6 +34,7 @@ import (
 	"github.com/clastix/kamaji/controllers/soot"
 	"github.com/clastix/kamaji/internal"
 	"github.com/clastix/kamaji/internal/builders/controlplane"
+	kamajidatastore "github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/metrics"
 	"github.com/clastix/kamaji/internal/utilities"
 	"github.com/clastix/kamaji/internal/webhook"
@@ -50,6 +52,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		leaderElect                   bool
 		tmpDirectory                  string
 		kineImage                     string
+		managedEtcdImage              string
 		controllerReconcileTimeout    time.Duration
 		cacheResyncPeriod             time.Duration
 		datastore                     string
@@ -58,9 +61,18 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		managerServiceName            string
 		webhookCABundle               []byte
 		migrateJobImage               string
+		backupJobImage                string
 		maxConcurrentReconciles       int
 		disableTelemetry              bool
 		certificateExpirationDeadline time.Duration
+		dataStoreUsageInterval        time.Duration
+		dataStoreOrphansInterval      time.Duration
+		dataStoreHealthInterval       time.Duration
+		dataStoreHealthTimeout        time.Duration
+		dataStoreMaxOpenConns         int
+		dataStoreMaxIdleConns         int
+		dataStoreConnIdleTimeout      time.Duration
+		cloneNodePortRange            = *utilnet.ParsePortRangeOrDie("30000-32767")
 
 		webhookCAPath string
 	)
@@ -75,7 +87,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			klog.SetOutput(io.Discard)
 			klog.LogToStderr(false)
 
-			if err = cmdutils.CheckFlags(cmd.Flags(), []string{"kine-image", "migrate-image", "tmp-directory", "pod-namespace", "webhook-service-name", "serviceaccount-name", "webhook-ca-path"}...); err != nil {
+			if err = cmdutils.CheckFlags(cmd.Flags(), []string{"kine-image", "migrate-image", "backup-image", "tmp-directory", "pod-namespace", "webhook-service-name", "serviceaccount-name", "webhook-ca-path"}...); err != nil {
 				return err
 			}
 
@@ -143,8 +155,22 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			// of dropping, which covers that window without a buffer size to pick.
 			tcpChannel, certChannel := make(chan event.GenericEvent), make(chan event.GenericEvent)
 			metricsRecorder := metrics.DefaultRecorder()
-
-			if err = (&controllers.DataStore{Client: mgr.GetClient(), Metrics: metricsRecorder, TenantControlPlaneTrigger: tcpChannel}).SetupWithManager(mgr); err != nil {
+			// The connections to the DataStore objects are shared across the controllers.
+			dataStoreConnections := kamajidatastore.NewConnectionManager(mgr.GetClient(), kamajidatastore.PoolOptions{
+				MaxOpenConnections: dataStoreMaxOpenConns,
+				MaxIdleConnections: dataStoreMaxIdleConns,
+				IdleTimeout:        dataStoreConnIdleTimeout,
+			}, metricsRecorder)
+
+			if err = (&controllers.DataStore{
+				Client:                    mgr.GetClient(),
+				APIReader:                 mgr.GetAPIReader(),
+				Metrics:                   metricsRecorder,
+				TenantControlPlaneTrigger: tcpChannel,
+				KamajiNamespace:           managerNamespace,
+				ManagedEtcdImage:          managedEtcdImage,
+				Connections:               dataStoreConnections,
+			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "DataStore")
 
 				return err
@@ -176,6 +202,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				KamajiMigrateImage:      migrateJobImage,
 				MaxConcurrentReconciles: maxConcurrentReconciles,
 				DiscoveryClient:         discoveryClient,
+				Connections:             dataStoreConnections,
 			}
 
 			if err = reconciler.SetupWithManager(ctx, mgr); err != nil {
@@ -184,6 +211,41 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&controllers.TenantControlPlaneBackupReconciler{
+				Client:               mgr.GetClient(),
+				KamajiNamespace:      managerNamespace,
+				KamajiServiceAccount: managerServiceAccountName,
+				BackupImage:          backupJobImage,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneBackup")
+
+				return err
+			}
+
+			if err = (&controllers.TenantControlPlaneRestoreReconciler{
+				Client:               mgr.GetClient(),
+				KamajiNamespace:      managerNamespace,
+				KamajiServiceAccount: managerServiceAccountName,
+				RestoreImage:         backupJobImage,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneRestore")
+
+				return err
+			}
+
+			if err = (&controllers.TenantControlPlaneCloneReconciler{
+				Client:               mgr.GetClient(),
+				APIReader:            mgr.GetAPIReader(),
+				KamajiNamespace:      managerNamespace,
+				KamajiServiceAccount: managerServiceAccountName,
+				CloneImage:           migrateJobImage,
+				NodePortRange:        cloneNodePortRange,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlaneClone")
+
+				return err
+			}
+
 			k8sVersion, versionErr := cmdutils.KubernetesVersion(mgr.GetConfig())
 			if versionErr != nil {
 				setupLog.Error(err, "unable to get kubernetes version")
@@ -207,6 +269,61 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				}
 			}
 
+			if dataStoreUsageInterval > 0 {
+				if err = mgr.Add(&controllers.DataStoreUsage{
+					Client:        mgr.GetClient(),
+					Metrics:       metricsRecorder,
+					EventRecorder: mgr.GetEventRecorder("datastore-usage"),
+					Connections:   dataStoreConnections,
+					Interval:      dataStoreUsageInterval,
+					Timeout:       controllerReconcileTimeout,
+				}); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "DataStoreUsage")
+
+					return err
+				}
+			}
+
+			if dataStoreOrphansInterval > 0 {
+				if err = mgr.Add(&controllers.DataStoreOrphans{
+					Client:        mgr.GetClient(),
+					Connections:   dataStoreConnections,
+					EventRecorder: mgr.GetEventRecorder("datastore-orphans"),
+					Interval:      dataStoreOrphansInterval,
+					Timeout:       controllerReconcileTimeout,
+				}); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "DataStoreOrphans")
+
+					return err
+				}
+			}
+
+			if dataStoreHealthInterval > 0 {
+				if err = mgr.Add(&controllers.DataStoreHealth{
+					Client:        mgr.GetClient(),
+					Metrics:       metricsRecorder,
+					EventRecorder: mgr.GetEventRecorder("datastore-health"),
+					Interval:      dataStoreHealthInterval,
+					Timeout:       dataStoreHealthTimeout,
+				}); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "DataStoreHealth")
+
+					return err
+				}
+			}
+
+			if err = (&controllers.DataStoreDrain{Client: mgr.GetClient(), KamajiNamespace: managerNamespace}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "DataStoreDrain")
+
+				return err
+			}
+
+			if err = (&controllers.DataStoreCredentialsRotation{Client: mgr.GetClient()}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "DataStoreCredentialsRotation")
+
+				return err
+			}
+
 			certController := &controllers.CertificateLifecycle{Channel: certChannel, Deadline: certificateExpirationDeadline, Metrics: metricsRecorder}
 			certController.EnqueueFn = certController.EnqueueForTenantControlPlane
 
@@ -228,6 +345,24 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&kamajiv1alpha1.TenantControlPlaneSpecDataStore{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneSpecDataStore")
+
+				return err
+			}
+
+			if err = (&kamajiv1alpha1.TenantControlPlaneDataStoreSchema{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneDataStoreSchema")
+
+				return err
+			}
+
+			if err = (&kamajiv1alpha1.TenantControlPlaneDataStoreUsername{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneDataStoreUsername")
+
+				return err
+			}
+
 			// Only requires to look for the core api group.
 			if utilities.AreGatewayResourcesAvailable(ctx, mgr.GetClient(), discoveryClient) {
 				if err = (&kamajiv1alpha1.GatewayListener{}).SetupWithManager(ctx, mgr); err != nil {
@@ -246,6 +381,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				},
 				routes.TenantControlPlaneDefaults{}: {
 					handlers.TenantControlPlaneDefaults{
+						Client:           mgr.GetClient(),
 						DefaultDatastore: datastore,
 					},
 				},
@@ -333,8 +469,11 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().BoolVar(&leaderElect, "leader-elect", true, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	cmd.Flags().StringVar(&tmpDirectory, "tmp-directory", "/tmp/kamaji", "Directory which will be used to work with temporary files.")
 	cmd.Flags().StringVar(&kineImage, "kine-image", "rancher/kine:v0.11.10-amd64", "Container image along with tag to use for the Kine sidecar container (used only if etcd-storage-type is set to one of kine strategies).")
+	cmd.Flags().StringVar(&managedEtcdImage, "managed-etcd-image", "quay.io/coreos/etcd", "Container image, without the tag, used by the etcd members of the managed DataStores: the tag is the DataStore managed version.")
 	cmd.Flags().StringVar(&datastore, "datastore", "", "Optional, the default DataStore that should be used by Kamaji to setup the required storage of Tenant Control Planes with undeclared DataStore.")
-	cmd.Flags().StringVar(&migrateJobImage, "migrate-image", fmt.Sprintf("%s/clastix/kamaji:%s", internal.ContainerRepository, internal.GitTag), "Specify the container image to launch when a TenantControlPlane is migrated to a new datastore.")
+	cmd.Flags().StringVar(&migrateJobImage, "migrate-image", fmt.Sprintf("%s/clastix/kamaji:%s", internal.ContainerRepository, internal.GitTag), "Specify the container image to launch when a TenantControlPlane is migrated to a new datastore, or cloned.")
+	cmd.Flags().StringVar(&backupJobImage, "backup-image", fmt.Sprintf("%s/clastix/kamaji:%s", internal.ContainerRepository, internal.GitTag), "Specify the container image to launch when a TenantControlPlane is backed up, or restored.")
+	cmd.Flags().Var(&cloneNodePortRange, "clone-node-port-range", "The NodePort range of the management cluster, as set by the --service-node-port-range flag of its API Server, which the ports of the clone TenantControlPlanes exposed with a NodePort Service are allocated from.")
 	cmd.Flags().IntVar(&maxConcurrentReconciles, "max-concurrent-tcp-reconciles", 1, "Specify the number of workers for the Tenant Control Plane controller (beware of CPU consumption)")
 	cmd.Flags().StringVar(&managerNamespace, "pod-namespace", os.Getenv("POD_NAMESPACE"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&managerServiceName, "webhook-service-name", "kamaji-webhook-service", "The Kamaji webhook server Service name which is used to get validation webhooks, required for the TenantControlPlane migration jobs.")
@@ -343,6 +482,13 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().DurationVar(&controllerReconcileTimeout, "controller-reconcile-timeout", 30*time.Second, "The reconciliation request timeout before the controller withdraw the external resource calls, such as dealing with the Datastore, or the Tenant Control Plane API endpoint.")
 	cmd.Flags().DurationVar(&cacheResyncPeriod, "cache-resync-period", 10*time.Hour, "The controller-runtime.Manager cache resync period.")
 	cmd.Flags().BoolVar(&disableTelemetry, "disable-telemetry", false, "Disable the analytics traces collection.")
+	cmd.Flags().DurationVar(&dataStoreOrphansInterval, "datastore-orphans-interval", time.Hour, "The interval for detecting the tenant databases and users left behind on each DataStore, deleted according to its orphans policy: 0 disables the detection.")
+	cmd.Flags().DurationVar(&dataStoreHealthInterval, "datastore-probe-interval", 30*time.Second, "The interval for probing each DataStore endpoint, excluding the unhealthy ones from the Tenant Control Planes configuration: 0 disables the probing.")
+	cmd.Flags().DurationVar(&dataStoreHealthTimeout, "datastore-probe-timeout", 5*time.Second, "The deadline of each DataStore endpoint health probe, including the connection setup.")
+	cmd.Flags().IntVar(&dataStoreMaxOpenConns, "datastore-max-open-conns", 10, "The maximum number of connections opened towards each SQL DataStore, shared across the reconciliations: 0 means unlimited for MySQL.")
+	cmd.Flags().IntVar(&dataStoreMaxIdleConns, "datastore-max-idle-conns", 2, "The maximum number of idle connections kept open towards each MySQL DataStore.")
+	cmd.Flags().DurationVar(&dataStoreConnIdleTimeout, "datastore-conn-idle-timeout", 5*time.Minute, "The time after which an idle connection towards a SQL DataStore is closed.")
+	cmd.Flags().DurationVar(&dataStoreUsageInterval, "datastore-usage-interval", 5*time.Minute, "The interval for collecting the storage used by each Tenant Control Plane on its DataStore, reported in the status and as metrics: 0 disables the collection.")
 	cmd.Flags().DurationVar(&certificateExpirationDeadline, "certificate-expiration-deadline", 24*time.Hour, "Define the deadline upon certificate expiration to start the renewal process, cannot be less than a 24 hours.")
 
 	cobra.OnInitialize(func() {
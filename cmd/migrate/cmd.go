@@ -6,17 +6,23 @@ package migrate
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
 )
 
 func NewCmd(scheme *runtime.Scheme) *cobra.Command {
@@ -26,15 +32,30 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		targetDataStore       string
 		cleanupPriorMigration bool
 		timeout               time.Duration
+		live                  bool
+		cutoverID             string
+		pollInterval          time.Duration
+		cutoverGracePeriod    time.Duration
+		lagThreshold          int
+		maxReplicationRounds  int
+		maxDrainRounds        int
+		replicationTimeout    time.Duration
 	)
 
 	cmd := &cobra.Command{
 		Use:          "migrate",
-		Short:        "Migrate the data of a TenantControlPlane to another compatible DataStore",
+		Short:        "Migrate the data of a TenantControlPlane to another DataStore",
 		SilenceUsage: true,
 		RunE: func(*cobra.Command, []string) error {
-			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
-			defer cancelFn()
+			// With live migration, the tenant is kept writable until the cutover, limited by the timeout:
+			// the data copy and the replication are limited by the replication timeout.
+			ctx := context.Background()
+			if !live {
+				var cancelFn context.CancelFunc
+
+				ctx, cancelFn = context.WithTimeout(ctx, timeout)
+				defer cancelFn()
+			}
 
 			log := ctrl.Log
 
@@ -73,13 +94,20 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
-			if tcp.Status.Storage.Driver != string(targetDs.Spec.Driver) {
-				return fmt.Errorf("migration between DataStore with different driver is not supported")
+			crossDriver := tcp.Status.Storage.Driver != string(targetDs.Spec.Driver)
+			if crossDriver && live {
+				return fmt.Errorf("live migration between DataStore with different driver is not supported")
 			}
 
 			if tcp.Status.Storage.DataStoreName == targetDs.GetName() {
 				return fmt.Errorf("cannot migrate to the same DataStore")
 			}
+			// Checking the clashes before any change to the target, such as the clean-up of a prior migration.
+			log.Info("checking the TenantControlPlane schema and username on the target DataStore")
+
+			if err = checkTargetClashes(ctx, client, *tcp, *targetDs); err != nil {
+				return err
+			}
 
 			log.Info("generating the origin storage connection")
 
@@ -113,7 +141,27 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			// Start migrating from the old Datastore to the new one
 			log.Info("migration from origin to target started")
 
-			if err = originConnection.Migrate(ctx, *tcp, targetConnection); err != nil {
+			switch {
+			case crossDriver:
+				log.Info("origin and target DataStore have different drivers, performing a driver-neutral copy", "origin", originDs.Spec.Driver, "target", targetDs.Spec.Driver)
+
+				err = datastore.MigrateKeyValues(ctx, *tcp, originConnection, targetConnection)
+			case live:
+				err = datastore.LiveMigrate(ctx, *tcp, originConnection, targetConnection, datastore.LiveMigrationOptions{
+					PollInterval:       pollInterval,
+					LagThreshold:       lagThreshold,
+					MaxRounds:          maxReplicationRounds,
+					CutoverFn:          cutover(client, *tcp, cutoverID, pollInterval, cutoverGracePeriod),
+					ReplicationTimeout: replicationTimeout,
+					CutoverTimeout:     timeout,
+					MaxDrainRounds:     maxDrainRounds,
+					Logger:             log,
+				})
+			default:
+				err = originConnection.Migrate(ctx, *tcp, targetConnection, tcp.Status.Storage.Setup.Schema)
+			}
+
+			if err != nil {
 				return fmt.Errorf("unable to migrate data from %s to %s: %w", originDs.GetName(), targetDs.GetName(), err)
 			}
 
@@ -126,10 +174,88 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().StringVar(&tenantControlPlane, "tenant-control-plane", "", "Namespaced-name of the TenantControlPlane that must be migrated (e.g.: default/test)")
 	cmd.Flags().StringVar(&targetDataStore, "target-datastore", "", "Name of the Datastore to which the TenantControlPlane will be migrated")
 	cmd.Flags().BoolVar(&cleanupPriorMigration, "cleanup-prior-migration", false, "When set to true, migration job will drop existing data in the target DataStore: useful to avoid stale data when migrating back and forth between DataStores.")
-	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Amount of time for the context timeout")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Amount of time for the context timeout: with live migration, it limits the cutover only, from the freeze of the TenantControlPlane to the drain of the remaining changes.")
+	cmd.Flags().BoolVar(&live, "live", false, "When set to true, the TenantControlPlane is kept writable during the data copy, and changes are replicated to the target DataStore until the cutover.")
+	cmd.Flags().StringVar(&cutoverID, "cutover-id", "", "Identifier of the migration used to request the cutover to the Kamaji controller, usually the UID of the migration Job.")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", time.Second, "Amount of time between two change replication rounds, used only when live migration is enabled.")
+	cmd.Flags().IntVar(&lagThreshold, "lag-threshold", 100, "Number of changes replicated by a round below which the cutover is requested, used only when live migration is enabled.")
+	cmd.Flags().IntVar(&maxReplicationRounds, "max-replication-rounds", 300, "Number of replication rounds after which the cutover is requested regardless of the lag, zero meaning no limit: used only when live migration is enabled.")
+	cmd.Flags().IntVar(&maxDrainRounds, "max-drain-rounds", 30, "Number of replication rounds after which the drain of the changes occurred before the freeze is failed, zero meaning no limit: used only when live migration is enabled.")
+	cmd.Flags().DurationVar(&replicationTimeout, "replication-timeout", time.Hour, "Amount of time for the data copy and the change replication preceding the cutover, used only when live migration is enabled.")
+	cmd.Flags().DurationVar(&cutoverGracePeriod, "cutover-grace-period", 5*time.Second, "Amount of time to wait once the TenantControlPlane has been frozen, letting in-flight writes settle before draining the remaining changes.")
 
 	_ = cmd.MarkFlagRequired("tenant-control-plane")
 	_ = cmd.MarkFlagRequired("target-datastore")
 
 	return cmd
 }
+
+// checkTargetClashes ensures the schema and the username of the Tenant Control Plane are not used by other ones on the target DataStore.
+func checkTargetClashes(ctx context.Context, client ctrlclient.Client, tcp kamajiv1alpha1.TenantControlPlane, targetDs kamajiv1alpha1.DataStore) error {
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := client.List(ctx, &tcpList); err != nil {
+		return fmt.Errorf("unable to list the TenantControlPlane objects: %w", err)
+	}
+	// Without an account, the NATS DataStore credentials are shared by its single Tenant Control Plane.
+	checkUsername := targetDs.Spec.Driver != kamajiv1alpha1.KineNatsDriver || targetDs.Spec.NATSAccount != nil
+
+	for _, item := range tcpList.Items {
+		if item.GetUID() == tcp.GetUID() || !slices.Contains(item.UsedDataStores(), targetDs.GetName()) {
+			continue
+		}
+
+		if item.EffectiveDataStoreSchema() == tcp.EffectiveDataStoreSchema() {
+			return fmt.Errorf("the DataStore schema %s is already used by the TenantControlPlane %s on the %s DataStore", tcp.EffectiveDataStoreSchema(), ctrlclient.ObjectKeyFromObject(&item), targetDs.GetName())
+		}
+
+		if checkUsername && item.EffectiveDataStoreUsername() == tcp.EffectiveDataStoreUsername() {
+			return fmt.Errorf("the DataStore username %s is already used by the TenantControlPlane %s on the %s DataStore", tcp.EffectiveDataStoreUsername(), ctrlclient.ObjectKeyFromObject(&item), targetDs.GetName())
+		}
+	}
+
+	return nil
+}
+
+// cutover requests the freezing of the Tenant Control Plane to the Kamaji controller,
+// and waits until the soot manager reports the freeze webhook as enforced.
+func cutover(client ctrlclient.Client, tcp kamajiv1alpha1.TenantControlPlane, cutoverID string, pollInterval, gracePeriod time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if cutoverID == "" {
+			return fmt.Errorf("missing cutover identifier, cannot request the cutover")
+		}
+
+		patch := ctrlclient.MergeFrom(tcp.DeepCopy())
+		tcp.SetAnnotations(utilities.MergeMaps(tcp.GetAnnotations(), map[string]string{kamajiv1alpha1.MigrationCutoverAnnotation: cutoverID}))
+		// The condition timestamps have a precision of seconds.
+		requestedAt := time.Now().Truncate(time.Second)
+
+		if err := client.Patch(ctx, &tcp, patch); err != nil {
+			return fmt.Errorf("unable to request the cutover: %w", err)
+		}
+		// The freeze webhook is installed asynchronously by the soot manager,
+		// which reports it once it's enforced by the Tenant Control Plane.
+		if err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+			if err := client.Get(ctx, ctrlclient.ObjectKeyFromObject(&tcp), &tcp); err != nil {
+				return false, err
+			}
+
+			if ptr.Deref(tcp.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionUnknown) != kamajiv1alpha1.VersionMigrating {
+				return false, nil
+			}
+
+			condition := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.TenantControlPlaneConditionFrozenType)
+
+			return condition != nil && condition.Status == metav1.ConditionTrue && !condition.LastTransitionTime.Time.Before(requestedAt), nil
+		}); err != nil {
+			return fmt.Errorf("the TenantControlPlane has not been frozen: %w", err)
+		}
+		// Letting the changes admitted before the freeze to be persisted.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(gracePeriod):
+		}
+
+		return nil
+	}
+}
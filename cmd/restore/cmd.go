@@ -0,0 +1,168 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package restore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/backup"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		tenantControlPlane string
+		archive            string
+		timeout            time.Duration
+		storageOptions     backup.StorageOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:          "restore",
+		Short:        "Restore the DataStore keyspace and the PKI of a TenantControlPlane from a backup archive",
+		SilenceUsage: true,
+		RunE: func(*cobra.Command, []string) error {
+			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+			defer cancelFn()
+
+			log := ctrl.Log
+
+			log.Info("generating the controller-runtime client")
+
+			client, err := ctrlclient.New(ctrl.GetConfigOrDie(), ctrlclient.Options{
+				Scheme: scheme,
+			})
+			if err != nil {
+				return err
+			}
+
+			parts := strings.Split(tenantControlPlane, string(types.Separator))
+			if len(parts) != 2 {
+				return fmt.Errorf("non well-formed namespaced name for the tenant control plane, expected <NAMESPACE>/NAME, got %s", tenantControlPlane)
+			}
+
+			log.Info("retrieving the TenantControlPlane")
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, tcp); err != nil {
+				return err
+			}
+
+			if status := ptr.Deref(tcp.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionUnknown); status != kamajiv1alpha1.VersionSleeping {
+				return fmt.Errorf("the TenantControlPlane must be scaled to zero replicas to be restored, current status is %s", status)
+			}
+
+			if tcp.Status.Storage.Setup.Schema == "" {
+				return fmt.Errorf("the TenantControlPlane storage has not been provisioned yet")
+			}
+
+			if err = backup.ValidateArchiveName(archive, tcp.GetNamespace(), ""); err != nil {
+				return err
+			}
+
+			log.Info("retrieving the TenantControlPlane used DataStore")
+
+			ds := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(ctx, types.NamespacedName{Name: tcp.Status.Storage.DataStoreName}, ds); err != nil {
+				return err
+			}
+
+			storage, err := storageOptions.Storage(ctx, client)
+			if err != nil {
+				return err
+			}
+
+			log.Info("retrieving the archive", "archive", archive)
+
+			body, err := storage.Get(ctx, archive)
+			if err != nil {
+				return fmt.Errorf("unable to retrieve the archive: %w", err)
+			}
+			defer body.Close()
+
+			reader, err := backup.NewArchiveReader(body)
+			if err != nil {
+				return err
+			}
+			defer reader.Close()
+
+			log.Info("restoring archive", "source", reader.Metadata.TenantControlPlane, "driver", reader.Metadata.Driver, "creationTimestamp", reader.Metadata.CreationTimestamp)
+
+			if err = backup.WritePKISecrets(ctx, client, tcp, reader.Secrets); err != nil {
+				return err
+			}
+
+			log.Info("generating the storage connection")
+
+			connection, err := datastore.NewStorageConnection(ctx, client, *ds)
+			if err != nil {
+				return err
+			}
+			defer connection.Close()
+
+			log.Info("restoring the TenantControlPlane keyspace")
+
+			if err = restoreKeyspace(ctx, connection, tcp, reader); err != nil {
+				return fmt.Errorf("unable to restore the keyspace: %w", err)
+			}
+
+			log.Info("restore completed")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantControlPlane, "tenant-control-plane", "", "Namespaced-name of the TenantControlPlane that must be restored (e.g.: default/test)")
+	cmd.Flags().StringVar(&archive, "archive", "", "Name of the archive, relative to the target (e.g.: default/test/nightly-20060102150405.tar.gz)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Amount of time for the context timeout")
+	storageOptions.AddFlags(cmd.Flags())
+
+	_ = cmd.MarkFlagRequired("tenant-control-plane")
+	_ = cmd.MarkFlagRequired("archive")
+
+	return cmd
+}
+
+// restoreKeyspace replaces the Tenant Control Plane keyspace with the archived one.
+func restoreKeyspace(ctx context.Context, connection datastore.Connection, tcp *kamajiv1alpha1.TenantControlPlane, reader *backup.ArchiveReader) error {
+	schema, user := tcp.Status.Storage.Setup.Schema, tcp.Status.Storage.Setup.User
+
+	if exists, _ := connection.DBExists(ctx, schema); exists {
+		if err := connection.DeleteDB(ctx, schema); err != nil {
+			return err
+		}
+	}
+
+	if err := connection.CreateDB(ctx, schema); err != nil {
+		return err
+	}
+
+	if err := reader.KeyValues(func(kvs []datastore.KeyValue) error {
+		return connection.Import(ctx, *tcp, kvs)
+	}); err != nil {
+		return err
+	}
+	// Dropping the schema could have removed the privileges of the Tenant Control Plane user.
+	if user == "" {
+		return nil
+	}
+
+	if exists, _ := connection.GrantPrivilegesExists(ctx, user, schema); exists {
+		return nil
+	}
+
+	return connection.GrantPrivileges(ctx, user, schema)
+}
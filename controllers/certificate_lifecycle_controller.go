@@ -146,13 +146,21 @@ func (s *CertificateLifecycle) EnqueueForKubeconfigGenerator(secret *corev1.Secr
 	}
 }
 
+// extractCertificateFromBareSecret returns the certificate expiring first among the ones stored in the Secret:
+// the Certificate Authorities issued by an external one are stored along with their chain, which could expire first.
 func (s *CertificateLifecycle) extractCertificateFromBareSecret(secret corev1.Secret) (*x509.Certificate, error) {
 	var crt *x509.Certificate
-	var err error
 
 	for _, v := range secret.Data {
-		if crt, err = crypto.ParseCertificateBytes(v); err == nil {
-			break
+		chain, err := crypto.ParseCertificateChainBytes(v)
+		if err != nil {
+			continue
+		}
+
+		for _, c := range chain {
+			if crt == nil || c.NotAfter.Before(crt.NotAfter) {
+				crt = c
+			}
 		}
 	}
 
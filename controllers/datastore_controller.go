@@ -6,7 +6,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -26,6 +28,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/metrics"
 )
 
@@ -36,10 +39,20 @@ type DataStore struct {
 	// if a Data Source is updated, we have to be sure that the reconciliation of the certificates content
 	// for each Tenant Control Plane is put in place properly.
 	TenantControlPlaneTrigger chan event.GenericEvent
+	// APIReader, KamajiNamespace, and ManagedEtcdImage are used to provision the etcd cluster of the managed DataStore objects.
+	APIReader        client.Reader
+	KamajiNamespace  string
+	ManagedEtcdImage string
+	// Connections is notified of the deleted DataStore objects, discarding their shared connection.
+	Connections *datastore.ConnectionManager
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastorepools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;delete
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=delete
 
 func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	var err error
@@ -60,6 +73,8 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		if k8serrors.IsNotFound(dsErr) {
 			logger.Info("resource may have been deleted, skipping")
 
+			r.Connections.Invalidate(request.Name)
+
 			return reconcile.Result{}, nil
 		}
 
@@ -122,7 +137,7 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		}
 
 		ds.Status.ObservedGeneration = ds.Generation
-		ds.Status.Ready = meta.IsStatusConditionTrue(ds.Status.Conditions, kamajiv1alpha1.DataStoreConditionValidType)
+		ds.Status.Ready = meta.IsStatusConditionTrue(ds.Status.Conditions, kamajiv1alpha1.DataStoreConditionValidType) && isManagedDataStoreReady(&ds)
 
 		if err = r.Client.Status().Update(ctx, &ds); err != nil {
 			logger.Error(err, "cannot update the status for the given instance")
@@ -193,6 +208,26 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, nil
 	}
 
+	var requeueAfter time.Duration
+
+	if ds.Spec.Managed != nil {
+		logger.Info("reconciling managed etcd cluster")
+
+		if requeueAfter, err = r.reconcileManaged(ctx, &ds); err != nil {
+			meta.SetStatusCondition(&ds.Status.Conditions, metav1.Condition{
+				Type:               kamajiv1alpha1.DataStoreConditionManagedType,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: ds.Generation,
+				Reason:             "ManagedClusterFailed",
+				Message:            err.Error(),
+			})
+
+			logger.Error(err, "cannot reconcile managed etcd cluster")
+
+			return reconcile.Result{}, err
+		}
+	}
+
 	if ds.Spec.BasicAuth != nil {
 		logger.Info("validating basic authentication")
 
@@ -213,6 +248,26 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		logger.Info("basic authentication is valid")
 	}
 
+	if ds.Spec.NATSAccount != nil {
+		logger.Info("validating NATS account")
+
+		if vErr := r.validateContentReference(ctx, ds.Spec.NATSAccount.SigningKey); vErr != nil {
+			meta.SetStatusCondition(&ds.Status.Conditions, metav1.Condition{
+				Type:               kamajiv1alpha1.DataStoreConditionValidType,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: ds.Generation,
+				Reason:             "NATSAccountValidationFailed",
+				Message:            fmt.Sprintf("NATS account signing key is not valid, %s", vErr.Error()),
+			})
+
+			logger.Info("invalid NATS account")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Info("NATS account is valid")
+	}
+
 	logger.Info("validating TLS configuration")
 
 	if vErr := r.validateTLSConfig(ctx, ds); vErr != nil {
@@ -239,7 +294,7 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		Message:            "",
 	})
 
-	return reconcile.Result{}, err
+	return reconcile.Result{RequeueAfter: requeueAfter}, err
 }
 
 // triggerTenantControlPlanes enqueues a reconciliation for every TenantControlPlane referencing the
@@ -298,6 +353,7 @@ func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
 	//nolint:forcetypeassert
 	return controllerruntime.NewControllerManagedBy(mgr).
 		For(&kamajiv1alpha1.DataStore{}).
+		Owns(&appsv1.StatefulSet{}).
 		Watches(&kamajiv1alpha1.TenantControlPlane{}, handler.Funcs{
 			CreateFunc: func(_ context.Context, createEvent event.TypedCreateEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 				enqueueFn(createEvent.Object.(*kamajiv1alpha1.TenantControlPlane), w)
@@ -0,0 +1,162 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// DataStoreCredentialsRotation requests the rotation of the Tenant Control Plane credentials,
+// according to the interval of the Tenant Control Plane, or of its DataStore: the request is the same performed on demand,
+// annotating the DataStore Secret of the Tenant Control Plane, which is reconciled since it owns the Secret.
+// The on-demand requests annotating the Tenant Control Plane are moved to its DataStore Secret as well.
+// The DataStore is checked back at least once per interval, catching up with the Tenant Control Planes and the Secrets created in the meanwhile.
+type DataStoreCredentialsRotation struct {
+	Client client.Client
+}
+
+func (r *DataStoreCredentialsRotation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var ds kamajiv1alpha1.DataStore
+	if err := r.Client.Get(ctx, request.NamespacedName, &ds); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := r.Client.List(ctx, &tcpList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, ds.GetName()),
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	now := time.Now()
+	// The DataStore is enqueued back for the first upcoming rotation.
+	var requeueAfter time.Duration
+	if ds.Spec.CredentialsRotationInterval != nil {
+		requeueAfter = ds.Spec.CredentialsRotationInterval.Duration
+	}
+
+	for _, tcp := range tcpList.Items {
+		if tcp.Status.Storage.Config.SecretName == "" {
+			continue
+		}
+
+		var secret corev1.Secret
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Storage.Config.SecretName}, &secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return reconcile.Result{}, err
+		}
+
+		requested := utilities.IsCredentialsRotationRequested(&tcp)
+		// The NATS credentials cannot be rotated, since the previous user cannot be revoked:
+		// the on-demand requests are rejected along with the DataStore Secret ones.
+		if interval := credentialsRotationInterval(&ds, &tcp); !requested && interval > 0 && ds.Spec.Driver != kamajiv1alpha1.KineNatsDriver && !utilities.IsCredentialsRotationRequested(&secret) {
+			lastRotation, ok := utilities.GetLastCredentialsRotation(&secret)
+			if !ok {
+				lastRotation = secret.GetCreationTimestamp().Time
+			}
+
+			after := lastRotation.Add(interval).Sub(now)
+			requested = after <= 0
+			// The credentials rotated now are due again after the interval.
+			if requested {
+				after = interval
+			}
+
+			if requeueAfter == 0 || after < requeueAfter {
+				requeueAfter = after
+			}
+		}
+
+		if !requested {
+			continue
+		}
+
+		logger.Info("requesting credentials rotation", "tenantControlPlane", client.ObjectKeyFromObject(&tcp).String())
+
+		if err := r.requestRotation(ctx, &secret); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		if !utilities.IsCredentialsRotationRequested(&tcp) {
+			continue
+		}
+		// The request has been moved to the DataStore Secret, it's removed from the Tenant Control Plane.
+		patch := client.MergeFrom(tcp.DeepCopy())
+		utilities.RemoveCredentialsRotationRequest(&tcp)
+
+		if err := r.Client.Patch(ctx, &tcp, patch); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *DataStoreCredentialsRotation) requestRotation(ctx context.Context, secret *corev1.Secret) error {
+	if utilities.IsCredentialsRotationRequested(secret) {
+		return nil
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+
+	annotations := secret.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[utilities.RotateCredentialsRequestAnnotation] = ""
+	secret.SetAnnotations(annotations)
+
+	return r.Client.Patch(ctx, secret, patch)
+}
+
+// credentialsRotationInterval returns the rotation interval of the Tenant Control Plane credentials,
+// falling back to the DataStore one: zero means the credentials are rotated only on demand.
+func credentialsRotationInterval(ds *kamajiv1alpha1.DataStore, tcp *kamajiv1alpha1.TenantControlPlane) time.Duration {
+	if interval := tcp.Spec.DataStoreCredentialsRotationInterval; interval != nil {
+		return interval.Duration
+	}
+
+	if interval := ds.Spec.CredentialsRotationInterval; interval != nil {
+		return interval.Duration
+	}
+
+	return 0
+}
+
+func (r *DataStoreCredentialsRotation) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("datastore-credentials-rotation").
+		For(&kamajiv1alpha1.DataStore{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&kamajiv1alpha1.TenantControlPlane{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+			if tcp.Status.Storage.DataStoreName == "" {
+				return nil
+			}
+
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: tcp.Status.Storage.DataStoreName}}}
+		}), builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Complete(r)
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+func TestDataStoreCredentialsRotation(t *testing.T) {
+	t.Parallel()
+
+	ds := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver:                      kamajiv1alpha1.KineMySQLDriver,
+			CredentialsRotationInterval: &metav1.Duration{Duration: 24 * time.Hour},
+		},
+	}
+
+	newTenant := func(name, lastRotation string) (*kamajiv1alpha1.TenantControlPlane, *corev1.Secret) {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		tcp.Status.Storage.DataStoreName = ds.GetName()
+		tcp.Status.Storage.Config.SecretName = name + "-datastore-config"
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:        tcp.Status.Storage.Config.SecretName,
+			Namespace:   "default",
+			Annotations: map[string]string{utilities.LastCredentialsRotationAnnotation: lastRotation},
+		}}
+
+		return tcp, secret
+	}
+
+	expiredTCP, expiredSecret := newTenant("expired", time.Now().Add(-25*time.Hour).Format(time.RFC3339))
+	recentTCP, recentSecret := newTenant("recent", time.Now().Add(-time.Hour).Format(time.RFC3339))
+
+	// The DataStore not used by any Tenant Control Plane yet.
+	unused := ds.DeepCopy()
+	unused.SetName("unused")
+
+	c := newFakeClientBuilder(t, ds, unused, expiredTCP, expiredSecret, recentTCP, recentSecret).
+		WithStatusSubresource(&kamajiv1alpha1.TenantControlPlane{}).
+		Build()
+
+	r := &DataStoreCredentialsRotation{Client: c}
+
+	result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: ds.GetName()}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The recent credentials are due in about 23 hours, before the ones just requested.
+	if result.RequeueAfter <= 22*time.Hour || result.RequeueAfter > 23*time.Hour {
+		t.Fatalf("unexpected requeue after %s", result.RequeueAfter)
+	}
+
+	var secret corev1.Secret
+	if err = c.Get(context.Background(), client.ObjectKeyFromObject(expiredSecret), &secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if !utilities.IsCredentialsRotationRequested(&secret) {
+		t.Fatalf("expected the rotation of the expired credentials to be requested")
+	}
+
+	if err = c.Get(context.Background(), client.ObjectKeyFromObject(recentSecret), &secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if utilities.IsCredentialsRotationRequested(&secret) {
+		t.Fatalf("unexpected rotation of the recent credentials")
+	}
+
+	if result, err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: unused.GetName()}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Checking back the unused DataStore, since the Tenant Control Planes are not watched.
+	if result.RequeueAfter != unused.Spec.CredentialsRotationInterval.Duration {
+		t.Fatalf("unexpected requeue after %s for the unused DataStore", result.RequeueAfter)
+	}
+}
+
+func TestTenantControlPlaneCredentialsRotation(t *testing.T) {
+	t.Parallel()
+
+	// The DataStore credentials are rotated only on demand.
+	ds := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "on-demand"},
+		Spec:       kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KinePostgreSQLDriver},
+	}
+
+	newTenant := func(name string, lastRotation time.Time) (*kamajiv1alpha1.TenantControlPlane, *corev1.Secret) {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		tcp.Status.Storage.DataStoreName = ds.GetName()
+		tcp.Status.Storage.Config.SecretName = name + "-datastore-config"
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:        tcp.Status.Storage.Config.SecretName,
+			Namespace:   "default",
+			Annotations: map[string]string{utilities.LastCredentialsRotationAnnotation: lastRotation.Format(time.RFC3339)},
+		}}
+
+		return tcp, secret
+	}
+	// The Tenant Control Plane rotation interval overrides the DataStore one.
+	periodicTCP, periodicSecret := newTenant("periodic", time.Now().Add(-2*time.Hour))
+	periodicTCP.Spec.DataStoreCredentialsRotationInterval = &metav1.Duration{Duration: time.Hour}
+	// The on-demand request annotating the Tenant Control Plane is moved to its DataStore Secret.
+	demandTCP, demandSecret := newTenant("demand", time.Now().Add(-time.Hour))
+	demandTCP.SetAnnotations(map[string]string{utilities.RotateCredentialsRequestAnnotation: ""})
+
+	c := newFakeClientBuilder(t, ds, periodicTCP, periodicSecret, demandTCP, demandSecret).
+		WithStatusSubresource(&kamajiv1alpha1.TenantControlPlane{}).
+		Build()
+
+	r := &DataStoreCredentialsRotation{Client: c}
+
+	result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: ds.GetName()}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The credentials just requested are due again after the Tenant Control Plane interval.
+	if result.RequeueAfter != time.Hour {
+		t.Fatalf("unexpected requeue after %s", result.RequeueAfter)
+	}
+
+	for _, key := range []client.ObjectKey{client.ObjectKeyFromObject(periodicSecret), client.ObjectKeyFromObject(demandSecret)} {
+		var secret corev1.Secret
+		if err = c.Get(context.Background(), key, &secret); err != nil {
+			t.Fatal(err)
+		}
+
+		if !utilities.IsCredentialsRotationRequested(&secret) {
+			t.Fatalf("expected the rotation of the %s credentials to be requested", key)
+		}
+	}
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err = c.Get(context.Background(), client.ObjectKeyFromObject(demandTCP), &tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	if utilities.IsCredentialsRotationRequested(&tcp) {
+		t.Fatalf("expected the rotation request to be removed from the Tenant Control Plane")
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// dataStoreDrainRequeueInterval is the interval to check back the migrations of a drained DataStore,
+// since the failures are reported by the migration Jobs, which are not watched.
+const dataStoreDrainRequeueInterval = 30 * time.Second
+
+// DataStoreDrain migrates the Tenant Control Planes away from a drained DataStore, a bunch at a time:
+// the migration is requested by changing their dataStore field, thus relying on the migration Job flow.
+type DataStoreDrain struct {
+	Client client.Client
+	// KamajiNamespace is where the migration Jobs are running.
+	KamajiNamespace string
+}
+
+func (r *DataStoreDrain) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var ds kamajiv1alpha1.DataStore
+	if err := r.Client.Get(ctx, request.NamespacedName, &ds); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if utils.IsPaused(&ds) {
+		return reconcile.Result{}, nil
+	}
+
+	original := ds.DeepCopy()
+
+	if ds.Spec.Drain == nil {
+		ds.Status.Drain = nil
+		meta.RemoveStatusCondition(&ds.Status.Conditions, kamajiv1alpha1.DataStoreConditionDrainedType)
+
+		return reconcile.Result{}, r.patchStatus(ctx, original, &ds)
+	}
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := r.Client.List(ctx, &tcpList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, ds.GetName()),
+	}); err != nil {
+		return reconcile.Result{}, fmt.Errorf("cannot retrieve the Tenant Control Planes using the DataStore: %w", err)
+	}
+
+	previous := make(map[string]kamajiv1alpha1.DataStoreDrainTenantStatus, len(ds.Status.Drain))
+	for _, status := range ds.Status.Drain {
+		previous[status.Name] = status
+	}
+
+	now := metav1.Now()
+	statuses := make(map[string]*kamajiv1alpha1.DataStoreDrainTenantStatus, len(tcpList.Items))
+
+	var migrating int32
+
+	var pending []*kamajiv1alpha1.TenantControlPlane
+
+	for i := range tcpList.Items {
+		tcp := &tcpList.Items[i]
+		name := client.ObjectKeyFromObject(tcp).String()
+
+		status, ok := previous[name]
+		if !ok {
+			status = kamajiv1alpha1.DataStoreDrainTenantStatus{Name: name, Phase: kamajiv1alpha1.DataStoreDrainPhasePending}
+		}
+
+		delete(previous, name)
+		statuses[name] = &status
+
+		if tcp.Spec.DataStore == ds.GetName() {
+			pending = append(pending, tcp)
+
+			continue
+		}
+		// The Tenant Control Plane is being migrated, either by the drain, or by a change of its dataStore field.
+		status.Target = tcp.Spec.DataStore
+		if status.StartTime == nil {
+			status.StartTime = &now
+		}
+
+		if message, failed := r.migrationFailure(ctx, tcp); failed {
+			status.Phase, status.Message = kamajiv1alpha1.DataStoreDrainPhaseFailed, message
+
+			continue
+		}
+
+		status.Phase = kamajiv1alpha1.DataStoreDrainPhaseMigrating
+		migrating++
+	}
+	// The Tenant Control Planes no more using the DataStore have been migrated.
+	for name, status := range previous {
+		if status.Phase != kamajiv1alpha1.DataStoreDrainPhaseCompleted {
+			status.Phase, status.Message, status.CompletionTime = kamajiv1alpha1.DataStoreDrainPhaseCompleted, "", &now
+		}
+
+		statuses[name] = &status
+	}
+
+	if len(pending) > 0 {
+		candidates, request, err := r.drainCandidates(ctx, ds)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		for _, tcp := range pending {
+			status := statuses[client.ObjectKeyFromObject(tcp).String()]
+
+			if migrating >= ds.Spec.Drain.MaxConcurrentMigrations {
+				status.Message = fmt.Sprintf("waiting for %d migrations to complete", migrating)
+
+				continue
+			}
+
+			request.Candidates = candidates
+
+			target, reason, placeErr := datastore.Place(request)
+			if placeErr != nil {
+				status.Message = placeErr.Error()
+
+				continue
+			}
+
+			patch := client.MergeFrom(tcp.DeepCopy())
+			tcp.Spec.DataStore = target.GetName()
+
+			if patchErr := r.Client.Patch(ctx, tcp, patch); patchErr != nil {
+				status.Message = fmt.Sprintf("cannot request the migration to %s: %s", target.GetName(), patchErr.Error())
+
+				continue
+			}
+
+			logger.Info("migrating Tenant Control Plane", "tenantControlPlane", status.Name, "target", target.GetName())
+
+			status.Phase, status.Target, status.Message, status.StartTime = kamajiv1alpha1.DataStoreDrainPhaseMigrating, target.GetName(), reason, &now
+			migrating++
+			// Accounting the Tenant Control Plane on the target, for the placement of the next ones:
+			// the cache could not reflect the patch yet.
+			request.Tenants[target.GetName()]++
+		}
+	}
+
+	ds.Status.Drain = make([]kamajiv1alpha1.DataStoreDrainTenantStatus, 0, len(statuses))
+	for _, status := range statuses {
+		ds.Status.Drain = append(ds.Status.Drain, *status)
+	}
+
+	slices.SortFunc(ds.Status.Drain, func(a, b kamajiv1alpha1.DataStoreDrainTenantStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreConditionDrainedType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ds.Generation,
+		Reason:             "Drained",
+		Message:            "No Tenant Control Plane is using the DataStore.",
+	}
+
+	if len(tcpList.Items) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Draining"
+		condition.Message = fmt.Sprintf("%d Tenant Control Planes are still using the DataStore.", len(tcpList.Items))
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, condition)
+
+	if err := r.patchStatus(ctx, original, &ds); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if len(tcpList.Items) > 0 {
+		return reconcile.Result{RequeueAfter: dataStoreDrainRequeueInterval}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// drainCandidates returns the DataStore objects the Tenant Control Planes can be migrated to, along with the placement policy:
+// the ones selected by the drain pool, or the ones backed by the same driver.
+func (r *DataStoreDrain) drainCandidates(ctx context.Context, ds kamajiv1alpha1.DataStore) ([]kamajiv1alpha1.DataStore, datastore.PlacementRequest, error) {
+	request, selector := datastore.PlacementRequest{}, labels.Everything()
+
+	if poolName := ds.Spec.Drain.DataStorePool; poolName != "" {
+		var pool kamajiv1alpha1.DataStorePool
+		if err := r.Client.Get(ctx, k8stypes.NamespacedName{Name: poolName}, &pool); err != nil {
+			return nil, request, fmt.Errorf("cannot retrieve the %s DataStorePool: %w", poolName, err)
+		}
+
+		var err error
+
+		if selector, err = metav1.LabelSelectorAsSelector(&pool.Spec.DataStoreSelector); err != nil {
+			return nil, request, fmt.Errorf("cannot parse the %s DataStorePool selector: %w", poolName, err)
+		}
+
+		request.Policy, request.SpreadLabel = pool.Spec.Policy, pool.Spec.SpreadLabel
+	}
+
+	var dsList kamajiv1alpha1.DataStoreList
+	if err := r.Client.List(ctx, &dsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, request, fmt.Errorf("cannot list DataStore objects for the drain: %w", err)
+	}
+
+	candidates := make([]kamajiv1alpha1.DataStore, 0, len(dsList.Items))
+
+	for _, candidate := range dsList.Items {
+		if candidate.GetName() == ds.GetName() {
+			continue
+		}
+
+		if ds.Spec.Drain.DataStorePool == "" && candidate.Spec.Driver != ds.Spec.Driver {
+			continue
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	var err error
+
+	if request.Tenants, err = datastore.AssignedTenants(ctx, r.Client, candidates); err != nil {
+		return nil, request, err
+	}
+
+	return candidates, request, nil
+}
+
+// migrationFailure returns the failure message if the migration Job of the given Tenant Control Plane failed.
+func (r *DataStoreDrain) migrationFailure(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (string, bool) {
+	var job batchv1.Job
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: r.KamajiNamespace, Name: fmt.Sprintf("migrate-%s", tcp.GetUID())}, &job); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "cannot retrieve the migration Job", "tenantControlPlane", client.ObjectKeyFromObject(tcp).String())
+		}
+
+		return "", false
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return fmt.Sprintf("migration Job %s failed: %s", job.GetName(), condition.Message), true
+		}
+	}
+
+	return "", false
+}
+
+func (r *DataStoreDrain) patchStatus(ctx context.Context, original, ds *kamajiv1alpha1.DataStore) error {
+	if equality.Semantic.DeepEqual(original.Status, ds.Status) {
+		return nil
+	}
+
+	return r.Client.Status().Patch(ctx, ds, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+}
+
+func (r *DataStoreDrain) SetupWithManager(mgr controllerruntime.Manager) error {
+	enqueueFn := func(tcp *kamajiv1alpha1.TenantControlPlane, limitingInterface workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		if dataStoreName := tcp.Status.Storage.DataStoreName; len(dataStoreName) > 0 {
+			limitingInterface.Add(reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: dataStoreName}})
+		}
+	}
+	//nolint:forcetypeassert
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("datastore-drain").
+		For(&kamajiv1alpha1.DataStore{}).
+		Watches(&kamajiv1alpha1.TenantControlPlane{}, handler.Funcs{
+			// The migrated Tenant Control Planes are no more using the drained DataStore.
+			UpdateFunc: func(_ context.Context, updateEvent event.TypedUpdateEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				enqueueFn(updateEvent.ObjectOld.(*kamajiv1alpha1.TenantControlPlane), w)
+			},
+			DeleteFunc: func(_ context.Context, deleteEvent event.TypedDeleteEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				enqueueFn(deleteEvent.Object.(*kamajiv1alpha1.TenantControlPlane), w)
+			},
+		}).
+		Complete(r)
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDataStoreDrain(t *testing.T) {
+	t.Parallel()
+
+	newDataStore := func(name string, driver kamajiv1alpha1.Driver, usedBy ...string) *kamajiv1alpha1.DataStore {
+		return &kamajiv1alpha1.DataStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kamajiv1alpha1.DataStoreSpec{Driver: driver},
+			Status:     kamajiv1alpha1.DataStoreStatus{Ready: true, UsedBy: usedBy},
+		}
+	}
+
+	newTenant := func(name, dataStore string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		tcp.Spec.DataStore = dataStore
+		tcp.Status.Storage.DataStoreName = dataStore
+
+		return tcp
+	}
+
+	drained := newDataStore("drained", kamajiv1alpha1.KineMySQLDriver, "default/tcp-a", "default/tcp-b")
+	drained.Spec.Cordoned = true
+	drained.Spec.Drain = &kamajiv1alpha1.DataStoreDrain{MaxConcurrentMigrations: 1}
+
+	busy := newDataStore("busy", kamajiv1alpha1.KineMySQLDriver, "default/tcp-c")
+	empty := newDataStore("empty", kamajiv1alpha1.KineMySQLDriver)
+	other := newDataStore("other", kamajiv1alpha1.KinePostgreSQLDriver)
+
+	c := newFakeClientBuilder(t, drained, busy, empty, other, newTenant("tcp-a", "drained"), newTenant("tcp-b", "drained"), newTenant("tcp-c", "busy")).
+		WithStatusSubresource(&kamajiv1alpha1.DataStore{}, &kamajiv1alpha1.TenantControlPlane{}).
+		Build()
+
+	r := &DataStoreDrain{Client: c, KamajiNamespace: "kamaji-system"}
+
+	reconcileAndGet := func() kamajiv1alpha1.DataStore {
+		t.Helper()
+
+		if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: drained.GetName()}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var ds kamajiv1alpha1.DataStore
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(drained), &ds); err != nil {
+			t.Fatal(err)
+		}
+
+		return ds
+	}
+
+	phases := func(ds kamajiv1alpha1.DataStore) map[string]kamajiv1alpha1.DataStoreDrainPhase {
+		result := make(map[string]kamajiv1alpha1.DataStoreDrainPhase)
+		for _, status := range ds.Status.Drain {
+			result[status.Name] = status.Phase
+		}
+
+		return result
+	}
+	// A single migration at a time is started, towards the least used DataStore with the same driver.
+	ds := reconcileAndGet()
+
+	if got := phases(ds); got["default/tcp-a"] != kamajiv1alpha1.DataStoreDrainPhaseMigrating || got["default/tcp-b"] != kamajiv1alpha1.DataStoreDrainPhasePending {
+		t.Fatalf("unexpected drain phases %v", got)
+	}
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "tcp-a"}, &tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	if tcp.Spec.DataStore != "empty" {
+		t.Fatalf("expected the migration to the empty DataStore, got %s", tcp.Spec.DataStore)
+	}
+	// Completing the migration lets the next one start.
+	tcp.Status.Storage.DataStoreName = tcp.Spec.DataStore
+	if err := c.Status().Update(context.Background(), &tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	ds = reconcileAndGet()
+
+	if got := phases(ds); got["default/tcp-a"] != kamajiv1alpha1.DataStoreDrainPhaseCompleted || got["default/tcp-b"] != kamajiv1alpha1.DataStoreDrainPhaseMigrating {
+		t.Fatalf("unexpected drain phases %v", got)
+	}
+
+	if !meta.IsStatusConditionFalse(ds.Status.Conditions, kamajiv1alpha1.DataStoreConditionDrainedType) {
+		t.Fatalf("expected the DataStore to be draining, got %v", ds.Status.Conditions)
+	}
+
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "tcp-b"}, &tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	tcp.Status.Storage.DataStoreName = tcp.Spec.DataStore
+	if err := c.Status().Update(context.Background(), &tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	ds = reconcileAndGet()
+
+	if !meta.IsStatusConditionTrue(ds.Status.Conditions, kamajiv1alpha1.DataStoreConditionDrainedType) {
+		t.Fatalf("expected the DataStore to be drained, got %v", ds.Status.Conditions)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+// dataStoreEndpointFailureThreshold is the number of consecutive failed probes marking an endpoint as unhealthy:
+// a single successful probe marks it back as healthy.
+const dataStoreEndpointFailureThreshold = 3
+
+// DataStoreHealth periodically probes each endpoint of the DataStore objects, recording the latency as metrics,
+// and reporting the endpoints health in the status: the unhealthy ones are excluded from the Tenant Control Planes configuration.
+type DataStoreHealth struct {
+	Client        client.Client
+	Metrics       *metrics.Recorder
+	EventRecorder events.EventRecorder
+	Interval      time.Duration
+	// Timeout is the deadline for probing a single endpoint.
+	Timeout time.Duration
+	// failures tracks the consecutive failed probes, keyed by DataStore name and endpoint.
+	failures map[string]int
+}
+
+func (m *DataStoreHealth) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.probe(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *DataStoreHealth) probe(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("datastore-health")
+
+	var dsList kamajiv1alpha1.DataStoreList
+	if err := m.Client.List(ctx, &dsList); err != nil {
+		logger.Error(err, "cannot list DataStore objects")
+
+		return
+	}
+
+	m.metricsRecorder().ResetDataStoreEndpointUp()
+
+	failures := make(map[string]int)
+
+	for i := range dsList.Items {
+		ds := &dsList.Items[i]
+
+		if !ds.Status.Ready || ds.GetDeletionTimestamp() != nil || utils.IsPaused(ds) {
+			continue
+		}
+
+		if err := m.probeDataStore(ctx, ds, failures); err != nil {
+			logger.Error(err, "cannot report endpoints health", "datastore", ds.GetName())
+		}
+	}
+	// Discarding the failures of the deleted DataStore objects, or of their removed endpoints.
+	m.failures = failures
+}
+
+func (m *DataStoreHealth) probeDataStore(ctx context.Context, ds *kamajiv1alpha1.DataStore, failures map[string]int) error {
+	original := ds.DeepCopy()
+
+	previous := make(map[string]kamajiv1alpha1.DataStoreEndpointStatus, len(ds.Status.Endpoints))
+	for _, status := range ds.Status.Endpoints {
+		previous[status.Endpoint] = status
+	}
+
+	statuses := make([]kamajiv1alpha1.DataStoreEndpointStatus, 0, len(ds.Spec.Endpoints))
+
+	for _, endpoint := range ds.Spec.Endpoints {
+		key := ds.GetName() + "/" + endpoint
+
+		latency, err := m.probeEndpoint(ctx, *ds, endpoint)
+		m.metricsRecorder().ObserveDataStoreEndpointProbe(ds.GetName(), endpoint, latency, err == nil)
+
+		status, found := previous[endpoint]
+		if !found {
+			status = kamajiv1alpha1.DataStoreEndpointStatus{Endpoint: endpoint, Healthy: true, LastTransitionTime: metav1.Now()}
+		}
+
+		switch {
+		case err == nil && !status.Healthy:
+			m.recordEndpointEvent(ds, corev1.EventTypeNormal, "EndpointHealthy", "endpoint %s is serving requests again", endpoint)
+
+			status.Healthy, status.Message, status.LastTransitionTime = true, "", metav1.Now()
+		case err != nil:
+			failures[key] = m.failures[key] + 1
+
+			if status.Healthy && failures[key] >= dataStoreEndpointFailureThreshold {
+				m.recordEndpointEvent(ds, corev1.EventTypeWarning, "EndpointUnhealthy", "endpoint %s failed %d consecutive probes: %s", endpoint, failures[key], err.Error())
+
+				status.Healthy, status.Message, status.LastTransitionTime = false, err.Error(), metav1.Now()
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	ds.Status.Endpoints = statuses
+	meta.SetStatusCondition(&ds.Status.Conditions, dataStoreHealthyCondition(ds))
+	meta.SetStatusCondition(&ds.Status.Conditions, dataStoreDegradedCondition(ds))
+	// Patching only upon changes: the DataStore update triggers the reconciliation of its Tenant Control Planes.
+	if equality.Semantic.DeepEqual(original.Status, ds.Status) {
+		return nil
+	}
+
+	return m.Client.Status().Patch(ctx, ds, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+}
+
+// probeEndpoint checks the given endpoint using the DataStore configuration,
+// returning the latency of the probe, including the connection setup.
+func (m *DataStoreHealth) probeEndpoint(ctx context.Context, ds kamajiv1alpha1.DataStore, endpoint string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	ds.Spec.Endpoints = kamajiv1alpha1.Endpoints{endpoint}
+	ds.Status.Endpoints = nil
+
+	start := time.Now()
+
+	connection, err := datastore.NewStorageConnection(ctx, m.Client, ds)
+	if err != nil {
+		return time.Since(start), err
+	}
+	defer connection.Close()
+
+	err = connection.Check(ctx)
+
+	return time.Since(start), err
+}
+
+func dataStoreHealthyCondition(ds *kamajiv1alpha1.DataStore) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreConditionHealthyType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ds.Generation,
+		Reason:             "EndpointsServing",
+		Message:            "At least one endpoint is serving requests.",
+	}
+
+	if len(unhealthyEndpoints(ds)) == len(ds.Status.Endpoints) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoEndpointServing"
+		condition.Message = "None of the endpoints is serving requests."
+	}
+
+	return condition
+}
+
+func dataStoreDegradedCondition(ds *kamajiv1alpha1.DataStore) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreConditionDegradedType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: ds.Generation,
+		Reason:             "AllEndpointsHealthy",
+		Message:            "All the endpoints are serving requests.",
+	}
+
+	if unhealthy := unhealthyEndpoints(ds); len(unhealthy) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "EndpointsUnhealthy"
+		condition.Message = fmt.Sprintf("The following endpoints are not serving requests: %s.", strings.Join(unhealthy, ", "))
+	}
+
+	return condition
+}
+
+func unhealthyEndpoints(ds *kamajiv1alpha1.DataStore) []string {
+	var unhealthy []string
+
+	for _, status := range ds.Status.Endpoints {
+		if !status.Healthy {
+			unhealthy = append(unhealthy, status.Endpoint)
+		}
+	}
+
+	return unhealthy
+}
+
+func (m *DataStoreHealth) recordEndpointEvent(ds *kamajiv1alpha1.DataStore, eventType, reason, note string, args ...any) {
+	if m.EventRecorder == nil {
+		return
+	}
+
+	m.EventRecorder.Eventf(ds, nil, eventType, reason, "ProbeEndpoint", note, args...)
+}
+
+func (m *DataStoreHealth) metricsRecorder() *metrics.Recorder {
+	if m.Metrics == nil {
+		m.Metrics = metrics.DefaultRecorder()
+	}
+
+	return m.Metrics
+}
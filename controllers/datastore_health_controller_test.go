@@ -0,0 +1,95 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+func TestDataStoreHealth(t *testing.T) {
+	t.Parallel()
+
+	// Nothing is listening on the discard port: the probes are expected to fail.
+	ds := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver:    kamajiv1alpha1.EtcdDriver,
+			Endpoints: kamajiv1alpha1.Endpoints{"127.0.0.1:9"},
+		},
+		Status: kamajiv1alpha1.DataStoreStatus{Ready: true},
+	}
+
+	c := newFakeClientBuilder(t, ds).
+		WithStatusSubresource(&kamajiv1alpha1.DataStore{}).
+		Build()
+
+	m := &DataStoreHealth{
+		Client:   c,
+		Metrics:  metrics.NewRecorder(prometheus.NewRegistry()),
+		Interval: time.Minute,
+		Timeout:  100 * time.Millisecond,
+	}
+
+	for probe := 1; probe <= dataStoreEndpointFailureThreshold; probe++ {
+		m.probe(context.Background())
+
+		var current kamajiv1alpha1.DataStore
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(ds), &current); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(current.Status.Endpoints) != 1 {
+			t.Fatalf("expected the endpoint status to be reported, got %v", current.Status.Endpoints)
+		}
+
+		unhealthy := probe == dataStoreEndpointFailureThreshold
+
+		if current.Status.Endpoints[0].Healthy == unhealthy {
+			t.Fatalf("probe %d: unexpected endpoint health %t", probe, current.Status.Endpoints[0].Healthy)
+		}
+
+		if meta.IsStatusConditionFalse(current.Status.Conditions, kamajiv1alpha1.DataStoreConditionHealthyType) != unhealthy {
+			t.Fatalf("probe %d: unexpected healthy condition %v", probe, current.Status.Conditions)
+		}
+
+		if meta.IsStatusConditionTrue(current.Status.Conditions, kamajiv1alpha1.DataStoreConditionDegradedType) != unhealthy {
+			t.Fatalf("probe %d: unexpected degraded condition %v", probe, current.Status.Conditions)
+		}
+		// The unhealthy endpoints are kept when none is available.
+		if active := current.ActiveEndpoints(); len(active) != 1 {
+			t.Fatalf("probe %d: unexpected active endpoints %v", probe, active)
+		}
+	}
+}
+
+func TestDataStoreActiveEndpoints(t *testing.T) {
+	t.Parallel()
+
+	ds := kamajiv1alpha1.DataStore{
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Endpoints: kamajiv1alpha1.Endpoints{"mysql-0:3306", "mysql-1:3306", "mysql-2:3306"},
+		},
+		Status: kamajiv1alpha1.DataStoreStatus{
+			Endpoints: []kamajiv1alpha1.DataStoreEndpointStatus{
+				{Endpoint: "mysql-0:3306", Healthy: false},
+				{Endpoint: "mysql-1:3306", Healthy: true},
+			},
+		},
+	}
+
+	active := ds.ActiveEndpoints()
+	if len(active) != 2 || active[0] != "mysql-1:3306" || active[1] != "mysql-2:3306" {
+		t.Fatalf("unexpected active endpoints %v", active)
+	}
+}
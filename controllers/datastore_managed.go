@@ -0,0 +1,506 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	etcdclient "go.etcd.io/etcd/client/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/builders/etcd"
+)
+
+const (
+	// managedDataStoreRequeueInterval is the interval to check back a managed DataStore which is not yet healthy.
+	managedDataStoreRequeueInterval = 10 * time.Second
+	// managedMemberReplacementTimeout is the time an etcd member can be unavailable before being replaced:
+	// its data is discarded, and a new one joins the cluster in its place.
+	managedMemberReplacementTimeout = 5 * time.Minute
+	// managedMemberTimeout bounds each request to the etcd cluster.
+	managedMemberTimeout = 5 * time.Second
+)
+
+type managedMember struct {
+	ID      uint64
+	Index   int32
+	Name    string
+	Started bool
+	Healthy bool
+	// UnavailableSince is when the member Pod stopped being ready, zero if it's ready.
+	UnavailableSince time.Time
+}
+
+type managedActionType string
+
+const (
+	managedActionNone    managedActionType = ""
+	managedActionAdd     managedActionType = "add"
+	managedActionRemove  managedActionType = "remove"
+	managedActionReplace managedActionType = "replace"
+)
+
+type managedAction struct {
+	Type  managedActionType
+	Index int32
+	ID    uint64
+}
+
+// planManagedMembers returns the next change of the etcd cluster membership, in order to reach the desired replicas:
+// the changes are performed one at a time, waiting for the joining member to start before proceeding,
+// since etcd refuses to add a member when the unstarted ones would compromise the quorum.
+func planManagedMembers(members []managedMember, desired int32, now time.Time) managedAction {
+	isExpired := func(member managedMember) bool {
+		return !member.UnavailableSince.IsZero() && now.Sub(member.UnavailableSince) > managedMemberReplacementTimeout
+	}
+
+	healthy := 0
+
+	for _, member := range members {
+		if member.Healthy {
+			healthy++
+		}
+	}
+
+	for _, member := range members {
+		if member.Started {
+			continue
+		}
+		// A member unable to join is replaced, since its data may belong to a previous one.
+		if isExpired(member) {
+			return managedAction{Type: managedActionReplace, Index: member.Index, ID: member.ID}
+		}
+
+		return managedAction{}
+	}
+
+	sorted := slices.Clone(members)
+	slices.SortFunc(sorted, func(a, b managedMember) int {
+		return int(b.Index - a.Index)
+	})
+
+	for _, member := range sorted {
+		if member.Index >= desired {
+			return managedAction{Type: managedActionRemove, Index: member.Index, ID: member.ID}
+		}
+	}
+
+	for index := range desired {
+		if !slices.ContainsFunc(members, func(member managedMember) bool { return member.Index == index }) {
+			return managedAction{Type: managedActionAdd, Index: index}
+		}
+	}
+
+	for _, member := range members {
+		if member.Healthy || !isExpired(member) {
+			continue
+		}
+		// The remaining members must keep the quorum once the failed one has been removed.
+		if healthy > (len(members)-1)/2 {
+			return managedAction{Type: managedActionReplace, Index: member.Index, ID: member.ID}
+		}
+	}
+
+	return managedAction{}
+}
+
+// reconcileManaged provisions the etcd cluster of the given managed DataStore in the Kamaji namespace,
+// populating its endpoints and TLS configuration, and reporting its health with the managed condition:
+// it returns the interval to check back the cluster, if it's not yet healthy.
+func (r *DataStore) reconcileManaged(ctx context.Context, ds *kamajiv1alpha1.DataStore) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	ca, err := r.reconcileManagedSecret(ctx, ds, etcd.CertificateAuthoritySecretName(*ds), func(secret *corev1.Secret) error {
+		return etcd.BuildCertificateAuthority(secret, *ds)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = r.reconcileManagedSecret(ctx, ds, etcd.ServerCertificateSecretName(*ds), func(secret *corev1.Secret) error {
+		return etcd.BuildServerCertificate(secret, *ds, r.KamajiNamespace, ca)
+	}); err != nil {
+		return 0, err
+	}
+
+	rootClient, err := r.reconcileManagedSecret(ctx, ds, etcd.ClientCertificateSecretName(*ds), func(secret *corev1.Secret) error {
+		return etcd.BuildClientCertificate(secret, *ds, ca)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	builder := etcd.StatefulSet{Image: r.ManagedEtcdImage}
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: etcd.Name(*ds), Namespace: r.KamajiNamespace}}
+	if _, err = controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		builder.BuildService(service, *ds)
+
+		return controllerutil.SetControllerReference(ds, service, r.Client.Scheme())
+	}); err != nil {
+		return 0, fmt.Errorf("cannot reconcile the managed etcd Service: %w", err)
+	}
+
+	if err = r.populateManagedSpec(ctx, ds); err != nil {
+		return 0, err
+	}
+
+	if ds.Status.Managed == nil {
+		ds.Status.Managed = &kamajiv1alpha1.ManagedDataStoreStatus{}
+	}
+
+	status := ds.Status.Managed
+	desired := ds.Spec.Managed.Replicas
+	replicas := desired
+
+	cli, err := r.managedClient(ds, ca, rootClient, max(desired, int32(len(status.Members))))
+	if err != nil {
+		return 0, err
+	}
+	defer cli.Close()
+
+	var members []managedMember
+
+	if status.Bootstrapped {
+		if members, err = r.managedMembers(ctx, cli, ds); err != nil {
+			logger.Error(err, "cannot retrieve the managed etcd members")
+		}
+
+		if len(members) > 0 {
+			if replicas, err = r.reconcileManagedMembers(ctx, cli, ds, members); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	state := etcd.InitialClusterStateNew
+	if status.Bootstrapped {
+		state = etcd.InitialClusterStateExisting
+	}
+
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: etcd.Name(*ds), Namespace: r.KamajiNamespace}}
+	if _, err = controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, func() error {
+		builder.Build(statefulSet, *ds, replicas, state)
+
+		return controllerutil.SetControllerReference(ds, statefulSet, r.Client.Scheme())
+	}); err != nil {
+		return 0, fmt.Errorf("cannot reconcile the managed etcd StatefulSet: %w", err)
+	}
+
+	if !status.Bootstrapped {
+		for index := range desired {
+			members = append(members, managedMember{Index: index, Name: etcd.MemberName(*ds, index), Healthy: isManagedMemberHealthy(ctx, cli, etcd.MemberEndpoint(*ds, r.KamajiNamespace, index))})
+		}
+	}
+
+	status.Members, status.HealthyMembers = nil, 0
+
+	for _, member := range members {
+		if member.Started || !status.Bootstrapped {
+			status.Members = append(status.Members, member.Name)
+		}
+
+		if member.Healthy {
+			status.HealthyMembers++
+		}
+	}
+
+	slices.Sort(status.Members)
+
+	hasQuorum := len(members) > 0 && int(status.HealthyMembers) > len(members)/2
+
+	if hasQuorum && !status.Bootstrapped {
+		if err = bootstrapManagedAuthentication(ctx, cli); err != nil {
+			return 0, err
+		}
+
+		logger.Info("managed etcd cluster has been bootstrapped")
+
+		status.Bootstrapped = true
+	}
+
+	if !hasQuorum || !status.Bootstrapped {
+		meta.SetStatusCondition(&ds.Status.Conditions, metav1.Condition{
+			Type:               kamajiv1alpha1.DataStoreConditionManagedType,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: ds.Generation,
+			Reason:             "ClusterNotReady",
+			Message:            fmt.Sprintf("The etcd cluster has no quorum, %d of %d members are healthy.", status.HealthyMembers, len(members)),
+		})
+
+		return managedDataStoreRequeueInterval, nil
+	}
+
+	meta.SetStatusCondition(&ds.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.DataStoreConditionManagedType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ds.Generation,
+		Reason:             "ClusterHealthy",
+		Message:            fmt.Sprintf("%d of %d members are healthy.", status.HealthyMembers, len(members)),
+	})
+
+	if int(status.HealthyMembers) < len(members) || int32(len(members)) != desired {
+		return managedDataStoreRequeueInterval, nil
+	}
+
+	return 0, nil
+}
+
+func (r *DataStore) reconcileManagedSecret(ctx context.Context, ds *kamajiv1alpha1.DataStore, name string, buildFn func(*corev1.Secret) error) (*corev1.Secret, error) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.KamajiNamespace}}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if err := buildFn(secret); err != nil {
+			return err
+		}
+
+		return controllerutil.SetControllerReference(ds, secret, r.Client.Scheme())
+	}); err != nil {
+		return nil, fmt.Errorf("cannot reconcile the managed etcd Secret %s: %w", name, err)
+	}
+
+	return secret, nil
+}
+
+// populateManagedSpec sets the endpoints and the TLS configuration of the managed DataStore,
+// referring to the generated certificates: the Tenant Control Planes connect to it as for the unmanaged ones.
+func (r *DataStore) populateManagedSpec(ctx context.Context, ds *kamajiv1alpha1.DataStore) error {
+	secretRef := func(name string) corev1.SecretReference {
+		return corev1.SecretReference{Name: name, Namespace: r.KamajiNamespace}
+	}
+
+	caRef := secretRef(etcd.CertificateAuthoritySecretName(*ds))
+	clientRef := secretRef(etcd.ClientCertificateSecretName(*ds))
+
+	endpoints := kamajiv1alpha1.Endpoints(etcd.Endpoints(*ds, r.KamajiNamespace, ds.Spec.Managed.Replicas))
+	tlsConfig := &kamajiv1alpha1.TLSConfig{
+		CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+			Certificate: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: caRef, KeyPath: kubeadmconstants.CACertName}},
+			PrivateKey:  &kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: caRef, KeyPath: kubeadmconstants.CAKeyName}},
+		},
+		ClientCertificate: &kamajiv1alpha1.ClientCertificate{
+			Certificate: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: clientRef, KeyPath: corev1.TLSCertKey}},
+			PrivateKey:  kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: clientRef, KeyPath: corev1.TLSPrivateKeyKey}},
+		},
+	}
+
+	if equality.Semantic.DeepEqual(ds.Spec.Endpoints, endpoints) && equality.Semantic.DeepEqual(ds.Spec.TLSConfig, tlsConfig) {
+		return nil
+	}
+
+	ds.Spec.Endpoints, ds.Spec.TLSConfig = endpoints, tlsConfig
+	// The update returns the persisted status, discarding the one computed by the current reconciliation.
+	status := ds.Status.DeepCopy()
+
+	if err := r.Client.Update(ctx, ds); err != nil {
+		return fmt.Errorf("cannot populate the managed DataStore endpoints and TLS configuration: %w", err)
+	}
+
+	ds.Status = *status
+
+	return nil
+}
+
+func (r *DataStore) managedClient(ds *kamajiv1alpha1.DataStore, ca, rootClient *corev1.Secret, replicas int32) (*etcdclient.Client, error) {
+	rootCAs := x509.NewCertPool()
+	if ok := rootCAs.AppendCertsFromPEM(ca.Data[kubeadmconstants.CACertName]); !ok {
+		return nil, fmt.Errorf("cannot load the managed etcd Certificate Authority")
+	}
+
+	certificate, err := tls.X509KeyPair(rootClient.Data[corev1.TLSCertKey], rootClient.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the managed etcd client certificate: %w", err)
+	}
+
+	return etcdclient.New(etcdclient.Config{
+		Endpoints: etcd.Endpoints(*ds, r.KamajiNamespace, replicas),
+		TLS: &tls.Config{
+			RootCAs:      rootCAs,
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   tls.VersionTLS12,
+		},
+	})
+}
+
+func (r *DataStore) managedMembers(ctx context.Context, cli *etcdclient.Client, ds *kamajiv1alpha1.DataStore) ([]managedMember, error) {
+	listCtx, cancel := context.WithTimeout(ctx, managedMemberTimeout)
+	defer cancel()
+
+	res, err := cli.MemberList(listCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]managedMember, 0, len(res.Members))
+
+	for _, m := range res.Members {
+		if len(m.PeerURLs) == 0 {
+			continue
+		}
+
+		index, ok := etcd.MemberIndex(*ds, m.PeerURLs[0])
+		if !ok {
+			continue
+		}
+
+		member := managedMember{
+			ID:      m.ID,
+			Index:   index,
+			Name:    etcd.MemberName(*ds, index),
+			Started: m.Name != "",
+		}
+
+		if member.Started {
+			member.Healthy = isManagedMemberHealthy(ctx, cli, etcd.MemberEndpoint(*ds, r.KamajiNamespace, index))
+		}
+
+		if !member.Healthy {
+			member.UnavailableSince = r.managedMemberUnavailableSince(ctx, member.Name)
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// managedMemberUnavailableSince returns when the Pod of the given member stopped being ready:
+// the Pods are not cached, since the manager would store all the ones of the cluster.
+func (r *DataStore) managedMemberUnavailableSince(ctx context.Context, name string) time.Time {
+	var pod corev1.Pod
+	if err := r.APIReader.Get(ctx, k8stypes.NamespacedName{Namespace: r.KamajiNamespace, Name: name}, &pod); err != nil {
+		return time.Time{}
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodReady {
+			continue
+		}
+
+		if condition.Status == corev1.ConditionTrue {
+			return time.Time{}
+		}
+
+		return condition.LastTransitionTime.Time
+	}
+
+	return pod.GetCreationTimestamp().Time
+}
+
+// reconcileManagedMembers performs the next change of the etcd cluster membership,
+// returning the replicas of the StatefulSet running the resulting members.
+func (r *DataStore) reconcileManagedMembers(ctx context.Context, cli *etcdclient.Client, ds *kamajiv1alpha1.DataStore, members []managedMember) (int32, error) {
+	logger := log.FromContext(ctx)
+
+	var replicas int32
+
+	for _, member := range members {
+		replicas = max(replicas, member.Index+1)
+	}
+
+	action := planManagedMembers(members, ds.Spec.Managed.Replicas, time.Now())
+	if action.Type == managedActionNone {
+		return replicas, nil
+	}
+
+	memberCtx, cancel := context.WithTimeout(ctx, managedMemberTimeout)
+	defer cancel()
+
+	name := etcd.MemberName(*ds, action.Index)
+
+	switch action.Type {
+	case managedActionAdd:
+		logger.Info("adding managed etcd member", "member", name)
+
+		if _, err := cli.MemberAdd(memberCtx, []string{etcd.MemberPeerURL(*ds, r.KamajiNamespace, action.Index)}); err != nil {
+			return 0, fmt.Errorf("cannot add the managed etcd member %s: %w", name, err)
+		}
+
+		replicas = max(replicas, action.Index+1)
+	case managedActionRemove, managedActionReplace:
+		logger.Info("removing managed etcd member", "member", name, "replace", action.Type == managedActionReplace)
+
+		if _, err := cli.MemberRemove(memberCtx, action.ID); err != nil && !errors.Is(err, rpctypes.ErrMemberNotFound) {
+			return 0, fmt.Errorf("cannot remove the managed etcd member %s: %w", name, err)
+		}
+
+		if action.Type == managedActionRemove {
+			return replicas, nil
+		}
+		// The member data must be discarded, letting the new one start from scratch:
+		// the StatefulSet creates again both the claim and the Pod.
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data-" + name, Namespace: r.KamajiNamespace}}
+		if err := r.Client.Delete(ctx, pvc); err != nil && !k8serrors.IsNotFound(err) {
+			return 0, fmt.Errorf("cannot delete the managed etcd member %s volume: %w", name, err)
+		}
+
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: r.KamajiNamespace}}
+		if err := r.Client.Delete(ctx, pod); err != nil && !k8serrors.IsNotFound(err) {
+			return 0, fmt.Errorf("cannot delete the managed etcd member %s Pod: %w", name, err)
+		}
+	}
+
+	return replicas, nil
+}
+
+func isManagedMemberHealthy(ctx context.Context, cli *etcdclient.Client, endpoint string) bool {
+	statusCtx, cancel := context.WithTimeout(ctx, managedMemberTimeout)
+	defer cancel()
+
+	res, err := cli.Status(statusCtx, endpoint)
+
+	return err == nil && len(res.Errors) == 0
+}
+
+// bootstrapManagedAuthentication enables the etcd authentication, required to confine each Tenant Control Plane to its own prefix:
+// the root user has no password, since it's authenticated by the client certificate common name.
+func bootstrapManagedAuthentication(ctx context.Context, cli *etcdclient.Client) error {
+	authCtx, cancel := context.WithTimeout(ctx, managedMemberTimeout)
+	defer cancel()
+
+	status, err := cli.AuthStatus(authCtx)
+	if err != nil {
+		return fmt.Errorf("cannot retrieve the managed etcd authentication status: %w", err)
+	}
+
+	if status.Enabled {
+		return nil
+	}
+
+	if _, err = cli.UserAddWithOptions(authCtx, etcd.RootUser, "", &etcdclient.UserAddOptions{NoPassword: true}); err != nil && !errors.Is(err, rpctypes.ErrUserAlreadyExist) {
+		return fmt.Errorf("cannot create the managed etcd root user: %w", err)
+	}
+
+	if _, err = cli.UserGrantRole(authCtx, etcd.RootUser, etcd.RootUser); err != nil {
+		return fmt.Errorf("cannot grant the managed etcd root role: %w", err)
+	}
+
+	if _, err = cli.AuthEnable(authCtx); err != nil {
+		return fmt.Errorf("cannot enable the managed etcd authentication: %w", err)
+	}
+
+	return nil
+}
+
+// isManagedDataStoreReady returns true if the data store is not managed, or its etcd cluster is healthy.
+func isManagedDataStoreReady(ds *kamajiv1alpha1.DataStore) bool {
+	return ds.Spec.Managed == nil || meta.IsStatusConditionTrue(ds.Status.Conditions, kamajiv1alpha1.DataStoreConditionManagedType)
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanManagedMembers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	expired := now.Add(-2 * managedMemberReplacementTimeout)
+	recent := now.Add(-time.Minute)
+
+	healthy := func(index int32) managedMember {
+		return managedMember{ID: uint64(index) + 1, Index: index, Started: true, Healthy: true}
+	}
+
+	unhealthy := func(index int32, since time.Time) managedMember {
+		return managedMember{ID: uint64(index) + 1, Index: index, Started: true, UnavailableSince: since}
+	}
+
+	tests := []struct {
+		name     string
+		members  []managedMember
+		desired  int32
+		expected managedAction
+	}{
+		{
+			name:     "cluster is converged",
+			members:  []managedMember{healthy(0), healthy(1), healthy(2)},
+			desired:  3,
+			expected: managedAction{},
+		},
+		{
+			name:     "scaling up adds the first missing member",
+			members:  []managedMember{healthy(0), healthy(2)},
+			desired:  3,
+			expected: managedAction{Type: managedActionAdd, Index: 1},
+		},
+		{
+			name:     "scaling down removes the highest member",
+			members:  []managedMember{healthy(0), healthy(1), healthy(2), healthy(3), healthy(4)},
+			desired:  3,
+			expected: managedAction{Type: managedActionRemove, Index: 4, ID: 5},
+		},
+		{
+			name:     "unstarted member is waited for",
+			members:  []managedMember{healthy(0), healthy(1), {ID: 3, Index: 2, UnavailableSince: recent}},
+			desired:  5,
+			expected: managedAction{},
+		},
+		{
+			name:     "unstarted member is replaced once expired",
+			members:  []managedMember{healthy(0), healthy(1), {ID: 3, Index: 2, UnavailableSince: expired}},
+			desired:  3,
+			expected: managedAction{Type: managedActionReplace, Index: 2, ID: 3},
+		},
+		{
+			name:     "unhealthy member is waited for",
+			members:  []managedMember{healthy(0), healthy(1), unhealthy(2, recent)},
+			desired:  3,
+			expected: managedAction{},
+		},
+		{
+			name:     "unhealthy member is replaced once expired",
+			members:  []managedMember{healthy(0), healthy(1), unhealthy(2, expired)},
+			desired:  3,
+			expected: managedAction{Type: managedActionReplace, Index: 2, ID: 3},
+		},
+		{
+			name:     "unhealthy member is not replaced without quorum",
+			members:  []managedMember{healthy(0), unhealthy(1, expired), unhealthy(2, expired)},
+			desired:  3,
+			expected: managedAction{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if action := planManagedMembers(tt.members, tt.desired, now); action != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, action)
+			}
+		})
+	}
+}
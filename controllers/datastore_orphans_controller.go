@@ -0,0 +1,259 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// DataStoreOrphans periodically detects the tenant databases and users left behind on each DataStore,
+// comparing them with the ones of the Tenant Control Planes using it, and reporting them in the DataStore status:
+// the orphans are deleted once the grace period elapsed, when the DataStore opted in with the Delete policy.
+// Only the databases and users recorded in the DataStore inventory, since used by a Tenant Control Plane, are taken in consideration:
+// the other ones existing on the DataStore server, such as the ones of other applications, are never reported, nor deleted.
+type DataStoreOrphans struct {
+	Client        client.Client
+	Connections   *datastore.ConnectionManager
+	EventRecorder events.EventRecorder
+	Interval      time.Duration
+	// Timeout is the deadline for detecting and deleting the orphans of a single DataStore.
+	Timeout time.Duration
+}
+
+func (m *DataStoreOrphans) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.collectOrphans(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *DataStoreOrphans) collectOrphans(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("datastore-orphans")
+
+	var dsList kamajiv1alpha1.DataStoreList
+	if err := m.Client.List(ctx, &dsList); err != nil {
+		logger.Error(err, "cannot list DataStore objects")
+
+		return
+	}
+	// Listing all the Tenant Control Planes, since the ones migrating to the DataStore,
+	// or storing some resources on it with the overrides, are not referenced by the index.
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := m.Client.List(ctx, &tcpList); err != nil {
+		logger.Error(err, "cannot list TenantControlPlane objects")
+
+		return
+	}
+
+	for i := range dsList.Items {
+		ds := &dsList.Items[i]
+
+		if !ds.Status.Ready || ds.GetDeletionTimestamp() != nil || utils.IsPaused(ds) {
+			continue
+		}
+
+		if err := m.collectDataStoreOrphans(ctx, ds, tcpList.Items); err != nil {
+			logger.Error(err, "cannot collect orphans", "datastore", ds.GetName())
+		}
+	}
+}
+
+func (m *DataStoreOrphans) collectDataStoreOrphans(ctx context.Context, ds *kamajiv1alpha1.DataStore, tcps []kamajiv1alpha1.TenantControlPlane) error {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	connection, err := m.Connections.Connection(ctx, *ds)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	databases, err := connection.ListDatabases(ctx)
+	if err != nil {
+		return err
+	}
+
+	users, err := connection.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	original := ds.DeepCopy()
+
+	usedDatabases, usedUsers := dataStoreTenantResources(ds.GetName(), tcps)
+	provisionedDatabases, provisionedUsers := dataStoreProvisionedResources(ds.GetName(), tcps)
+
+	inventory := map[kamajiv1alpha1.DataStoreOrphanKind]sets.Set[string]{
+		kamajiv1alpha1.DataStoreOrphanKindDatabase: recordInventory(ds.Status.Inventory, kamajiv1alpha1.DataStoreOrphanKindDatabase, databases, provisionedDatabases),
+		kamajiv1alpha1.DataStoreOrphanKindUser:     recordInventory(ds.Status.Inventory, kamajiv1alpha1.DataStoreOrphanKindUser, users, provisionedUsers),
+	}
+	// The databases are listed, and thus deleted, before the users, since these could own them.
+	orphans := detectOrphans(ds.Status.Orphans, kamajiv1alpha1.DataStoreOrphanKindDatabase, sets.List(inventory[kamajiv1alpha1.DataStoreOrphanKindDatabase]), usedDatabases)
+	orphans = append(orphans, detectOrphans(ds.Status.Orphans, kamajiv1alpha1.DataStoreOrphanKindUser, sets.List(inventory[kamajiv1alpha1.DataStoreOrphanKindUser]), usedUsers)...)
+
+	if policy := ds.Spec.Orphans; policy != nil && policy.Policy == kamajiv1alpha1.DataStoreOrphanPolicyDelete {
+		orphans = slices.DeleteFunc(orphans, func(orphan kamajiv1alpha1.DataStoreOrphanStatus) bool {
+			if time.Since(orphan.DetectionTime.Time) < policy.GracePeriod.Duration {
+				return false
+			}
+
+			if !m.deleteOrphan(ctx, ds, connection, orphan) {
+				return false
+			}
+
+			inventory[orphan.Kind].Delete(orphan.Name)
+
+			return true
+		})
+	}
+
+	ds.Status.Inventory = inventoryStatus(inventory)
+	ds.Status.Orphans = orphans
+
+	if equality.Semantic.DeepEqual(original.Status, ds.Status) {
+		return nil
+	}
+
+	return m.Client.Status().Patch(ctx, ds, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+}
+
+// deleteOrphan returns true if the orphan has been deleted.
+func (m *DataStoreOrphans) deleteOrphan(ctx context.Context, ds *kamajiv1alpha1.DataStore, connection datastore.Connection, orphan kamajiv1alpha1.DataStoreOrphanStatus) bool {
+	var err error
+
+	switch orphan.Kind {
+	case kamajiv1alpha1.DataStoreOrphanKindDatabase:
+		err = connection.DeleteDB(ctx, orphan.Name)
+	case kamajiv1alpha1.DataStoreOrphanKindUser:
+		err = connection.DeleteUser(ctx, orphan.Name)
+	}
+
+	if err != nil {
+		m.recordOrphanEvent(ds, corev1.EventTypeWarning, "OrphanDeletionFailed", "cannot delete the orphan %s %s: %s", orphan.Kind, orphan.Name, err.Error())
+
+		return false
+	}
+
+	m.recordOrphanEvent(ds, corev1.EventTypeNormal, "OrphanDeleted", "deleted the orphan %s %s", orphan.Kind, orphan.Name)
+
+	return true
+}
+
+// detectOrphans returns the sorted orphans among the existing names, retaining their previous detection time.
+func detectOrphans(previous []kamajiv1alpha1.DataStoreOrphanStatus, kind kamajiv1alpha1.DataStoreOrphanKind, existing []string, used sets.Set[string]) []kamajiv1alpha1.DataStoreOrphanStatus {
+	var orphans []kamajiv1alpha1.DataStoreOrphanStatus
+
+	for _, name := range sets.List(sets.New(existing...).Difference(used)) {
+		orphan := kamajiv1alpha1.DataStoreOrphanStatus{Kind: kind, Name: name, DetectionTime: metav1.Now()}
+
+		if index := slices.IndexFunc(previous, func(status kamajiv1alpha1.DataStoreOrphanStatus) bool {
+			return status.Kind == kind && status.Name == name
+		}); index >= 0 {
+			orphan.DetectionTime = previous[index].DetectionTime
+		}
+
+		orphans = append(orphans, orphan)
+	}
+
+	return orphans
+}
+
+// recordInventory returns the names of the given kind recorded in the inventory, along with the provisioned ones:
+// the ones no longer existing on the DataStore are dropped.
+func recordInventory(inventory []kamajiv1alpha1.DataStoreInventoryItem, kind kamajiv1alpha1.DataStoreOrphanKind, existing []string, provisioned sets.Set[string]) sets.Set[string] {
+	recorded := provisioned.Clone()
+
+	for _, item := range inventory {
+		if item.Kind == kind {
+			recorded.Insert(item.Name)
+		}
+	}
+
+	return recorded.Intersection(sets.New(existing...))
+}
+
+// inventoryStatus returns the sorted inventory, the databases first.
+func inventoryStatus(inventory map[kamajiv1alpha1.DataStoreOrphanKind]sets.Set[string]) []kamajiv1alpha1.DataStoreInventoryItem {
+	var items []kamajiv1alpha1.DataStoreInventoryItem
+
+	for _, kind := range []kamajiv1alpha1.DataStoreOrphanKind{kamajiv1alpha1.DataStoreOrphanKindDatabase, kamajiv1alpha1.DataStoreOrphanKindUser} {
+		for _, name := range sets.List(inventory[kind]) {
+			items = append(items, kamajiv1alpha1.DataStoreInventoryItem{Kind: kind, Name: name})
+		}
+	}
+
+	return items
+}
+
+// usesDataStore returns true if the Tenant Control Plane uses the given DataStore,
+// either as its current one, as the target of a migration, or with the overrides.
+func usesDataStore(tcp *kamajiv1alpha1.TenantControlPlane, dataStoreName string) bool {
+	return tcp.Status.Storage.DataStoreName == dataStoreName || tcp.Spec.DataStore == dataStoreName ||
+		slices.ContainsFunc(tcp.Spec.DataStoreOverrides, func(override kamajiv1alpha1.DataStoreOverride) bool {
+			return override.DataStore == dataStoreName
+		})
+}
+
+// dataStoreProvisionedResources returns the effective databases and users of the Tenant Control Planes using the given DataStore.
+func dataStoreProvisionedResources(dataStoreName string, tcps []kamajiv1alpha1.TenantControlPlane) (sets.Set[string], sets.Set[string]) {
+	databases, users := sets.New[string](), sets.New[string]()
+
+	for i := range tcps {
+		if tcp := &tcps[i]; usesDataStore(tcp, dataStoreName) {
+			databases.Insert(tcp.EffectiveDataStoreSchema())
+			users.Insert(tcp.EffectiveDataStoreUsername())
+		}
+	}
+
+	return databases, users
+}
+
+// dataStoreTenantResources returns the databases and users the Tenant Control Planes using the given DataStore could refer to,
+// never reported as orphans.
+func dataStoreTenantResources(dataStoreName string, tcps []kamajiv1alpha1.TenantControlPlane) (sets.Set[string], sets.Set[string]) {
+	databases, users := sets.New[string](), sets.New[string]()
+
+	for i := range tcps {
+		tcp := &tcps[i]
+
+		if !usesDataStore(tcp, dataStoreName) {
+			continue
+		}
+
+		databases.Insert(tcp.Status.Storage.Setup.Schema, tcp.Spec.DataStoreSchema, tcp.GetDefaultDatastoreSchema())
+		users.Insert(tcp.Status.Storage.Setup.User, tcp.Spec.DataStoreUsername, tcp.GetDefaultDatastoreUsername())
+	}
+
+	return databases, users
+}
+
+func (m *DataStoreOrphans) recordOrphanEvent(ds *kamajiv1alpha1.DataStore, eventType, reason, note string, args ...any) {
+	if m.EventRecorder == nil {
+		return
+	}
+
+	m.EventRecorder.Eventf(ds, nil, eventType, reason, "CollectOrphans", note, args...)
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestDataStoreOrphansDetection(t *testing.T) {
+	t.Parallel()
+
+	newTenant := func(name, dataStore string) kamajiv1alpha1.TenantControlPlane {
+		tcp := kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name)}}
+		tcp.Spec.DataStore = dataStore
+		tcp.Spec.DataStoreSchema = name + "_schema"
+		tcp.Spec.DataStoreUsername = name + "_user"
+
+		return tcp
+	}
+
+	current := newTenant("current", "mysql")
+	current.Status.Storage.DataStoreName = "mysql"
+	current.Status.Storage.Setup.Schema = "current_schema"
+	current.Status.Storage.Setup.User = "current_user"
+	// A migration to the DataStore is in progress.
+	migrating := newTenant("migrating", "mysql")
+	migrating.Status.Storage.DataStoreName = "legacy"
+
+	override := newTenant("override", "other")
+	override.Spec.DataStoreOverrides = []kamajiv1alpha1.DataStoreOverride{{Resource: "/events", DataStore: "mysql"}}
+	// The Tenant Control Plane has been migrated to another DataStore.
+	migrated := newTenant("migrated", "other")
+	migrated.Status.Storage.DataStoreName = "other"
+
+	databases, users := dataStoreTenantResources("mysql", []kamajiv1alpha1.TenantControlPlane{current, migrating, override, migrated})
+
+	detected := metav1.NewTime(time.Now().Add(-time.Hour))
+	previous := []kamajiv1alpha1.DataStoreOrphanStatus{
+		{Kind: kamajiv1alpha1.DataStoreOrphanKindDatabase, Name: "migrated_schema", DetectionTime: detected},
+		{Kind: kamajiv1alpha1.DataStoreOrphanKindDatabase, Name: "deleted_schema", DetectionTime: detected},
+	}
+
+	orphans := detectOrphans(previous, kamajiv1alpha1.DataStoreOrphanKindDatabase, []string{"override_schema", "migrating_schema", "migrated_schema", "current_schema", "leftover_schema"}, databases)
+	if len(orphans) != 2 || orphans[0].Name != "leftover_schema" || orphans[1].Name != "migrated_schema" {
+		t.Fatalf("unexpected orphan databases %v", orphans)
+	}
+
+	if !orphans[1].DetectionTime.Equal(&detected) {
+		t.Fatalf("expected the detection time to be retained, got %v", orphans[1].DetectionTime)
+	}
+
+	if orphans[0].DetectionTime.Equal(&detected) {
+		t.Fatal("expected a new orphan to be detected now")
+	}
+
+	orphans = detectOrphans(previous, kamajiv1alpha1.DataStoreOrphanKindUser, []string{"current_user", "migrated_user"}, users)
+	if len(orphans) != 1 || orphans[0].Name != "migrated_user" || orphans[0].DetectionTime.Equal(&detected) {
+		t.Fatalf("unexpected orphan users %v", orphans)
+	}
+}
+
+func TestDataStoreOrphansInventory(t *testing.T) {
+	t.Parallel()
+
+	current := kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "current", Namespace: "default", UID: "uid-current"}}
+	current.Spec.DataStore = "postgresql"
+	current.Status.Storage.DataStoreName = "postgresql"
+	current.Status.Storage.Setup.Schema = "current_schema"
+	current.Status.Storage.Setup.User = "current_user"
+
+	tcps := []kamajiv1alpha1.TenantControlPlane{current}
+	// The Tenant Control Plane deleted in the meanwhile has been recorded by a previous detection.
+	inventory := []kamajiv1alpha1.DataStoreInventoryItem{
+		{Kind: kamajiv1alpha1.DataStoreOrphanKindDatabase, Name: "deleted_schema"},
+		{Kind: kamajiv1alpha1.DataStoreOrphanKindUser, Name: "deleted_user"},
+		{Kind: kamajiv1alpha1.DataStoreOrphanKindUser, Name: "dropped_user"},
+	}
+	// The foreign database and user belong to another application sharing the DataStore server.
+	existingDatabases := []string{"current_schema", "deleted_schema", "wordpress"}
+	existingUsers := []string{"current_user", "deleted_user", "wordpress"}
+
+	usedDatabases, usedUsers := dataStoreTenantResources("postgresql", tcps)
+	provisionedDatabases, provisionedUsers := dataStoreProvisionedResources("postgresql", tcps)
+
+	databases := recordInventory(inventory, kamajiv1alpha1.DataStoreOrphanKindDatabase, existingDatabases, provisionedDatabases)
+	if !databases.Equal(sets.New("current_schema", "deleted_schema")) {
+		t.Fatalf("unexpected databases inventory %v", sets.List(databases))
+	}
+
+	users := recordInventory(inventory, kamajiv1alpha1.DataStoreOrphanKindUser, existingUsers, provisionedUsers)
+	if !users.Equal(sets.New("current_user", "deleted_user")) {
+		t.Fatalf("unexpected users inventory %v", sets.List(users))
+	}
+
+	orphans := detectOrphans(nil, kamajiv1alpha1.DataStoreOrphanKindDatabase, sets.List(databases), usedDatabases)
+	orphans = append(orphans, detectOrphans(nil, kamajiv1alpha1.DataStoreOrphanKindUser, sets.List(users), usedUsers)...)
+
+	if len(orphans) != 2 || orphans[0].Name != "deleted_schema" || orphans[1].Name != "deleted_user" {
+		t.Fatalf("unexpected orphans %v", orphans)
+	}
+
+	status := inventoryStatus(map[kamajiv1alpha1.DataStoreOrphanKind]sets.Set[string]{
+		kamajiv1alpha1.DataStoreOrphanKindDatabase: databases,
+		kamajiv1alpha1.DataStoreOrphanKindUser:     users,
+	})
+	if len(status) != 4 || status[0].Kind != kamajiv1alpha1.DataStoreOrphanKindDatabase || status[3].Name != "deleted_user" {
+		t.Fatalf("unexpected inventory status %v", status)
+	}
+}
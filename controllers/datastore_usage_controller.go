@@ -0,0 +1,238 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// DataStoreUsage periodically collects the storage consumed by each Tenant Control Plane on its DataStore,
+// reporting it in the Tenant Control Plane status and as metrics, and enforcing the storage quota.
+type DataStoreUsage struct {
+	Client        client.Client
+	Metrics       *metrics.Recorder
+	EventRecorder events.EventRecorder
+	Connections   *datastore.ConnectionManager
+	Interval      time.Duration
+	// Timeout is the deadline for collecting the usage of all the Tenant Control Planes of a single DataStore.
+	Timeout time.Duration
+	// reported holds the usage series set by the collections, deleted once the Tenant Control Plane is removed,
+	// or moved to another DataStore.
+	reported sets.Set[usageSeries]
+}
+
+type usageSeries struct {
+	namespace, name, dataStore string
+}
+
+func (m *DataStoreUsage) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.collectUsage(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *DataStoreUsage) collectUsage(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("datastore-usage")
+
+	var dsList kamajiv1alpha1.DataStoreList
+	if err := m.Client.List(ctx, &dsList); err != nil {
+		logger.Error(err, "cannot list DataStore objects")
+
+		return
+	}
+
+	for _, ds := range dsList.Items {
+		if !ds.Status.Ready {
+			continue
+		}
+
+		if err := m.collectDataStoreUsage(ctx, ds); err != nil {
+			logger.Error(err, "cannot collect usage", "datastore", ds.GetName())
+		}
+	}
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := m.Client.List(ctx, &tcpList); err != nil {
+		logger.Error(err, "cannot list TenantControlPlane objects")
+
+		return
+	}
+
+	m.deleteRemovedUsage(tcpList.Items)
+}
+
+// deleteRemovedUsage deletes the usage series of the Tenant Control Planes no longer using the reported DataStore:
+// the series of the other ones are kept, although their usage couldn't be collected.
+func (m *DataStoreUsage) deleteRemovedUsage(tenantControlPlanes []kamajiv1alpha1.TenantControlPlane) {
+	current := sets.New[usageSeries]()
+
+	for _, tcp := range tenantControlPlanes {
+		current.Insert(usageSeries{namespace: tcp.GetNamespace(), name: tcp.GetName(), dataStore: tcp.Status.Storage.DataStoreName})
+	}
+
+	for series := range m.reported.Difference(current) {
+		m.metricsRecorder().DeleteTenantControlPlaneDataStoreUsage(series.namespace, series.name, series.dataStore)
+		m.reported.Delete(series)
+	}
+}
+
+func (m *DataStoreUsage) collectDataStoreUsage(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	logger := log.FromContext(ctx).WithName("datastore-usage")
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := m.Client.List(ctx, &tcpList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, ds.GetName()),
+	}); err != nil {
+		return err
+	}
+
+	if len(tcpList.Items) == 0 {
+		return nil
+	}
+
+	connection, err := m.Connections.Connection(ctx, ds)
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	for i := range tcpList.Items {
+		tcp := &tcpList.Items[i]
+
+		if tcp.Status.Storage.Setup.Schema == "" {
+			continue
+		}
+
+		usage, usageErr := connection.Usage(ctx, tcp.Status.Storage.Setup.Schema)
+		if usageErr != nil {
+			logger.Error(usageErr, "cannot retrieve usage", "tenantControlPlane", client.ObjectKeyFromObject(tcp).String())
+
+			continue
+		}
+
+		m.metricsRecorder().SetTenantControlPlaneDataStoreUsage(tcp.GetNamespace(), tcp.GetName(), ds.GetName(), usage.Bytes, usage.Keys)
+
+		if m.reported == nil {
+			m.reported = sets.New[usageSeries]()
+		}
+
+		m.reported.Insert(usageSeries{namespace: tcp.GetNamespace(), name: tcp.GetName(), dataStore: ds.GetName()})
+
+		patch := client.MergeFrom(tcp.DeepCopy())
+
+		tcp.Status.Storage.Usage = kamajiv1alpha1.DataStoreUsageStatus{
+			Bytes:      usage.Bytes,
+			Keys:       usage.Keys,
+			LiveBytes:  usage.LiveBytes,
+			LastUpdate: metav1.Now(),
+		}
+
+		wasExceeded := meta.IsStatusConditionTrue(tcp.Status.Conditions, kamajiv1alpha1.TenantControlPlaneConditionStorageQuotaExceededType)
+
+		quota := tcp.Spec.StorageQuota
+		if quota == nil {
+			quota = ds.Spec.DefaultStorageQuota
+		}
+
+		if quota != nil {
+			meta.SetStatusCondition(&tcp.Status.Conditions, storageQuotaCondition(tcp, *quota, usage.LiveBytes))
+		} else {
+			meta.RemoveStatusCondition(&tcp.Status.Conditions, kamajiv1alpha1.TenantControlPlaneConditionStorageQuotaExceededType)
+		}
+
+		if patchErr := m.Client.Status().Patch(ctx, tcp, patch); patchErr != nil {
+			logger.Error(patchErr, "cannot update usage", "tenantControlPlane", client.ObjectKeyFromObject(tcp).String())
+
+			continue
+		}
+
+		m.recordStorageQuotaEvent(tcp, wasExceeded)
+	}
+
+	return nil
+}
+
+// storageQuotaCondition computes the storage quota condition according to the live bytes of the tenant:
+// writes are limited once the hard threshold is reached, and lifted only when the usage drops below the soft one.
+// The size of the tables and buckets isn't used, since it doesn't shrink upon deletions until the DataStore reclaims the space.
+func storageQuotaCondition(tcp *kamajiv1alpha1.TenantControlPlane, quota kamajiv1alpha1.StorageQuota, bytes int64) metav1.Condition {
+	hard, soft := quota.Hard, quota.SoftLimit()
+	used := resource.NewQuantity(bytes, resource.BinarySI)
+
+	condition := metav1.Condition{
+		Type:               kamajiv1alpha1.TenantControlPlaneConditionStorageQuotaExceededType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: tcp.Generation,
+		Reason:             "WithinQuota",
+		Message:            fmt.Sprintf("storage usage of %s is within the hard limit of %s", used.String(), hard.String()),
+	}
+
+	switch {
+	case used.Cmp(hard) >= 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "HardLimitReached"
+		condition.Message = fmt.Sprintf("storage usage of %s reached the hard limit of %s: creation and update operations are blocked, deletions are allowed to reclaim space", used.String(), hard.String())
+	case meta.IsStatusConditionTrue(tcp.Status.Conditions, condition.Type) && used.Cmp(soft) >= 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "AboveSoftLimit"
+		condition.Message = fmt.Sprintf("storage usage of %s is above the soft limit of %s: creation and update operations are blocked until it drops below", used.String(), soft.String())
+	}
+
+	return condition
+}
+
+func (m *DataStoreUsage) recordStorageQuotaEvent(tcp *kamajiv1alpha1.TenantControlPlane, wasExceeded bool) {
+	if m.EventRecorder == nil {
+		return
+	}
+
+	condition := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.TenantControlPlaneConditionStorageQuotaExceededType)
+	isExceeded := condition != nil && condition.Status == metav1.ConditionTrue
+
+	switch {
+	case !wasExceeded && isExceeded:
+		m.EventRecorder.Eventf(tcp, nil, corev1.EventTypeWarning, "StorageQuotaExceeded", "LimitWrites", "%s", condition.Message)
+	case wasExceeded && !isExceeded:
+		m.EventRecorder.Eventf(tcp, nil, corev1.EventTypeNormal, "StorageQuotaRestored", "AllowWrites", "write block lifted: %s", ptr.Deref(condition, metav1.Condition{Message: "storage quota has been removed"}).Message)
+	}
+}
+
+func (m *DataStoreUsage) metricsRecorder() *metrics.Recorder {
+	if m.Metrics == nil {
+		m.Metrics = metrics.DefaultRecorder()
+	}
+
+	return m.Metrics
+}
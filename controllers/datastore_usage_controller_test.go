@@ -0,0 +1,97 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+func TestStorageQuotaCondition(t *testing.T) {
+	t.Parallel()
+
+	quota := kamajiv1alpha1.StorageQuota{
+		Hard: resource.MustParse("100Mi"),
+		Soft: ptr.To(resource.MustParse("80Mi")),
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	// Each step builds on the condition of the previous one, asserting the hysteresis between the thresholds.
+	steps := []struct {
+		usage    string
+		exceeded bool
+	}{
+		{usage: "90Mi", exceeded: false},
+		{usage: "100Mi", exceeded: true},
+		{usage: "90Mi", exceeded: true},
+		{usage: "79Mi", exceeded: false},
+		{usage: "90Mi", exceeded: false},
+	}
+
+	for _, step := range steps {
+		used := resource.MustParse(step.usage)
+
+		meta.SetStatusCondition(&tcp.Status.Conditions, storageQuotaCondition(tcp, quota, used.Value()))
+
+		if got := meta.IsStatusConditionTrue(tcp.Status.Conditions, kamajiv1alpha1.TenantControlPlaneConditionStorageQuotaExceededType); got != step.exceeded {
+			t.Fatalf("usage %s: expected exceeded to be %t, got %t", step.usage, step.exceeded, got)
+		}
+
+		permissions := tcp.EffectiveWritePermissions()
+		if permissions.BlockCreate != step.exceeded || permissions.BlockUpdate != step.exceeded || permissions.BlockDelete {
+			t.Fatalf("usage %s: unexpected write permissions %+v", step.usage, permissions)
+		}
+	}
+}
+
+func TestStorageQuotaSoftLimitDefaultsToHard(t *testing.T) {
+	t.Parallel()
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		Status: kamajiv1alpha1.TenantControlPlaneStatus{
+			Conditions: []metav1.Condition{{
+				Type:   kamajiv1alpha1.TenantControlPlaneConditionStorageQuotaExceededType,
+				Status: metav1.ConditionTrue,
+			}},
+		},
+	}
+
+	used := resource.MustParse("99Mi")
+
+	condition := storageQuotaCondition(tcp, kamajiv1alpha1.StorageQuota{Hard: resource.MustParse("100Mi")}, used.Value())
+	if condition.Status != metav1.ConditionFalse {
+		t.Fatalf("expected the write block to be lifted below the hard limit, got %s: %s", condition.Status, condition.Message)
+	}
+}
+
+func TestDeleteRemovedUsage(t *testing.T) {
+	t.Parallel()
+
+	kept := usageSeries{namespace: "default", name: "kept", dataStore: "postgresql"}
+	removed := usageSeries{namespace: "default", name: "removed", dataStore: "postgresql"}
+	moved := usageSeries{namespace: "default", name: "moved", dataStore: "postgresql"}
+
+	m := &DataStoreUsage{Metrics: metrics.DefaultRecorder(), reported: sets.New(kept, removed, moved)}
+
+	tenantControlPlanes := []kamajiv1alpha1.TenantControlPlane{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kept"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "moved"}},
+	}
+	tenantControlPlanes[0].Status.Storage.DataStoreName = "postgresql"
+	tenantControlPlanes[1].Status.Storage.DataStoreName = "mysql"
+
+	m.deleteRemovedUsage(tenantControlPlanes)
+	// Only the series of the removed Tenant Control Planes, or of the ones moved to another DataStore, are deleted.
+	if !m.reported.Equal(sets.New(kept)) {
+		t.Fatalf("unexpected reported series %v", m.reported.UnsortedList())
+	}
+}
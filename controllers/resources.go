@@ -160,7 +160,7 @@ func getKubeadmConfigResources(c client.Client, tmpDirectory string, dataStore k
 
 	switch dataStore.Spec.Driver {
 	case kamajiv1alpha1.EtcdDriver:
-		endpoints = dataStore.Spec.Endpoints
+		endpoints = dataStore.ActiveEndpoints()
 	default:
 		endpoints = []string{"127.0.0.1:2379"}
 	}
@@ -11,8 +11,11 @@ import (
 
 	"github.com/go-logr/logr"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	pointer "k8s.io/utils/ptr"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -28,11 +31,13 @@ import (
 	"github.com/clastix/kamaji/api/v1alpha1"
 	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
 type Migrate struct {
 	Client                    client.Client
+	AdminClient               client.Client
 	Logger                    logr.Logger
 	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
 	WebhookNamespace          string
@@ -60,9 +65,13 @@ func (m *Migrate) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile
 
 	switch *tcp.Status.Kubernetes.Version.Status {
 	case v1alpha1.VersionMigrating:
-		err = m.createOrUpdate(ctx)
+		var frozen bool
+
+		if frozen, err = m.freeze(ctx, tcp); err == nil && !frozen {
+			return reconcile.Result{RequeueAfter: time.Second}, nil
+		}
 	case v1alpha1.VersionReady:
-		err = m.cleanup(ctx)
+		err = m.unfreeze(ctx, tcp)
 	}
 
 	if err != nil {
@@ -74,6 +83,76 @@ func (m *Migrate) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile
 	return reconcile.Result{}, nil
 }
 
+// freeze installs the freeze webhook, and reports its enforcement in the Tenant Control Plane status:
+// the webhook configuration is loaded asynchronously by the API Server, thus the migration job must be notified
+// only once the changes are effectively blocked.
+func (m *Migrate) freeze(ctx context.Context, tcp *v1alpha1.TenantControlPlane) (bool, error) {
+	if err := m.createOrUpdate(ctx); err != nil {
+		return false, err
+	}
+
+	if frozen, err := m.isFrozen(ctx); err != nil || !frozen {
+		return false, err
+	}
+
+	return true, m.setFrozenCondition(ctx, tcp, metav1.ConditionTrue, "FreezeWebhookEnforced", "The changes are blocked by the freeze webhook")
+}
+
+func (m *Migrate) unfreeze(ctx context.Context, tcp *v1alpha1.TenantControlPlane) error {
+	if err := m.cleanup(ctx); err != nil {
+		return err
+	}
+
+	if !meta.IsStatusConditionTrue(tcp.Status.Conditions, v1alpha1.TenantControlPlaneConditionFrozenType) {
+		return nil
+	}
+
+	return m.setFrozenCondition(ctx, tcp, metav1.ConditionFalse, "FreezeWebhookRemoved", "The changes are allowed")
+}
+
+// isFrozen returns true if the freeze webhook is enforced by the API Server,
+// by performing a dry-run creation which must be denied by it with the freeze reason.
+func (m *Migrate) isFrozen(ctx context.Context) (bool, error) {
+	probe := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kamaji-freeze-probe",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	err := m.Client.Create(ctx, probe, client.DryRunAll)
+
+	switch {
+	case err == nil, apierrors.IsAlreadyExists(err):
+		return false, nil
+	case apierrors.ReasonForError(err) == constants.FreezeStatusReason:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unable to check the freeze webhook enforcement: %w", err)
+	}
+}
+
+// setFrozenCondition reports the enforcement of the freeze webhook in the Tenant Control Plane status.
+func (m *Migrate) setFrozenCondition(ctx context.Context, tcp *v1alpha1.TenantControlPlane, status metav1.ConditionStatus, reason, message string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.AdminClient.Get(ctx, client.ObjectKeyFromObject(tcp), tcp); err != nil {
+			return err
+		}
+
+		if !meta.SetStatusCondition(&tcp.Status.Conditions, metav1.Condition{
+			Type:               v1alpha1.TenantControlPlaneConditionFrozenType,
+			Status:             status,
+			ObservedGeneration: tcp.Generation,
+			Reason:             reason,
+			Message:            message,
+		}) {
+			return nil
+		}
+
+		return m.AdminClient.Status().Update(ctx, tcp)
+	})
+}
+
 func (m *Migrate) cleanup(ctx context.Context) error {
 	if err := m.Client.Delete(ctx, m.object()); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -58,10 +58,13 @@ func (r *WritePermissions) Reconcile(ctx context.Context, _ reconcile.Request) (
 		return reconcile.Result{RequeueAfter: time.Second}, nil
 	}
 
+	// Along with the declared permissions, writes are blocked when the storage quota is exceeded.
+	writePermissions := tcp.EffectiveWritePermissions()
+
 	switch {
 	case ptr.Deref(tcp.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionUnknown) == kamajiv1alpha1.VersionWriteLimited &&
-		tcp.Spec.WritePermissions.HasAnyLimitation():
-		err = r.createOrUpdate(ctx, tcp.Spec.WritePermissions)
+		writePermissions.HasAnyLimitation():
+		err = r.createOrUpdate(ctx, writePermissions)
 	default:
 		err = r.cleanup(ctx)
 	}
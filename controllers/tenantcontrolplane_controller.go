@@ -62,6 +62,8 @@ type TenantControlPlaneReconciler struct {
 	MaxConcurrentReconciles int
 	ReconcileTimeout        time.Duration
 	DiscoveryClient         discovery.DiscoveryInterface
+	// Connections provides the connections to the DataStore objects, shared across the reconciliations.
+	Connections *datastore.ConnectionManager
 	// CertificateChan is the channel used by the CertificateLifecycleController that is checking for
 	// certificates and kubeconfig user certs validity: a generic event for the given TCP will be triggered
 	// once the validity threshold for the given certificate is reached.
@@ -91,6 +93,7 @@ type TenantControlPlaneReconcilerConfig struct {
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=get;create;delete
 
 //nolint:maintidx
 func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -172,8 +175,24 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		return ctrl.Result{RequeueAfter: time.Second}, nil
 	}
+	// The DataStore capacity is enforced by the admission webhooks as well,
+	// although the concurrent admissions could have exceeded it.
+	if !markedToBeDeleted {
+		admitted, admittedErr := datastore.IsAdmitted(ctx, r.Client, ds, tenantControlPlane)
+		if admittedErr != nil {
+			log.Error(admittedErr, "cannot check the DataStore capacity for the given instance")
+
+			return ctrl.Result{}, admittedErr
+		}
+
+		if !admitted {
+			log.Info("cannot reconcile since DataStore has reached its maximum capacity", "dataStore", ds.GetName())
+
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
 
-	dsConnection, err := datastore.NewStorageConnection(ctx, r.Client, *ds)
+	dsConnection, err := r.Connections.Connection(ctx, *ds)
 	if err != nil {
 		log.Error(err, "cannot generate the DataStore connection for the given instance")
 
@@ -189,7 +208,7 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 	dsoConnections := make(map[string]datastore.Connection, len(dso))
 	for _, ds := range dso {
-		dsoConnection, err := datastore.NewStorageConnection(ctx, r.Client, ds.DataStore)
+		dsoConnection, err := r.Connections.Connection(ctx, ds.DataStore)
 		if err != nil {
 			log.Error(err, "cannot generate the DataStoreOverride connection for the given instance")
 
@@ -299,6 +318,26 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		return ctrl.Result{}, err
 	}
+	// The Graceful rotation of the Certificate Authority moves to the next phase once the soak period elapsed,
+	// as the Service Account signing key is rotated on schedule, and the retired ones dropped once their retention period elapsed.
+	// The NATS user JWTs are expiring, thus they must be renewed periodically.
+	var requeueAfter time.Duration
+
+	for _, after := range []time.Duration{
+		resources.CertificateAuthorityRotationRequeueAfter(tenantControlPlane),
+		resources.ServiceAccountKeyRotationRequeueAfter(tenantControlPlane),
+		datastore.NATSCredentialsRequeueAfter(tenantControlPlane),
+	} {
+		if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+
+	if requeueAfter > 0 {
+		log.Info("enqueuing back for the keys rotation and the credentials renewal", "after", requeueAfter.String())
+
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
 
 	return ctrl.Result{}, nil
 }
@@ -0,0 +1,345 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/backup"
+)
+
+const (
+	backupComponentLabel = "kamaji.clastix.io/component"
+	// backupOwnerLabel is the hash of the owner namespaced name, since names exceed the label value length.
+	backupOwnerLabel          = "backup.kamaji.clastix.io/owner"
+	backupNameAnnotation      = "backup.kamaji.clastix.io/name"
+	backupNamespaceAnnotation = "backup.kamaji.clastix.io/namespace"
+	backupArchiveAnnotation   = "backup.kamaji.clastix.io/archive"
+	// backupRequeueInterval is used when waiting for resources not watched by the controllers,
+	// such as a missing Tenant Control Plane.
+	backupRequeueInterval = time.Minute
+)
+
+type TenantControlPlaneBackupReconciler struct {
+	Client               client.Client
+	KamajiNamespace      string
+	KamajiServiceAccount string
+	// BackupImage is the container image running the backup and restore Jobs.
+	BackupImage string
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanebackups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanebackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete;deletecollection
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+
+func (r *TenantControlPlaneBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var tcpBackup kamajiv1alpha1.TenantControlPlaneBackup
+	if err := r.Client.Get(ctx, req.NamespacedName, &tcpBackup); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, cleaning up Jobs")
+
+			return ctrl.Result{}, deleteBackupJobs(ctx, r.Client, r.KamajiNamespace, "backup", req.NamespacedName)
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return ctrl.Result{}, err
+	}
+
+	if utils.IsPaused(&tcpBackup) {
+		logger.Info("paused reconciliation, no further actions")
+
+		return ctrl.Result{}, nil
+	}
+
+	result, finished, err := r.handle(ctx, &tcpBackup, time.Now())
+	if err != nil {
+		logger.Error(err, "cannot handle the request")
+
+		return ctrl.Result{}, err
+	}
+
+	tcpBackup.Status.ObservedGeneration = tcpBackup.Generation
+
+	if statusErr := r.Client.Status().Update(ctx, &tcpBackup); statusErr != nil {
+		logger.Error(statusErr, "cannot update resource status")
+
+		return ctrl.Result{}, statusErr
+	}
+	// The outcome of the finished Jobs has been persisted, they are no longer needed:
+	// upon a failed status update they're retained, and recorded again at the next reconciliation.
+	for i := range finished {
+		if err = r.Client.Delete(ctx, &finished[i], client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "cannot delete finished Job", "job", finished[i].GetName())
+
+			return ctrl.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// handle records the outcome of the finished Jobs, returned to be deleted once the status is persisted,
+// and starts the next backup when due.
+func (r *TenantControlPlaneBackupReconciler) handle(ctx context.Context, tcpBackup *kamajiv1alpha1.TenantControlPlaneBackup, now time.Time) (ctrl.Result, []batchv1.Job, error) {
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tcpBackup.GetNamespace(), Name: tcpBackup.Spec.TenantControlPlane}, &tcp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil, err
+		}
+
+		setBackupReadyCondition(tcpBackup, metav1.ConditionFalse, "TenantControlPlaneNotFound", fmt.Sprintf("the TenantControlPlane %s does not exist", tcpBackup.Spec.TenantControlPlane))
+
+		return ctrl.Result{RequeueAfter: backupRequeueInterval}, nil, nil
+	}
+
+	var jobs batchv1.JobList
+	if err := r.Client.List(ctx, &jobs, client.InNamespace(r.KamajiNamespace), backupJobLabels("backup", client.ObjectKeyFromObject(tcpBackup))); err != nil {
+		return ctrl.Result{}, nil, err
+	}
+
+	tcpBackup.Status.ActiveJob = ""
+
+	var finished []batchv1.Job
+
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		// Tracking the schedule from the Jobs too, in case the status update failed upon their creation.
+		if last := tcpBackup.Status.LastScheduleTime; last == nil || last.Before(&job.CreationTimestamp) {
+			tcpBackup.Status.LastScheduleTime = job.CreationTimestamp.DeepCopy()
+		}
+
+		condition := finishedJobCondition(job)
+		if condition == nil {
+			tcpBackup.Status.ActiveJob = job.GetName()
+
+			continue
+		}
+
+		if condition.Type == batchv1.JobComplete {
+			recordBackupArchive(tcpBackup, job.GetAnnotations()[backupArchiveAnnotation], condition.LastTransitionTime)
+			setBackupReadyCondition(tcpBackup, metav1.ConditionTrue, "BackupSucceeded", fmt.Sprintf("the archive %s has been stored", job.GetAnnotations()[backupArchiveAnnotation]))
+		} else {
+			setBackupReadyCondition(tcpBackup, metav1.ConditionFalse, "BackupFailed", fmt.Sprintf("the Job %s/%s failed: %s", job.GetNamespace(), job.GetName(), condition.Message))
+		}
+
+		finished = append(finished, *job)
+	}
+
+	if tcpBackup.Status.ActiveJob != "" {
+		return ctrl.Result{}, finished, nil
+	}
+
+	next, err := nextBackupTime(tcpBackup)
+	if err != nil {
+		setBackupReadyCondition(tcpBackup, metav1.ConditionFalse, "InvalidSchedule", err.Error())
+
+		return ctrl.Result{}, finished, nil
+	}
+
+	if next == nil {
+		return ctrl.Result{}, finished, nil
+	}
+
+	if next.After(now) {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, finished, nil
+	}
+
+	if tcp.Status.Storage.Setup.Schema == "" {
+		setBackupReadyCondition(tcpBackup, metav1.ConditionFalse, "TenantControlPlaneNotProvisioned", "the TenantControlPlane storage has not been provisioned yet")
+
+		return ctrl.Result{RequeueAfter: backupRequeueInterval}, finished, nil
+	}
+
+	if err = backup.CheckTargetAccess(ctx, r.Client, r.KamajiNamespace, tcpBackup.Spec.Target, tcpBackup.GetNamespace()); err != nil {
+		setBackupReadyCondition(tcpBackup, metav1.ConditionFalse, "TargetNotAllowed", err.Error())
+
+		return ctrl.Result{RequeueAfter: backupRequeueInterval}, finished, nil
+	}
+
+	archive := backup.ArchiveName(tcp.GetNamespace(), tcp.GetName(), tcpBackup.GetName(), now)
+
+	job := newBackupJob(r.KamajiNamespace, r.KamajiServiceAccount, r.BackupImage, "backup", tcpBackup,
+		fmt.Sprintf("backup-%s-%d", tcpBackup.GetUID(), now.Unix()), archive, tcpBackup.Spec.Target,
+		"backup",
+		fmt.Sprintf("--tenant-control-plane=%s/%s", tcp.GetNamespace(), tcp.GetName()),
+		fmt.Sprintf("--archive=%s", archive),
+		fmt.Sprintf("--keep-last=%d", tcpBackup.Spec.Retention.KeepLast),
+	)
+
+	if err = r.Client.Create(ctx, job); err != nil {
+		return ctrl.Result{}, nil, fmt.Errorf("unable to launch backup job: %w", err)
+	}
+
+	tcpBackup.Status.ActiveJob = job.GetName()
+	tcpBackup.Status.LastScheduleTime = &metav1.Time{Time: now}
+
+	return ctrl.Result{}, finished, nil
+}
+
+// newBackupJob returns the Job running the backup, or the restore, command on behalf of the given owner,
+// with the storage flags and volumes matching the target.
+func newBackupJob(namespace, serviceAccount, image, component string, owner client.Object, name, archive string, target kamajiv1alpha1.BackupTarget, args ...string) *batchv1.Job {
+	storageArgs, volumes, volumeMounts := backup.JobStorageArgs(target, owner.GetNamespace())
+
+	job := newKamajiJob(namespace, serviceAccount, image, component, owner, name, append(args, storageArgs...)...)
+	job.Annotations[backupArchiveAnnotation] = archive
+	job.Spec.Template.Spec.Volumes = volumes
+	job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+
+	return job
+}
+
+// newKamajiJob returns the Job running the given Kamaji command on behalf of the given owner, labelled after it:
+// Jobs are running in the Kamaji namespace, as the migration ones.
+func newKamajiJob(namespace, serviceAccount, image, component string, owner client.Object, name string, args ...string) *batchv1.Job {
+	labels := backupJobLabels(component, client.ObjectKeyFromObject(owner))
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				backupNameAnnotation:      owner.GetName(),
+				backupNamespaceAnnotation: owner.GetNamespace(),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: serviceAccount,
+					RestartPolicy:      corev1.RestartPolicyOnFailure,
+					Containers: []corev1.Container{
+						{
+							Name:  component,
+							Image: image,
+							Args:  args,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// nextBackupTime returns when the next backup must be taken, or nil when no further backups are expected:
+// missed schedules are not recovered, a single backup is taken instead.
+func nextBackupTime(tcpBackup *kamajiv1alpha1.TenantControlPlaneBackup) (*time.Time, error) {
+	if tcpBackup.Spec.Schedule == "" {
+		if tcpBackup.Status.LastScheduleTime != nil {
+			return nil, nil //nolint:nilnil
+		}
+
+		return &tcpBackup.CreationTimestamp.Time, nil
+	}
+
+	schedule, err := cron.ParseStandard(tcpBackup.Spec.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the schedule %q: %w", tcpBackup.Spec.Schedule, err)
+	}
+
+	last := tcpBackup.CreationTimestamp.Time
+	if tcpBackup.Status.LastScheduleTime != nil {
+		last = tcpBackup.Status.LastScheduleTime.Time
+	}
+
+	next := schedule.Next(last)
+
+	return &next, nil
+}
+
+// recordBackupArchive appends the archive to the status, retaining the same archives kept by the retention:
+// an archive already recorded, since the deletion of its Job failed, is skipped.
+func recordBackupArchive(tcpBackup *kamajiv1alpha1.TenantControlPlaneBackup, archive string, completionTime metav1.Time) {
+	if last := tcpBackup.Status.LastSuccessfulTime; last != nil && !completionTime.After(last.Time) {
+		return
+	}
+
+	tcpBackup.Status.Archives = append(tcpBackup.Status.Archives, kamajiv1alpha1.BackupArchive{Name: archive, CreationTimestamp: completionTime})
+	tcpBackup.Status.LastSuccessfulTime = &completionTime
+
+	if keep := int(tcpBackup.Spec.Retention.KeepLast); keep > 0 && len(tcpBackup.Status.Archives) > keep {
+		tcpBackup.Status.Archives = tcpBackup.Status.Archives[len(tcpBackup.Status.Archives)-keep:]
+	}
+}
+
+func setBackupReadyCondition(tcpBackup *kamajiv1alpha1.TenantControlPlaneBackup, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&tcpBackup.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.TenantControlPlaneBackupConditionReadyType,
+		Status:             status,
+		ObservedGeneration: tcpBackup.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// finishedJobCondition returns the terminal condition of the Job, if any.
+func finishedJobCondition(job *batchv1.Job) *batchv1.JobCondition {
+	// Note: job.Status.Conditions can contain more than one condition on Kubernetes versions greater than v1.30
+	for i, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// backupJobLabels returns the labels selecting the Jobs of the given owner:
+// the owner is referenced by the annotations, its name could exceed the label value length.
+func backupJobLabels(component string, key types.NamespacedName) client.MatchingLabels {
+	return client.MatchingLabels{
+		backupComponentLabel: component,
+		backupOwnerLabel:     fmt.Sprintf("%x", md5.Sum([]byte(key.String()))),
+	}
+}
+
+func deleteBackupJobs(ctx context.Context, c client.Client, namespace, component string, key types.NamespacedName) error {
+	return c.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace(namespace), backupJobLabels(component, key), client.PropagationPolicy(metav1.DeletePropagationBackground))
+}
+
+// enqueueFromBackupJob maps the backup, restore, and clone Jobs to the object which started them.
+func enqueueFromBackupJob(namespace, component string) (handler.EventHandler, builder.Predicates) {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			annotations := object.GetAnnotations()
+
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: annotations[backupNamespaceAnnotation], Name: annotations[backupNameAnnotation]}}}
+		}), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetNamespace() == namespace && object.GetLabels()[backupComponentLabel] == component
+		}))
+}
+
+func (r *TenantControlPlaneBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	jobHandler, jobPredicates := enqueueFromBackupJob(r.KamajiNamespace, "backup")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kamajiv1alpha1.TenantControlPlaneBackup{}).
+		Watches(&batchv1.Job{}, jobHandler, jobPredicates).
+		Complete(r)
+}
@@ -0,0 +1,271 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/backup"
+)
+
+func TestNextBackupTime(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC)
+
+	tcpBackup := &kamajiv1alpha1.TenantControlPlaneBackup{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+	}
+	// Without a schedule, a single backup is taken.
+	if next, err := nextBackupTime(tcpBackup); err != nil || next == nil || !next.Equal(created) {
+		t.Fatalf("expected the one-off backup to be due upon creation, got %v (%v)", next, err)
+	}
+
+	tcpBackup.Status.LastScheduleTime = &metav1.Time{Time: created}
+	if next, err := nextBackupTime(tcpBackup); err != nil || next != nil {
+		t.Fatalf("expected no further one-off backups, got %v (%v)", next, err)
+	}
+
+	tcpBackup.Spec.Schedule = "0 */6 * * *"
+	if next, err := nextBackupTime(tcpBackup); err != nil || !next.Equal(time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected next scheduled backup %v (%v)", next, err)
+	}
+
+	tcpBackup.Spec.Schedule = "every hour"
+	if _, err := nextBackupTime(tcpBackup); err == nil {
+		t.Fatal("expected an error for an invalid schedule")
+	}
+}
+
+func TestTenantControlPlaneBackupJobs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+
+	tcpBackup := &kamajiv1alpha1.TenantControlPlaneBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "tenants", UID: "uid", CreationTimestamp: metav1.NewTime(now.Add(-13 * time.Hour))},
+		Spec: kamajiv1alpha1.TenantControlPlaneBackupSpec{
+			TenantControlPlane: "tcp",
+			Target: kamajiv1alpha1.BackupTarget{
+				PersistentVolumeClaim: &kamajiv1alpha1.PersistentVolumeClaimBackupTarget{ClaimName: "backups", SubPath: "kamaji"},
+			},
+			Schedule:  "0 0 * * *",
+			Retention: kamajiv1alpha1.BackupRetention{KeepLast: 1},
+		},
+		Status: kamajiv1alpha1.TenantControlPlaneBackupStatus{
+			Archives: []kamajiv1alpha1.BackupArchive{{Name: "tenants/tcp/nightly-20261016000000.tar.gz"}},
+		},
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "tenants"},
+		Status: kamajiv1alpha1.TenantControlPlaneStatus{
+			Storage: kamajiv1alpha1.StorageStatus{Setup: kamajiv1alpha1.DataStoreSetupStatus{Schema: "tenants_tcp"}},
+		},
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "backups", Namespace: "kamaji-system", Annotations: map[string]string{backup.AllowedNamespacesAnnotation: "other"}},
+	}
+
+	c := newFakeClient(t, tcp, claim)
+
+	r := &TenantControlPlaneBackupReconciler{Client: c, KamajiNamespace: "kamaji-system", KamajiServiceAccount: "kamaji", BackupImage: "clastix/kamaji:latest"}
+	// The claim of the Kamaji namespace must allow the namespace of the backup.
+	if _, _, err := r.handle(t.Context(), tcpBackup, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var jobs batchv1.JobList
+	if err := c.List(t.Context(), &jobs, client.InNamespace("kamaji-system")); err != nil || len(jobs.Items) != 0 {
+		t.Fatalf("expected no backup job for a not allowed claim, got %d (%v)", len(jobs.Items), err)
+	}
+
+	if condition := meta.FindStatusCondition(tcpBackup.Status.Conditions, kamajiv1alpha1.TenantControlPlaneBackupConditionReadyType); condition == nil || condition.Reason != "TargetNotAllowed" {
+		t.Fatalf("unexpected conditions %+v", tcpBackup.Status.Conditions)
+	}
+
+	claim.Annotations[backup.AllowedNamespacesAnnotation] = "other,tenants"
+	if err := c.Update(t.Context(), claim); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := r.handle(t.Context(), tcpBackup, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.List(t.Context(), &jobs, client.InNamespace("kamaji-system")); err != nil || len(jobs.Items) != 1 {
+		t.Fatalf("expected a single backup job, got %d (%v)", len(jobs.Items), err)
+	}
+
+	job := jobs.Items[0]
+
+	if tcpBackup.Status.ActiveJob != job.GetName() || tcpBackup.Status.LastScheduleTime == nil {
+		t.Fatalf("unexpected status %+v", tcpBackup.Status)
+	}
+
+	pod := job.Spec.Template.Spec
+	if pod.ServiceAccountName != "kamaji" || pod.Volumes[0].PersistentVolumeClaim.ClaimName != "backups" || pod.Containers[0].VolumeMounts[0].SubPath != "kamaji/tenants" {
+		t.Fatalf("unexpected job pod spec %+v", pod)
+	}
+	// An active Job prevents any further backup.
+	if _, _, err := r.handle(t.Context(), tcpBackup, now.Add(48*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.List(t.Context(), &jobs, client.InNamespace("kamaji-system")); err != nil || len(jobs.Items) != 1 {
+		t.Fatalf("expected a single backup job, got %d (%v)", len(jobs.Items), err)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(time.Minute))}}
+	if err := c.Status().Update(t.Context(), &job); err != nil {
+		t.Fatal(err)
+	}
+
+	result, finished, err := r.handle(t.Context(), tcpBackup, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.RequeueAfter != 11*time.Hour {
+		t.Fatalf("expected the next backup to be scheduled at midnight, requeued after %s", result.RequeueAfter)
+	}
+
+	if len(tcpBackup.Status.Archives) != 1 || tcpBackup.Status.Archives[0].Name != "tenants/tcp/nightly-20261017120000.tar.gz" {
+		t.Fatalf("expected the archives to follow the retention, got %+v", tcpBackup.Status.Archives)
+	}
+
+	if !meta.IsStatusConditionTrue(tcpBackup.Status.Conditions, kamajiv1alpha1.TenantControlPlaneBackupConditionReadyType) || tcpBackup.Status.ActiveJob != "" {
+		t.Fatalf("unexpected status %+v", tcpBackup.Status)
+	}
+
+	if len(finished) != 1 || finished[0].GetName() != job.GetName() {
+		t.Fatalf("expected the completed job to be returned for deletion, got %+v", finished)
+	}
+	// The completed Job is deleted only once the status has been persisted.
+	if err = c.List(t.Context(), &jobs, client.InNamespace("kamaji-system")); err != nil || len(jobs.Items) != 1 {
+		t.Fatalf("expected the completed job to be retained, got %d (%v)", len(jobs.Items), err)
+	}
+	// Recording the outcome of the same Job again doesn't duplicate its archive.
+	if _, _, err = r.handle(t.Context(), tcpBackup, now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tcpBackup.Status.Archives) != 1 {
+		t.Fatalf("expected the archive to be recorded once, got %+v", tcpBackup.Status.Archives)
+	}
+}
+
+func TestTenantControlPlaneBackupFinishedJobsDeletion(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tcpBackup := &kamajiv1alpha1.TenantControlPlaneBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "once", Namespace: "tenants", UID: "uid"},
+		Spec: kamajiv1alpha1.TenantControlPlaneBackupSpec{
+			TenantControlPlane: "tcp",
+			Target: kamajiv1alpha1.BackupTarget{
+				PersistentVolumeClaim: &kamajiv1alpha1.PersistentVolumeClaimBackupTarget{ClaimName: "backups"},
+			},
+		},
+		Status: kamajiv1alpha1.TenantControlPlaneBackupStatus{LastScheduleTime: &metav1.Time{Time: now.Add(-time.Hour)}},
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "tenants"}}
+
+	job := newBackupJob("kamaji-system", "kamaji", "clastix/kamaji:latest", "backup", tcpBackup, "backup-uid", "tenants/tcp/once.tar.gz", tcpBackup.Spec.Target)
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))}}
+
+	failing := true
+
+	c := newFakeClientBuilder(t, tcpBackup, tcp, job).
+		WithStatusSubresource(tcpBackup, job).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if failing {
+					return apierrors.NewConflict(kamajiv1alpha1.GroupVersion.WithResource("tenantcontrolplanebackups").GroupResource(), obj.GetName(), nil)
+				}
+
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &TenantControlPlaneBackupReconciler{Client: c, KamajiNamespace: "kamaji-system", KamajiServiceAccount: "kamaji", BackupImage: "clastix/kamaji:latest"}
+	// Upon a failed status update, the finished Job is retained along with its outcome.
+	if _, err := r.Reconcile(t.Context(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tcpBackup)}); err == nil {
+		t.Fatal("expected the status update to fail")
+	}
+
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(job), &batchv1.Job{}); err != nil {
+		t.Fatalf("expected the finished job to be retained: %v", err)
+	}
+
+	failing = false
+
+	if _, err := r.Reconcile(t.Context(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tcpBackup)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(job), &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the finished job to be deleted, got %v", err)
+	}
+
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(tcpBackup), tcpBackup); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tcpBackup.Status.Archives) != 1 || tcpBackup.Status.Archives[0].Name != "tenants/tcp/once.tar.gz" {
+		t.Fatalf("expected the archive to be persisted, got %+v", tcpBackup.Status.Archives)
+	}
+}
+
+func TestNewKamajiJobLongOwnerName(t *testing.T) {
+	t.Parallel()
+
+	owner := &kamajiv1alpha1.TenantControlPlaneBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 253), Namespace: strings.Repeat("b", 63)},
+	}
+
+	job := newKamajiJob("kamaji-system", "kamaji", "clastix/kamaji:latest", "backup", owner, "backup-uid")
+
+	for key, value := range job.GetLabels() {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			t.Fatalf("invalid value of the label %s: %v", key, errs)
+		}
+	}
+
+	if !labels.SelectorFromSet(labels.Set(backupJobLabels("backup", client.ObjectKeyFromObject(owner)))).Matches(labels.Set(job.GetLabels())) {
+		t.Fatalf("expected the job labels %v to match its owner", job.GetLabels())
+	}
+
+	eventHandler, _ := enqueueFromBackupJob("kamaji-system", "backup")
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+
+	eventHandler.Create(t.Context(), event.CreateEvent{Object: job}, queue)
+
+	if request, _ := queue.Get(); request.NamespacedName != client.ObjectKeyFromObject(owner) {
+		t.Fatalf("expected the job to be mapped to its owner, got %s", request.NamespacedName)
+	}
+}
@@ -0,0 +1,466 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/backup"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// clonePKIPendingValue is the value of the paused reconciliation annotation of a clone created reusing the source PKI:
+// it's removed once the PKI Secrets have been written, otherwise Kamaji would generate them.
+const clonePKIPendingValue = "pki"
+
+type TenantControlPlaneCloneReconciler struct {
+	Client client.Client
+	// APIReader lists the allocated NodePorts bypassing the cache, which could miss the clones just created.
+	APIReader            client.Reader
+	KamajiNamespace      string
+	KamajiServiceAccount string
+	// CloneImage is the container image running the clone Jobs.
+	CloneImage string
+	// NodePortRange is the NodePort range of the management cluster, which the ports of the clones are allocated from.
+	NodePortRange utilnet.PortRange
+
+	// nodePortsLock serializes the allocation of the NodePorts along with the creation of the clone,
+	// preventing concurrent clones from picking the same ports.
+	nodePortsLock sync.Mutex
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplaneclones,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplaneclones/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+
+func (r *TenantControlPlaneCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var tcpClone kamajiv1alpha1.TenantControlPlaneClone
+	if err := r.Client.Get(ctx, req.NamespacedName, &tcpClone); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, cleaning up Jobs")
+
+			return ctrl.Result{}, deleteBackupJobs(ctx, r.Client, r.KamajiNamespace, "clone", req.NamespacedName)
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return ctrl.Result{}, err
+	}
+
+	if utils.IsPaused(&tcpClone) {
+		logger.Info("paused reconciliation, no further actions")
+
+		return ctrl.Result{}, nil
+	}
+
+	if tcpClone.Status.Phase == kamajiv1alpha1.ClonePhaseCompleted || tcpClone.Status.Phase == kamajiv1alpha1.ClonePhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.handle(ctx, &tcpClone, time.Now())
+	if err != nil {
+		logger.Error(err, "cannot handle the request")
+
+		return ctrl.Result{}, err
+	}
+
+	if statusErr := r.Client.Status().Update(ctx, &tcpClone); statusErr != nil {
+		logger.Error(statusErr, "cannot update resource status")
+
+		return ctrl.Result{}, statusErr
+	}
+
+	return result, nil
+}
+
+func (r *TenantControlPlaneCloneReconciler) handle(ctx context.Context, tcpClone *kamajiv1alpha1.TenantControlPlaneClone, now time.Time) (ctrl.Result, error) {
+	var clone kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tcpClone.GetNamespace(), Name: tcpClone.Spec.TenantControlPlane}, &clone); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		if tcpClone.Status.Phase != "" && tcpClone.Status.Phase != kamajiv1alpha1.ClonePhasePending {
+			setCloneFailed(tcpClone, now, fmt.Sprintf("the clone TenantControlPlane %s has been deleted", tcpClone.Spec.TenantControlPlane))
+
+			return ctrl.Result{}, nil
+		}
+
+		return r.start(ctx, tcpClone, now)
+	}
+
+	if clone.GetAnnotations()[kamajiv1alpha1.CloneAnnotation] != tcpClone.GetName() {
+		setCloneFailed(tcpClone, now, fmt.Sprintf("the TenantControlPlane %s already exists, and it has not been created by this clone", clone.GetName()))
+
+		return ctrl.Result{}, nil
+	}
+
+	if clone.GetAnnotations()[kamajiv1alpha1.PausedReconciliationAnnotation] == clonePKIPendingValue {
+		return ctrl.Result{}, r.writePKI(ctx, tcpClone, &clone, now)
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.KamajiNamespace, Name: fmt.Sprintf("clone-%s", tcpClone.GetUID())}, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		return r.copy(ctx, tcpClone, &clone, now)
+	}
+
+	condition := finishedJobCondition(job)
+
+	switch {
+	case condition == nil:
+		tcpClone.Status.Phase = kamajiv1alpha1.ClonePhaseCopying
+		tcpClone.Status.Message = fmt.Sprintf("the keyspace is being copied by the Job %s/%s", job.GetNamespace(), job.GetName())
+	case condition.Type == batchv1.JobComplete:
+		// The clone has been created with no replicas, preventing the API Server from running on an empty keyspace.
+		patch := client.MergeFrom(clone.DeepCopy())
+		clone.Spec.ControlPlane.Deployment.Replicas = tcpClone.Status.Replicas
+
+		if err := r.Client.Patch(ctx, &clone, patch); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to scale the clone TenantControlPlane: %w", err)
+		}
+
+		tcpClone.Status.Phase = kamajiv1alpha1.ClonePhaseCompleted
+		tcpClone.Status.Message = "the keyspace has been copied, and the clone TenantControlPlane has been scaled up"
+		tcpClone.Status.CompletionTime = condition.LastTransitionTime.DeepCopy()
+	default:
+		setCloneFailed(tcpClone, now, fmt.Sprintf("the Job %s/%s failed: %s", job.GetNamespace(), job.GetName(), condition.Message))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// start creates the clone Tenant Control Plane once the source one has been provisioned, copying the PKI if required.
+func (r *TenantControlPlaneCloneReconciler) start(ctx context.Context, tcpClone *kamajiv1alpha1.TenantControlPlaneClone, now time.Time) (ctrl.Result, error) {
+	pending := func(message string) (ctrl.Result, error) {
+		tcpClone.Status.Phase = kamajiv1alpha1.ClonePhasePending
+		tcpClone.Status.Message = message
+
+		return ctrl.Result{RequeueAfter: backupRequeueInterval}, nil
+	}
+
+	var source kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tcpClone.GetNamespace(), Name: tcpClone.Spec.Source}, &source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return pending(fmt.Sprintf("the TenantControlPlane %s does not exist", tcpClone.Spec.Source))
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if source.Status.Storage.Setup.Schema == "" || source.Status.Storage.DataStoreName == "" {
+		return pending(fmt.Sprintf("the TenantControlPlane %s storage has not been provisioned yet", source.GetName()))
+	}
+
+	if (source.Spec.ControlPlane.Ingress != nil || source.Spec.ControlPlane.Gateway != nil) && tcpClone.Spec.Hostname == "" {
+		setCloneFailed(tcpClone, now, fmt.Sprintf("the TenantControlPlane %s is exposed through an Ingress or a Gateway, a hostname is required", source.GetName()))
+
+		return ctrl.Result{}, nil
+	}
+
+	secrets, err := r.sourcePKISecrets(ctx, tcpClone, &source)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return pending(fmt.Sprintf("the TenantControlPlane %s PKI has not been provisioned yet", source.GetName()))
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	clone := newCloneTenantControlPlane(tcpClone, &source)
+	// The PKI Secrets are controlled by the clone, thus written by writePKI once it has been created:
+	// it's kept paused meanwhile, otherwise Kamaji would generate them.
+	if len(secrets) > 0 {
+		clone.Annotations[kamajiv1alpha1.PausedReconciliationAnnotation] = clonePKIPendingValue
+	}
+
+	r.nodePortsLock.Lock()
+	defer r.nodePortsLock.Unlock()
+
+	if err = r.allocateNodePorts(ctx, clone); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.Client.Create(ctx, clone); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to create the clone TenantControlPlane: %w", err)
+	}
+
+	tcpClone.Status.Phase = kamajiv1alpha1.ClonePhaseProvisioning
+	tcpClone.Status.Message = fmt.Sprintf("the clone TenantControlPlane %s has been created, waiting for its storage to be provisioned", clone.GetName())
+	tcpClone.Status.StartTime = &metav1.Time{Time: now}
+	tcpClone.Status.Replicas = tcpClone.Spec.Replicas
+
+	if tcpClone.Status.Replicas == nil {
+		tcpClone.Status.Replicas = source.Spec.ControlPlane.Deployment.Replicas
+	}
+	// The creation of the clone enqueues the request back, writing the PKI Secrets.
+	return ctrl.Result{}, nil
+}
+
+// sourcePKISecrets returns the data of the source PKI Secrets reused by the clone, keyed by their name without the tenant prefix.
+func (r *TenantControlPlaneCloneReconciler) sourcePKISecrets(ctx context.Context, tcpClone *kamajiv1alpha1.TenantControlPlaneClone, source *kamajiv1alpha1.TenantControlPlane) (map[string]map[string][]byte, error) {
+	var reused []string
+
+	if tcpClone.Spec.PKI.CertificateAuthorities == kamajiv1alpha1.ClonePKIReuse {
+		reused = append(reused, "ca", "front-proxy-ca-certificate")
+	}
+
+	if tcpClone.Spec.PKI.ServiceAccountKeys == kamajiv1alpha1.ClonePKIReuse {
+		reused = append(reused, "sa-certificate")
+	}
+
+	secrets := make(map[string]map[string][]byte, len(reused))
+
+	for _, name := range reused {
+		var secret corev1.Secret
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: source.GetNamespace(), Name: utilities.AddTenantPrefix(name, source)}, &secret); err != nil {
+			return nil, err
+		}
+
+		secrets[name] = secret.Data
+	}
+
+	return secrets, nil
+}
+
+// writePKI writes the source PKI Secrets reused by the paused clone, controlled by it, and resumes its reconciliation:
+// the clone fails if a Secret already exists, and it's not controlled by the clone.
+func (r *TenantControlPlaneCloneReconciler) writePKI(ctx context.Context, tcpClone *kamajiv1alpha1.TenantControlPlaneClone, clone *kamajiv1alpha1.TenantControlPlane, now time.Time) error {
+	var source kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tcpClone.GetNamespace(), Name: tcpClone.Spec.Source}, &source); err != nil {
+		if apierrors.IsNotFound(err) {
+			setCloneFailed(tcpClone, now, fmt.Sprintf("the TenantControlPlane %s has been deleted", tcpClone.Spec.Source))
+
+			return nil
+		}
+
+		return err
+	}
+
+	secrets, err := r.sourcePKISecrets(ctx, tcpClone, &source)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the source PKI: %w", err)
+	}
+
+	for name := range secrets {
+		var secret corev1.Secret
+		if err = r.Client.Get(ctx, types.NamespacedName{Namespace: clone.GetNamespace(), Name: utilities.AddTenantPrefix(name, clone)}, &secret); err == nil && !metav1.IsControlledBy(&secret, clone) {
+			setCloneFailed(tcpClone, now, fmt.Sprintf("the Secret %s already exists, and it's not controlled by the clone TenantControlPlane", secret.GetName()))
+
+			return nil
+		}
+	}
+
+	if err = backup.WritePKISecrets(ctx, r.Client, clone, secrets); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(clone.DeepCopy())
+	delete(clone.Annotations, kamajiv1alpha1.PausedReconciliationAnnotation)
+
+	if err = r.Client.Patch(ctx, clone, patch); err != nil {
+		return fmt.Errorf("unable to resume the clone TenantControlPlane: %w", err)
+	}
+
+	return nil
+}
+
+// copy launches the clone Job once the clone storage has been provisioned, and its API Server is not running.
+func (r *TenantControlPlaneCloneReconciler) copy(ctx context.Context, tcpClone *kamajiv1alpha1.TenantControlPlaneClone, clone *kamajiv1alpha1.TenantControlPlane, now time.Time) (ctrl.Result, error) {
+	tcpClone.Status.Phase = kamajiv1alpha1.ClonePhaseProvisioning
+
+	if ptr.Deref(clone.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionUnknown) != kamajiv1alpha1.VersionSleeping || clone.Status.Storage.Setup.Schema == "" {
+		tcpClone.Status.Message = fmt.Sprintf("waiting for the clone TenantControlPlane %s storage to be provisioned", clone.GetName())
+
+		return ctrl.Result{}, nil
+	}
+
+	var source kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tcpClone.GetNamespace(), Name: tcpClone.Spec.Source}, &source); err != nil {
+		if apierrors.IsNotFound(err) {
+			setCloneFailed(tcpClone, now, fmt.Sprintf("the TenantControlPlane %s has been deleted", tcpClone.Spec.Source))
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+	// The clone keyspace is dropped before the copy: sharing it with the source would lead to data loss.
+	if sourceSetup, cloneSetup := source.Status.Storage.Setup, clone.Status.Storage.Setup; cloneSetup.Schema == sourceSetup.Schema || (cloneSetup.User != "" && cloneSetup.User == sourceSetup.User) {
+		setCloneFailed(tcpClone, now, "the clone DataStore schema or username collides with the source ones")
+
+		return ctrl.Result{}, nil
+	}
+
+	job := newKamajiJob(r.KamajiNamespace, r.KamajiServiceAccount, r.CloneImage, "clone", tcpClone,
+		fmt.Sprintf("clone-%s", tcpClone.GetUID()),
+		"clone",
+		fmt.Sprintf("--source=%s/%s", source.GetNamespace(), source.GetName()),
+		fmt.Sprintf("--tenant-control-plane=%s/%s", clone.GetNamespace(), clone.GetName()),
+	)
+	// The Job is retained as a record of the clone, and deleted along with the TenantControlPlaneClone.
+	if err := r.Client.Create(ctx, job); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to launch clone job: %w", err)
+	}
+
+	tcpClone.Status.Phase = kamajiv1alpha1.ClonePhaseCopying
+	tcpClone.Status.Message = fmt.Sprintf("the keyspace is being copied by the Job %s/%s", job.GetNamespace(), job.GetName())
+
+	return ctrl.Result{}, nil
+}
+
+// newCloneTenantControlPlane returns the clone of the source Tenant Control Plane, scaled to zero replicas:
+// the DataStore schema and username are left empty, and defaulted upon creation after the clone UID,
+// while the NodePorts are allocated by allocateNodePorts.
+func newCloneTenantControlPlane(tcpClone *kamajiv1alpha1.TenantControlPlaneClone, source *kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.TenantControlPlane {
+	clone := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        tcpClone.Spec.TenantControlPlane,
+			Namespace:   tcpClone.GetNamespace(),
+			Annotations: map[string]string{kamajiv1alpha1.CloneAnnotation: tcpClone.GetName()},
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	clone.Spec.DataStore = tcpClone.Spec.DataStore
+	if clone.Spec.DataStore == "" {
+		clone.Spec.DataStore = source.Status.Storage.DataStoreName
+	}
+
+	clone.Spec.DataStorePool = ""
+	clone.Spec.DataStoreSchema = ""
+	clone.Spec.DataStoreUsername = ""
+	clone.Spec.ControlPlane.Deployment.Replicas = ptr.To(int32(0))
+	// The API Server endpoint identifies the source, thus it cannot be shared.
+	clone.Spec.NetworkProfile.Address = ""
+	clone.Spec.NetworkProfile.AdvertiseAddress = ""
+
+	if clone.Spec.ControlPlane.Ingress != nil {
+		clone.Spec.ControlPlane.Ingress.Hostname = tcpClone.Spec.Hostname
+	}
+
+	if clone.Spec.ControlPlane.Gateway != nil {
+		clone.Spec.ControlPlane.Gateway.Hostname = gatewayv1.Hostname(tcpClone.Spec.Hostname)
+	}
+
+	return clone
+}
+
+// allocateNodePorts assigns new ports to the API Server and the Konnectivity server of a clone exposed with a NodePort Service:
+// the source ones are already allocated, thus the lowest ports of the NodePort range, unused by any Service,
+// or by any other Tenant Control Plane, are picked.
+// The ports are listed from the API Server, and the clone must be created before releasing the nodePortsLock.
+func (r *TenantControlPlaneCloneReconciler) allocateNodePorts(ctx context.Context, clone *kamajiv1alpha1.TenantControlPlane) error {
+	if clone.Spec.ControlPlane.Service.ServiceType != kamajiv1alpha1.ServiceTypeNodePort {
+		return nil
+	}
+
+	used := sets.New[int32]()
+
+	var services corev1.ServiceList
+	if err := r.APIReader.List(ctx, &services); err != nil {
+		return fmt.Errorf("unable to list Services: %w", err)
+	}
+
+	for _, service := range services.Items {
+		for _, port := range service.Spec.Ports {
+			used.Insert(port.NodePort)
+		}
+	}
+	// The Service of a Tenant Control Plane could be missing yet, although its ports are reserved.
+	var tenantControlPlanes kamajiv1alpha1.TenantControlPlaneList
+	if err := r.APIReader.List(ctx, &tenantControlPlanes); err != nil {
+		return fmt.Errorf("unable to list TenantControlPlanes: %w", err)
+	}
+
+	for _, tcp := range tenantControlPlanes.Items {
+		if tcp.Spec.ControlPlane.Service.ServiceType != kamajiv1alpha1.ServiceTypeNodePort {
+			continue
+		}
+
+		used.Insert(tcp.Spec.NetworkProfile.Port)
+
+		if tcp.Spec.Addons.Konnectivity != nil {
+			used.Insert(tcp.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port)
+		}
+	}
+
+	next := func() (int32, error) {
+		for port := int32(r.NodePortRange.Base); port < int32(r.NodePortRange.Base+r.NodePortRange.Size); port++ {
+			if !used.Has(port) {
+				used.Insert(port)
+
+				return port, nil
+			}
+		}
+
+		return 0, fmt.Errorf("no NodePort available for the clone TenantControlPlane %s", clone.GetName())
+	}
+
+	port, err := next()
+	if err != nil {
+		return err
+	}
+
+	clone.Spec.NetworkProfile.Port = port
+
+	if clone.Spec.Addons.Konnectivity != nil {
+		if port, err = next(); err != nil {
+			return err
+		}
+
+		clone.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port = port
+	}
+
+	return nil
+}
+
+func setCloneFailed(tcpClone *kamajiv1alpha1.TenantControlPlaneClone, now time.Time, message string) {
+	tcpClone.Status.Phase = kamajiv1alpha1.ClonePhaseFailed
+	tcpClone.Status.Message = message
+	tcpClone.Status.CompletionTime = &metav1.Time{Time: now}
+}
+
+func (r *TenantControlPlaneCloneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	jobHandler, jobPredicates := enqueueFromBackupJob(r.KamajiNamespace, "clone")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kamajiv1alpha1.TenantControlPlaneClone{}).
+		Watches(&batchv1.Job{}, jobHandler, jobPredicates).
+		Watches(&kamajiv1alpha1.TenantControlPlane{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetAnnotations()[kamajiv1alpha1.CloneAnnotation]}}}
+		}), builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			_, ok := object.GetAnnotations()[kamajiv1alpha1.CloneAnnotation]
+
+			return ok
+		}))).
+		Complete(r)
+}
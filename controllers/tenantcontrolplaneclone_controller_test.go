@@ -0,0 +1,316 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlaneClone(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+
+	source := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "tenants"},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			DataStore:       "etcd-a",
+			DataStoreSchema: "prod",
+			NetworkProfile:  kamajiv1alpha1.NetworkProfileSpec{Address: "10.0.0.10"},
+		},
+		Status: kamajiv1alpha1.TenantControlPlaneStatus{
+			Storage: kamajiv1alpha1.StorageStatus{DataStoreName: "etcd-a", Setup: kamajiv1alpha1.DataStoreSetupStatus{Schema: "prod", User: "prod"}},
+		},
+	}
+	source.Spec.ControlPlane.Deployment.Replicas = ptr.To(int32(3))
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-ca", Namespace: "tenants"},
+		Data:       map[string][]byte{"ca.crt": []byte("cert"), "ca.key": []byte("key")},
+	}
+	frontProxySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-front-proxy-ca-certificate", Namespace: "tenants"},
+		Data:       map[string][]byte{"ca.crt": []byte("cert"), "ca.key": []byte("key")},
+	}
+
+	tcpClone := &kamajiv1alpha1.TenantControlPlaneClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "rehearsal", Namespace: "tenants", UID: "uid"},
+		Spec: kamajiv1alpha1.TenantControlPlaneCloneSpec{
+			Source:             "prod",
+			TenantControlPlane: "prod-rehearsal",
+			DataStore:          "etcd-b",
+			PKI: kamajiv1alpha1.ClonePKISpec{
+				CertificateAuthorities: kamajiv1alpha1.ClonePKIReuse,
+				ServiceAccountKeys:     kamajiv1alpha1.ClonePKIRegenerate,
+			},
+		},
+	}
+
+	c := newFakeClientBuilder(t, source, caSecret, frontProxySecret).WithStatusSubresource(&kamajiv1alpha1.TenantControlPlane{}).Build()
+
+	r := &TenantControlPlaneCloneReconciler{Client: c, KamajiNamespace: "kamaji-system", KamajiServiceAccount: "kamaji", CloneImage: "clastix/kamaji:latest"}
+
+	if _, err := r.handle(t.Context(), tcpClone, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tcpClone.Status.Phase != kamajiv1alpha1.ClonePhaseProvisioning || ptr.Deref(tcpClone.Status.Replicas, 0) != 3 {
+		t.Fatalf("unexpected status %+v", tcpClone.Status)
+	}
+
+	var clone kamajiv1alpha1.TenantControlPlane
+	if err := c.Get(t.Context(), types.NamespacedName{Namespace: "tenants", Name: "prod-rehearsal"}, &clone); err != nil {
+		t.Fatalf("expected the clone to be created: %v", err)
+	}
+
+	if clone.Spec.DataStore != "etcd-b" || clone.Spec.DataStoreSchema != "" || clone.Spec.NetworkProfile.Address != "" || ptr.Deref(clone.Spec.ControlPlane.Deployment.Replicas, -1) != 0 {
+		t.Fatalf("unexpected clone spec %+v", clone.Spec)
+	}
+	// The clone is kept paused until its PKI Secrets have been written.
+	if _, paused := clone.GetAnnotations()[kamajiv1alpha1.PausedReconciliationAnnotation]; !paused {
+		t.Fatal("expected the clone to be paused")
+	}
+
+	if _, err := r.handle(t.Context(), tcpClone, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(&clone), &clone); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, paused := clone.GetAnnotations()[kamajiv1alpha1.PausedReconciliationAnnotation]; paused {
+		t.Fatal("expected the clone to be resumed once the PKI has been written")
+	}
+	// The certificate authorities must be reused, the Service Account keys regenerated.
+	var secret corev1.Secret
+	if err := c.Get(t.Context(), types.NamespacedName{Namespace: "tenants", Name: "prod-rehearsal-ca"}, &secret); err != nil || string(secret.Data["ca.key"]) != "key" {
+		t.Fatalf("expected the CA to be copied: %v", err)
+	}
+
+	if !metav1.IsControlledBy(&secret, &clone) {
+		t.Fatalf("expected the CA to be controlled by the clone, got %v", secret.GetOwnerReferences())
+	}
+
+	if err := c.Get(t.Context(), types.NamespacedName{Namespace: "tenants", Name: "prod-rehearsal-sa-certificate"}, &secret); err == nil {
+		t.Fatal("expected the Service Account keys not to be copied")
+	}
+	// No Job is started until the clone storage is provisioned.
+	if _, err := r.handle(t.Context(), tcpClone, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var jobs batchv1.JobList
+	if err := c.List(t.Context(), &jobs, client.InNamespace("kamaji-system")); err != nil || len(jobs.Items) != 0 {
+		t.Fatalf("expected no clone job, got %d (%v)", len(jobs.Items), err)
+	}
+
+	clone.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionSleeping
+	clone.Status.Storage = kamajiv1alpha1.StorageStatus{DataStoreName: "etcd-b", Setup: kamajiv1alpha1.DataStoreSetupStatus{Schema: "uid-clone", User: "uid-clone"}}
+
+	if err := c.Status().Update(t.Context(), &clone); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.handle(t.Context(), tcpClone, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.List(t.Context(), &jobs, client.InNamespace("kamaji-system")); err != nil || len(jobs.Items) != 1 {
+		t.Fatalf("expected a single clone job, got %d (%v)", len(jobs.Items), err)
+	}
+
+	job := jobs.Items[0]
+
+	if args := job.Spec.Template.Spec.Containers[0].Args; len(args) != 3 || args[1] != "--source=tenants/prod" || args[2] != "--tenant-control-plane=tenants/prod-rehearsal" {
+		t.Fatalf("unexpected job args %v", args)
+	}
+
+	if tcpClone.Status.Phase != kamajiv1alpha1.ClonePhaseCopying {
+		t.Fatalf("unexpected status %+v", tcpClone.Status)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(time.Minute))}}
+	if err := c.Status().Update(t.Context(), &job); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.handle(t.Context(), tcpClone, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(&clone), &clone); err != nil {
+		t.Fatal(err)
+	}
+
+	if tcpClone.Status.Phase != kamajiv1alpha1.ClonePhaseCompleted || ptr.Deref(clone.Spec.ControlPlane.Deployment.Replicas, 0) != 3 {
+		t.Fatalf("expected the clone to be scaled up once completed, got %+v", tcpClone.Status)
+	}
+}
+
+func TestTenantControlPlaneClonePKIOwnership(t *testing.T) {
+	t.Parallel()
+
+	source := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "tenants"},
+		Status: kamajiv1alpha1.TenantControlPlaneStatus{
+			Storage: kamajiv1alpha1.StorageStatus{DataStoreName: "etcd", Setup: kamajiv1alpha1.DataStoreSetupStatus{Schema: "prod", User: "prod"}},
+		},
+	}
+
+	saSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-sa-certificate", Namespace: "tenants"},
+		Data:       map[string][]byte{"sa.pub": []byte("pub"), "sa.key": []byte("key")},
+	}
+	// A Secret named after the clone, which doesn't belong to it.
+	foreign := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-rehearsal-sa-certificate", Namespace: "tenants"},
+		Data:       map[string][]byte{"sa.pub": []byte("foreign")},
+	}
+
+	tcpClone := &kamajiv1alpha1.TenantControlPlaneClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "rehearsal", Namespace: "tenants", UID: "uid"},
+		Spec: kamajiv1alpha1.TenantControlPlaneCloneSpec{
+			Source:             "prod",
+			TenantControlPlane: "prod-rehearsal",
+			PKI:                kamajiv1alpha1.ClonePKISpec{ServiceAccountKeys: kamajiv1alpha1.ClonePKIReuse},
+		},
+	}
+
+	c := newFakeClient(t, source, saSecret, foreign)
+
+	r := &TenantControlPlaneCloneReconciler{Client: c, KamajiNamespace: "kamaji-system", KamajiServiceAccount: "kamaji", CloneImage: "clastix/kamaji:latest"}
+
+	for range 2 {
+		if _, err := r.handle(t.Context(), tcpClone, time.Now()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if tcpClone.Status.Phase != kamajiv1alpha1.ClonePhaseFailed {
+		t.Fatalf("expected the clone to fail, got %+v", tcpClone.Status)
+	}
+
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(foreign), foreign); err != nil || string(foreign.Data["sa.pub"]) != "foreign" {
+		t.Fatalf("expected the foreign Secret not to be overwritten: %v", err)
+	}
+}
+
+func TestTenantControlPlaneCloneCollision(t *testing.T) {
+	t.Parallel()
+
+	setup := kamajiv1alpha1.DataStoreSetupStatus{Schema: "shared", User: "shared"}
+	sleeping := kamajiv1alpha1.KubernetesVersion{Status: &kamajiv1alpha1.VersionSleeping}
+
+	source := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "tenants"},
+		Status:     kamajiv1alpha1.TenantControlPlaneStatus{Storage: kamajiv1alpha1.StorageStatus{DataStoreName: "etcd", Setup: setup}},
+	}
+	clone := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-rehearsal", Namespace: "tenants", Annotations: map[string]string{kamajiv1alpha1.CloneAnnotation: "rehearsal"}},
+		Status: kamajiv1alpha1.TenantControlPlaneStatus{
+			Storage:    kamajiv1alpha1.StorageStatus{DataStoreName: "etcd", Setup: setup},
+			Kubernetes: kamajiv1alpha1.KubernetesStatus{Version: sleeping},
+		},
+	}
+
+	tcpClone := &kamajiv1alpha1.TenantControlPlaneClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "rehearsal", Namespace: "tenants", UID: "uid"},
+		Spec:       kamajiv1alpha1.TenantControlPlaneCloneSpec{Source: "prod", TenantControlPlane: "prod-rehearsal"},
+		Status:     kamajiv1alpha1.TenantControlPlaneCloneStatus{Phase: kamajiv1alpha1.ClonePhaseProvisioning},
+	}
+
+	c := newFakeClient(t, source, clone)
+
+	r := &TenantControlPlaneCloneReconciler{Client: c, KamajiNamespace: "kamaji-system", KamajiServiceAccount: "kamaji", CloneImage: "clastix/kamaji:latest"}
+
+	if _, err := r.handle(t.Context(), tcpClone, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The clone keyspace is dropped by the Job: it must never be started on the source one.
+	if tcpClone.Status.Phase != kamajiv1alpha1.ClonePhaseFailed {
+		t.Fatalf("expected the clone to fail, got %+v", tcpClone.Status)
+	}
+
+	var jobs batchv1.JobList
+	if err := c.List(t.Context(), &jobs); err != nil || len(jobs.Items) != 0 {
+		t.Fatalf("expected no clone job, got %d (%v)", len(jobs.Items), err)
+	}
+}
+
+func TestTenantControlPlaneCloneNodePorts(t *testing.T) {
+	t.Parallel()
+
+	source := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "tenants"},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			NetworkProfile: kamajiv1alpha1.NetworkProfileSpec{Port: 30000},
+			Addons: kamajiv1alpha1.AddonsSpec{
+				Konnectivity: &kamajiv1alpha1.KonnectivitySpec{KonnectivityServerSpec: kamajiv1alpha1.KonnectivityServerSpec{Port: 30001}},
+			},
+		},
+		Status: kamajiv1alpha1.TenantControlPlaneStatus{
+			Storage: kamajiv1alpha1.StorageStatus{DataStoreName: "etcd", Setup: kamajiv1alpha1.DataStoreSetupStatus{Schema: "prod", User: "prod"}},
+		},
+	}
+	source.Spec.ControlPlane.Service.ServiceType = kamajiv1alpha1.ServiceTypeNodePort
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "ingress-system"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, Ports: []corev1.ServicePort{{Port: 443, NodePort: 30002}}},
+	}
+
+	tcpClone := &kamajiv1alpha1.TenantControlPlaneClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "rehearsal", Namespace: "tenants", UID: "uid"},
+		Spec:       kamajiv1alpha1.TenantControlPlaneCloneSpec{Source: "prod", TenantControlPlane: "prod-rehearsal"},
+	}
+
+	c := newFakeClient(t, source, service)
+
+	r := &TenantControlPlaneCloneReconciler{
+		Client:               c,
+		APIReader:            c,
+		KamajiNamespace:      "kamaji-system",
+		KamajiServiceAccount: "kamaji",
+		CloneImage:           "clastix/kamaji:latest",
+		NodePortRange:        utilnet.PortRange{Base: 30000, Size: 6},
+	}
+
+	if _, err := r.handle(t.Context(), tcpClone, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var clone kamajiv1alpha1.TenantControlPlane
+	if err := c.Get(t.Context(), types.NamespacedName{Namespace: "tenants", Name: "prod-rehearsal"}, &clone); err != nil {
+		t.Fatalf("expected the clone to be created: %v", err)
+	}
+	// The NodePorts of the source, and of any other Service, are already allocated.
+	if port := clone.Spec.NetworkProfile.Port; port != 30003 {
+		t.Fatalf("unexpected API Server NodePort %d", port)
+	}
+
+	if port := clone.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port; port != 30004 {
+		t.Fatalf("unexpected Konnectivity server NodePort %d", port)
+	}
+	// The ports of the clone just created must not be picked again, exhausting the range.
+	another := &kamajiv1alpha1.TenantControlPlaneClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "another", Namespace: "tenants", UID: "another"},
+		Spec:       kamajiv1alpha1.TenantControlPlaneCloneSpec{Source: "prod", TenantControlPlane: "prod-another"},
+	}
+
+	if _, err := r.handle(t.Context(), another, time.Now()); err == nil {
+		t.Fatal("expected the NodePort range to be exhausted")
+	}
+}
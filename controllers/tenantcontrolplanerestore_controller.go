@@ -0,0 +1,197 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/backup"
+)
+
+type TenantControlPlaneRestoreReconciler struct {
+	Client               client.Client
+	KamajiNamespace      string
+	KamajiServiceAccount string
+	// RestoreImage is the container image running the restore Jobs.
+	RestoreImage string
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanerestores,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanerestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+
+func (r *TenantControlPlaneRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var restore kamajiv1alpha1.TenantControlPlaneRestore
+	if err := r.Client.Get(ctx, req.NamespacedName, &restore); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, cleaning up Jobs")
+
+			return ctrl.Result{}, deleteBackupJobs(ctx, r.Client, r.KamajiNamespace, "restore", req.NamespacedName)
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return ctrl.Result{}, err
+	}
+
+	if utils.IsPaused(&restore) {
+		logger.Info("paused reconciliation, no further actions")
+
+		return ctrl.Result{}, nil
+	}
+
+	if restore.Status.Phase == kamajiv1alpha1.RestorePhaseCompleted || restore.Status.Phase == kamajiv1alpha1.RestorePhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.handle(ctx, &restore)
+	if err != nil {
+		logger.Error(err, "cannot handle the request")
+
+		return ctrl.Result{}, err
+	}
+
+	if statusErr := r.Client.Status().Update(ctx, &restore); statusErr != nil {
+		logger.Error(statusErr, "cannot update resource status")
+
+		return ctrl.Result{}, statusErr
+	}
+
+	return result, nil
+}
+
+func (r *TenantControlPlaneRestoreReconciler) handle(ctx context.Context, restore *kamajiv1alpha1.TenantControlPlaneRestore) (ctrl.Result, error) {
+	job := &batchv1.Job{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.KamajiNamespace, Name: fmt.Sprintf("restore-%s", restore.GetUID())}, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		return r.start(ctx, restore)
+	}
+
+	condition := finishedJobCondition(job)
+
+	switch {
+	case condition == nil:
+		restore.Status.Phase = kamajiv1alpha1.RestorePhaseRunning
+		restore.Status.Message = fmt.Sprintf("the archive is being restored by the Job %s/%s", job.GetNamespace(), job.GetName())
+	case condition.Type == batchv1.JobComplete:
+		restore.Status.Phase = kamajiv1alpha1.RestorePhaseCompleted
+		restore.Status.Message = "the archive has been restored: the TenantControlPlane can be scaled up"
+		restore.Status.CompletionTime = condition.LastTransitionTime.DeepCopy()
+	default:
+		restore.Status.Phase = kamajiv1alpha1.RestorePhaseFailed
+		restore.Status.Message = fmt.Sprintf("the Job %s/%s failed: %s", job.GetNamespace(), job.GetName(), condition.Message)
+		restore.Status.CompletionTime = condition.LastTransitionTime.DeepCopy()
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// start launches the restore Job once the source archive is resolved, and the Tenant Control Plane is sleeping:
+// restoring the keyspace requires no API Server to be running, since its cache would be stale.
+func (r *TenantControlPlaneRestoreReconciler) start(ctx context.Context, restore *kamajiv1alpha1.TenantControlPlaneRestore) (ctrl.Result, error) {
+	pending := func(message string) (ctrl.Result, error) {
+		restore.Status.Phase = kamajiv1alpha1.RestorePhasePending
+		restore.Status.Message = message
+
+		return ctrl.Result{RequeueAfter: backupRequeueInterval}, nil
+	}
+
+	failed := func(message string) (ctrl.Result, error) {
+		restore.Status.Phase = kamajiv1alpha1.RestorePhaseFailed
+		restore.Status.Message = message
+
+		return ctrl.Result{}, nil
+	}
+
+	target, archive := restore.Spec.Source.Target, restore.Spec.Source.Archive
+	// Archives are confined to the directory of a Tenant Control Plane of the same namespace,
+	// the backed up one when restoring from a TenantControlPlaneBackup.
+	var sourceTenantControlPlane string
+
+	if name := restore.Spec.Source.Backup; name != "" {
+		var tcpBackup kamajiv1alpha1.TenantControlPlaneBackup
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: restore.GetNamespace(), Name: name}, &tcpBackup); err != nil {
+			if apierrors.IsNotFound(err) {
+				return pending(fmt.Sprintf("the TenantControlPlaneBackup %s does not exist", name))
+			}
+
+			return ctrl.Result{}, err
+		}
+
+		target, sourceTenantControlPlane = &tcpBackup.Spec.Target, tcpBackup.Spec.TenantControlPlane
+
+		if archive == "" {
+			if len(tcpBackup.Status.Archives) == 0 {
+				return pending(fmt.Sprintf("the TenantControlPlaneBackup %s has no archives yet", name))
+			}
+
+			archive = tcpBackup.Status.Archives[len(tcpBackup.Status.Archives)-1].Name
+		}
+	}
+
+	if err := backup.ValidateArchiveName(archive, restore.GetNamespace(), sourceTenantControlPlane); err != nil {
+		return failed(err.Error())
+	}
+
+	if err := backup.CheckTargetAccess(ctx, r.Client, r.KamajiNamespace, *target, restore.GetNamespace()); err != nil {
+		return pending(err.Error())
+	}
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: restore.GetNamespace(), Name: restore.Spec.TenantControlPlane}, &tcp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return pending(fmt.Sprintf("the TenantControlPlane %s does not exist", restore.Spec.TenantControlPlane))
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if ptr.Deref(tcp.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionUnknown) != kamajiv1alpha1.VersionSleeping || tcp.Status.Storage.Setup.Schema == "" {
+		return pending(fmt.Sprintf("the TenantControlPlane %s must be provisioned, and scaled to zero replicas", tcp.GetName()))
+	}
+
+	job := newBackupJob(r.KamajiNamespace, r.KamajiServiceAccount, r.RestoreImage, "restore", restore,
+		fmt.Sprintf("restore-%s", restore.GetUID()), archive, *target,
+		"restore",
+		fmt.Sprintf("--tenant-control-plane=%s/%s", tcp.GetNamespace(), tcp.GetName()),
+		fmt.Sprintf("--archive=%s", archive),
+	)
+	// The Job is retained as a record of the restore, and deleted along with the TenantControlPlaneRestore.
+	if err := r.Client.Create(ctx, job); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to launch restore job: %w", err)
+	}
+
+	restore.Status.Phase = kamajiv1alpha1.RestorePhaseRunning
+	restore.Status.Message = fmt.Sprintf("the archive is being restored by the Job %s/%s", job.GetNamespace(), job.GetName())
+	restore.Status.Archive = archive
+	restore.Status.StartTime = &metav1.Time{Time: job.GetCreationTimestamp().Time}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *TenantControlPlaneRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	jobHandler, jobPredicates := enqueueFromBackupJob(r.KamajiNamespace, "restore")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kamajiv1alpha1.TenantControlPlaneRestore{}).
+		Watches(&batchv1.Job{}, jobHandler, jobPredicates).
+		Complete(r)
+}
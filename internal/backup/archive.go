@@ -0,0 +1,259 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+const (
+	metadataEntry  = "metadata.json"
+	pkiDirectory   = "pki/"
+	keyspacePrefix = "keyspace/"
+	// keyspaceChunkSize is the number of key-values stored in a single archive entry:
+	// tar entries must declare their size upfront, chunking bounds the memory required to build the archive.
+	keyspaceChunkSize = 500
+)
+
+// PKISecrets are the Tenant Control Plane Secrets, without the tenant prefix, retained in the archive:
+// the certificate authorities and the service account key pair are enough to restore the cluster identity,
+// since the remaining certificates and kubeconfigs are issued back by Kamaji.
+var PKISecrets = []string{"ca", "front-proxy-ca-certificate", "sa-certificate"}
+
+// Metadata describes the content of a backup archive.
+type Metadata struct {
+	// TenantControlPlane is the namespaced name of the backed up Tenant Control Plane.
+	TenantControlPlane string    `json:"tenantControlPlane"`
+	KubernetesVersion  string    `json:"kubernetesVersion"`
+	Driver             string    `json:"driver"`
+	CreationTimestamp  time.Time `json:"creationTimestamp"`
+}
+
+// Archive is the content of a backup archive, except for the keyspace which is streamed.
+type Archive struct {
+	Metadata Metadata
+	// Secrets maps the PKI Secrets, without the tenant prefix, to their data.
+	Secrets map[string]map[string][]byte
+}
+
+type archiveKeyValue struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// WriteArchive writes a gzipped tar archive made of the metadata, the PKI Secrets,
+// and the driver-neutral keyspace returned by the export function.
+func WriteArchive(w io.Writer, archive Archive, export func(fn func(kv datastore.KeyValue) error) error) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	metadata, err := json.Marshal(archive.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if err = writeEntry(tw, metadataEntry, archive.Metadata.CreationTimestamp, metadata); err != nil {
+		return err
+	}
+
+	for name, data := range archive.Secrets {
+		secret, mErr := json.Marshal(data)
+		if mErr != nil {
+			return mErr
+		}
+
+		if err = writeEntry(tw, pkiDirectory+name+".json", archive.Metadata.CreationTimestamp, secret); err != nil {
+			return err
+		}
+	}
+
+	var (
+		chunk   bytes.Buffer
+		items   int
+		chunkID int
+	)
+
+	flush := func() error {
+		if items == 0 {
+			return nil
+		}
+
+		if fErr := writeEntry(tw, fmt.Sprintf("%s%06d.jsonl", keyspacePrefix, chunkID), archive.Metadata.CreationTimestamp, chunk.Bytes()); fErr != nil {
+			return fErr
+		}
+
+		chunk.Reset()
+		items = 0
+		chunkID++
+
+		return nil
+	}
+
+	encoder := json.NewEncoder(&chunk)
+
+	if err = export(func(kv datastore.KeyValue) error {
+		if eErr := encoder.Encode(archiveKeyValue{Key: kv.Key, Value: kv.Value}); eErr != nil {
+			return eErr
+		}
+
+		if items++; items < keyspaceChunkSize {
+			return nil
+		}
+
+		return flush()
+	}); err != nil {
+		return fmt.Errorf("unable to export keyspace: %w", err)
+	}
+
+	if err = flush(); err != nil {
+		return err
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+func writeEntry(tw *tar.Writer, name string, modTime time.Time, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o600,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+	}); err != nil {
+		return err
+	}
+
+	_, err := tw.Write(data)
+
+	return err
+}
+
+// ArchiveReader reads a backup archive: the metadata and the PKI Secrets are loaded upon its creation,
+// allowing to validate them before streaming the keyspace.
+type ArchiveReader struct {
+	Archive
+
+	gz      *gzip.Reader
+	tr      *tar.Reader
+	pending *tar.Header
+}
+
+func NewArchiveReader(r io.Reader) (*ArchiveReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress archive: %w", err)
+	}
+
+	ar := &ArchiveReader{
+		Archive: Archive{Secrets: map[string]map[string][]byte{}},
+		gz:      gz,
+		tr:      tar.NewReader(gz),
+	}
+
+	var hasMetadata bool
+
+	for {
+		header, hErr := ar.tr.Next()
+		if errors.Is(hErr, io.EOF) {
+			break
+		}
+
+		if hErr != nil {
+			return nil, fmt.Errorf("unable to read archive: %w", hErr)
+		}
+
+		if strings.HasPrefix(header.Name, keyspacePrefix) {
+			ar.pending = header
+
+			break
+		}
+
+		switch {
+		case header.Name == metadataEntry:
+			if err = json.NewDecoder(ar.tr).Decode(&ar.Metadata); err != nil {
+				return nil, fmt.Errorf("unable to decode archive metadata: %w", err)
+			}
+
+			hasMetadata = true
+		case strings.HasPrefix(header.Name, pkiDirectory):
+			var data map[string][]byte
+			if err = json.NewDecoder(ar.tr).Decode(&data); err != nil {
+				return nil, fmt.Errorf("unable to decode %s: %w", header.Name, err)
+			}
+
+			ar.Secrets[strings.TrimSuffix(path.Base(header.Name), ".json")] = data
+		}
+	}
+
+	if !hasMetadata {
+		return nil, fmt.Errorf("the archive is missing the %s entry", metadataEntry)
+	}
+
+	return ar, nil
+}
+
+// KeyValues streams the archived keyspace, invoking the given function with batches of key-values.
+func (ar *ArchiveReader) KeyValues(fn func(kvs []datastore.KeyValue) error) error {
+	for header := ar.pending; header != nil; {
+		if strings.HasPrefix(header.Name, keyspacePrefix) {
+			batch := make([]datastore.KeyValue, 0, keyspaceChunkSize)
+
+			scanner := bufio.NewScanner(ar.tr)
+			// Kubernetes objects are limited by the etcd request size, 1.5MiB by default.
+			scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+			for scanner.Scan() {
+				var kv archiveKeyValue
+				if err := json.Unmarshal(scanner.Bytes(), &kv); err != nil {
+					return fmt.Errorf("unable to decode %s: %w", header.Name, err)
+				}
+
+				batch = append(batch, datastore.KeyValue{Key: kv.Key, Value: kv.Value})
+			}
+
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("unable to read %s: %w", header.Name, err)
+			}
+
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+
+		var err error
+
+		header, err = ar.tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to read archive: %w", err)
+		}
+	}
+
+	ar.pending = nil
+
+	return nil
+}
+
+func (ar *ArchiveReader) Close() error {
+	return ar.gz.Close()
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	archive := Archive{
+		Metadata: Metadata{
+			TenantControlPlane: "default/tcp",
+			KubernetesVersion:  "v1.33.0",
+			Driver:             "etcd",
+			CreationTimestamp:  time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC),
+		},
+		Secrets: map[string]map[string][]byte{
+			"ca":             {"ca.crt": []byte("certificate"), "ca.key": []byte("key")},
+			"sa-certificate": {"sa.pub": []byte("public"), "sa.key": []byte("private")},
+		},
+	}
+	// Spanning multiple keyspace chunks.
+	kvs := make([]datastore.KeyValue, 0, keyspaceChunkSize+10)
+	for i := range keyspaceChunkSize + 10 {
+		kvs = append(kvs, datastore.KeyValue{Key: fmt.Sprintf("/configmaps/default/cm-%d", i), Value: []byte{0x6b, 0x38, 0x73, 0x00, byte(i)}})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteArchive(&buf, archive, func(fn func(kv datastore.KeyValue) error) error {
+		for _, kv := range kvs {
+			if err := fn(kv); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error writing archive: %v", err)
+	}
+
+	ar, err := NewArchiveReader(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading archive: %v", err)
+	}
+	defer ar.Close()
+
+	if !ar.Metadata.CreationTimestamp.Equal(archive.Metadata.CreationTimestamp) || ar.Metadata.TenantControlPlane != archive.Metadata.TenantControlPlane {
+		t.Fatalf("unexpected metadata %+v", ar.Metadata)
+	}
+
+	if !reflect.DeepEqual(ar.Secrets, archive.Secrets) {
+		t.Fatalf("unexpected secrets %v", ar.Secrets)
+	}
+
+	var restored []datastore.KeyValue
+	if err = ar.KeyValues(func(batch []datastore.KeyValue) error {
+		restored = append(restored, batch...)
+
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error reading keyspace: %v", err)
+	}
+
+	if !reflect.DeepEqual(restored, kvs) {
+		t.Fatalf("restored %d key-values, expected %d", len(restored), len(kvs))
+	}
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storage := FilesystemStorage{Root: t.TempDir()}
+	start := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+
+	var names []string
+
+	for i := range 4 {
+		name := ArchiveName("default", "tcp", "nightly", start.Add(time.Duration(i)*time.Hour))
+		names = append(names, name)
+
+		if err := storage.Put(ctx, name, bytes.NewReader([]byte("archive"))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Archives of a different backup sharing the same prefix must be retained.
+	other := ArchiveName("default", "tcp", "nightly-eu", start)
+	if err := storage.Put(ctx, other, bytes.NewReader([]byte("archive"))); err != nil {
+		t.Fatal(err)
+	}
+
+	pruned, err := Prune(ctx, storage, ArchivePrefix("default", "tcp", "nightly"), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(pruned, names[:2]) {
+		t.Fatalf("pruned %v, expected %v", pruned, names[:2])
+	}
+
+	remaining, err := storage.List(ctx, "default/tcp/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{names[2], names[3], other}; !reflect.DeepEqual(remaining, expected) {
+		t.Fatalf("remaining %v, expected %v", remaining, expected)
+	}
+}
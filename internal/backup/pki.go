@@ -0,0 +1,46 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// WritePKISecrets replaces the certificate authorities and the service account key pair of the Tenant Control Plane,
+// where secrets maps the Secret names, without the tenant prefix, to their data: Kamaji takes them over,
+// and issues back the remaining certificates and kubeconfigs, since they are no longer signed by the written ones.
+// The Secrets are controlled by the Tenant Control Plane, which must exist: the existing ones not controlled by it are not overwritten.
+func WritePKISecrets(ctx context.Context, c client.Client, tcp *kamajiv1alpha1.TenantControlPlane, secrets map[string]map[string][]byte) error {
+	for name, data := range secrets {
+		secret := &corev1.Secret{}
+		secret.SetNamespace(tcp.GetNamespace())
+		secret.SetName(utilities.AddTenantPrefix(name, tcp))
+
+		if _, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+			if secret.GetResourceVersion() != "" && !metav1.IsControlledBy(secret, tcp) {
+				return fmt.Errorf("the Secret %s/%s is not controlled by the TenantControlPlane", secret.GetNamespace(), secret.GetName())
+			}
+
+			secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(tcp.GetName(), name)))
+			secret.Data = data
+
+			utilities.SetObjectChecksum(secret, secret.Data)
+
+			return controllerutil.SetControllerReference(tcp, secret, c.Scheme())
+		}); err != nil {
+			return fmt.Errorf("unable to write the %s PKI secret: %w", name, err)
+		}
+	}
+
+	return nil
+}
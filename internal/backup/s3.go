@@ -0,0 +1,120 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Storage stores the archives in an S3-compatible object storage, addressing the bucket with the path-style.
+type S3Storage struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	Prefix          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func (s S3Storage) Put(ctx context.Context, name string, body io.ReadSeeker) error {
+	size, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	if _, err = body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PutObject(ctx, s.Bucket, s.key(name), body, size, minio.PutObjectOptions{ContentType: "application/gzip"})
+
+	return err
+}
+
+func (s S3Storage) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := client.GetObject(ctx, s.Bucket, s.key(name), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// The object is retrieved lazily: checking it exists, the error is not deferred to the first read.
+	if _, err = object.Stat(); err != nil {
+		_ = object.Close()
+
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func (s S3Storage) List(ctx context.Context, prefix string) ([]string, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for object := range client.ListObjects(ctx, s.Bucket, minio.ListObjectsOptions{Prefix: s.key(prefix), Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("unable to list objects: %w", object.Err)
+		}
+
+		names = append(names, strings.TrimPrefix(object.Key, s.key("")))
+	}
+
+	return names, nil
+}
+
+func (s S3Storage) Delete(ctx context.Context, name string) error {
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+
+	return client.RemoveObject(ctx, s.Bucket, s.key(name), minio.RemoveObjectOptions{})
+}
+
+func (s S3Storage) key(name string) string {
+	if s.Prefix == "" {
+		return name
+	}
+
+	return strings.TrimSuffix(s.Prefix, "/") + "/" + name
+}
+
+// client returns the S3 client of the endpoint, whose scheme determines if TLS is used.
+func (s S3Storage) client() (*minio.Client, error) {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" || strings.Trim(endpoint.Path, "/") != "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %s, expected http(s)://host[:port]", s.Endpoint)
+	}
+
+	return minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(s.AccessKeyID, s.SecretAccessKey, ""),
+		Secure:       endpoint.Scheme == "https",
+		Region:       s.Region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+}
@@ -0,0 +1,197 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 is a path-style S3 API serving the objects of a single bucket from memory.
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket)
+	if !ok || r.Header.Get("Authorization") == "" {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	key = strings.TrimPrefix(key, "/")
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		type content struct {
+			Key  string
+			Size int
+		}
+
+		result := struct {
+			XMLName  xml.Name `xml:"ListBucketResult"`
+			Name     string
+			Prefix   string
+			KeyCount int
+			Contents []content
+		}{Name: f.bucket, Prefix: r.URL.Query().Get("prefix")}
+
+		for name, data := range f.objects {
+			if strings.HasPrefix(name, result.Prefix) {
+				result.Contents = append(result.Contents, content{Key: name, Size: len(data)})
+			}
+		}
+
+		slices.SortFunc(result.Contents, func(a, b content) int { return strings.Compare(a.Key, b.Key) })
+		result.KeyCount = len(result.Contents)
+
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPut:
+		data, err := readS3Payload(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		f.objects[key] = data
+
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, found := f.objects[key]
+		if !found {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", "Sat, 17 Oct 2026 00:00:00 GMT")
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// readS3Payload returns the object data, decoding the chunks of a streaming upload.
+func readS3Payload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var data bytes.Buffer
+
+	reader := bufio.NewReader(r.Body)
+
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.ParseInt(strings.TrimSpace(strings.SplitN(header, ";", 2)[0]), 16, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if size == 0 {
+			return data.Bytes(), nil
+		}
+
+		if _, err = io.CopyN(&data, reader, size); err != nil {
+			return nil, err
+		}
+
+		if _, err = reader.Discard(2); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := httptest.NewServer(&fakeS3{bucket: "backups", objects: map[string][]byte{"other/default/tcp/nightly-20261017000000.tar.gz": []byte("other")}})
+	defer server.Close()
+
+	storage := S3Storage{Endpoint: server.URL, Region: "us-east-1", Bucket: "backups", Prefix: "kamaji/", AccessKeyID: "access", SecretAccessKey: "secret"}
+
+	for _, name := range []string{"default/tcp/nightly-20261016000000.tar.gz", "default/tcp/nightly-20261017000000.tar.gz"} {
+		if err := storage.Put(ctx, name, bytes.NewReader([]byte(name))); err != nil {
+			t.Fatalf("unable to store %s: %v", name, err)
+		}
+	}
+
+	names, err := storage.List(ctx, "default/tcp/nightly-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"default/tcp/nightly-20261016000000.tar.gz", "default/tcp/nightly-20261017000000.tar.gz"}; !slices.Equal(names, expected) {
+		t.Fatalf("unexpected archives %v, expected %v", names, expected)
+	}
+
+	if err = storage.Delete(ctx, names[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := storage.Get(ctx, names[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	if data, _ := io.ReadAll(reader); string(data) != names[1] {
+		t.Fatalf("unexpected archive content %s", data)
+	}
+
+	if _, err = storage.Get(ctx, names[0]); err == nil {
+		t.Fatal("expected the deleted archive to be missing")
+	}
+}
+
+func TestS3StorageEndpoint(t *testing.T) {
+	t.Parallel()
+
+	for endpoint, valid := range map[string]bool{
+		"https://s3.eu-west-1.amazonaws.com": true,
+		"http://minio.storage.svc:9000":      true,
+		"http://minio.storage.svc:9000/":     true,
+		"minio.storage.svc:9000":             false,
+		"ftp://minio.storage.svc":            false,
+		"https://minio.storage.svc/bucket":   false,
+	} {
+		if _, err := (S3Storage{Endpoint: endpoint}).client(); (err == nil) != valid {
+			t.Errorf("unexpected validation result for %s: %v", endpoint, err)
+		}
+	}
+}
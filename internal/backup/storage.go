@@ -0,0 +1,220 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ArchiveExtension = ".tar.gz"
+	timestampLayout  = "20060102150405"
+)
+
+// Storage abstracts the target where archives are stored: names are slash-separated paths relative to the target.
+type Storage interface {
+	Put(ctx context.Context, name string, body io.ReadSeeker) error
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the names of the stored objects starting with the given prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// ArchiveDirectory is the directory holding the archives of the given Tenant Control Plane.
+func ArchiveDirectory(namespace, tenantControlPlane string) string {
+	return path.Join(namespace, tenantControlPlane) + "/"
+}
+
+// ValidateArchiveName checks the archive is stored in the directory of a Tenant Control Plane of the given namespace,
+// the given one if not empty: archives of other namespaces must never be restored, since they hold the private keys of other tenants.
+func ValidateArchiveName(name, namespace, tenantControlPlane string) error {
+	if path.IsAbs(name) || filepath.IsAbs(name) || path.Clean(name) != name {
+		return fmt.Errorf("the archive name %s must be a relative, clean path", name)
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 || parts[0] != namespace || parts[1] == "" || parts[1] == ".." || parts[2] == ".." || !strings.HasSuffix(parts[2], ArchiveExtension) {
+		return fmt.Errorf("the archive %s is not stored in the directory of a TenantControlPlane of the namespace %s", name, namespace)
+	}
+
+	if tenantControlPlane != "" && parts[1] != tenantControlPlane {
+		return fmt.Errorf("the archive %s is not stored in the directory %s", name, ArchiveDirectory(namespace, tenantControlPlane))
+	}
+
+	return nil
+}
+
+// ArchivePrefix is the common prefix of the archives taken by the given TenantControlPlaneBackup.
+func ArchivePrefix(namespace, tenantControlPlane, backup string) string {
+	return path.Join(namespace, tenantControlPlane, backup) + "-"
+}
+
+// ArchiveName returns the name of an archive taken at the given time: names are sortable by time.
+func ArchiveName(namespace, tenantControlPlane, backup string, timestamp time.Time) string {
+	return ArchivePrefix(namespace, tenantControlPlane, backup) + timestamp.UTC().Format(timestampLayout) + ArchiveExtension
+}
+
+// ArchivePrefixFromName returns the prefix of the given archive name, shared with the archives of the same backup.
+func ArchivePrefixFromName(name string) (string, error) {
+	trimmed := strings.TrimSuffix(name, ArchiveExtension)
+	if len(trimmed) == len(name) || len(trimmed) < len(timestampLayout) {
+		return "", fmt.Errorf("non well-formed archive name %s", name)
+	}
+
+	prefix, timestamp := trimmed[:len(trimmed)-len(timestampLayout)], trimmed[len(trimmed)-len(timestampLayout):]
+	if _, err := time.Parse(timestampLayout, timestamp); err != nil {
+		return "", fmt.Errorf("non well-formed archive name %s: %w", name, err)
+	}
+
+	return prefix, nil
+}
+
+// Prune deletes the oldest archives with the given prefix, keeping the most recent ones:
+// the deleted archive names are returned.
+func Prune(ctx context.Context, storage Storage, prefix string, keep int) ([]string, error) {
+	names, err := storage.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list archives: %w", err)
+	}
+
+	archives := make([]string, 0, len(names))
+
+	for _, name := range names {
+		// Ignoring objects sharing the prefix, such as the archives of a backup named with the same prefix.
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ArchiveExtension)
+		if _, pErr := time.Parse(timestampLayout, timestamp); pErr != nil || !strings.HasSuffix(name, ArchiveExtension) {
+			continue
+		}
+
+		archives = append(archives, name)
+	}
+
+	if len(archives) <= keep {
+		return nil, nil
+	}
+
+	sort.Strings(archives)
+
+	pruned := archives[:len(archives)-keep]
+	for _, name := range pruned {
+		if dErr := storage.Delete(ctx, name); dErr != nil {
+			return nil, fmt.Errorf("unable to delete archive %s: %w", name, dErr)
+		}
+	}
+
+	return pruned, nil
+}
+
+// FilesystemStorage stores the archives in a local directory, such as a mounted PersistentVolumeClaim.
+type FilesystemStorage struct {
+	Root string
+}
+
+// resolve returns the local path of the given name, which must not escape the root directory.
+func (f FilesystemStorage) resolve(name string) (string, error) {
+	if path.IsAbs(name) || filepath.IsAbs(name) {
+		return "", fmt.Errorf("the name %s must be a relative path", name)
+	}
+
+	target := filepath.Join(f.Root, filepath.FromSlash(name))
+
+	relative, err := filepath.Rel(f.Root, target)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("the name %s escapes the root directory", name)
+	}
+
+	return target, nil
+}
+
+func (f FilesystemStorage) Put(_ context.Context, name string, body io.ReadSeeker) error {
+	target, err := f.resolve(name)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+	// Writing to a temporary file first, the archive is never observed partially written.
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".tmp-"+filepath.Base(target))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, body); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), target)
+}
+
+func (f FilesystemStorage) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	target, err := f.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(target)
+}
+
+func (f FilesystemStorage) List(_ context.Context, prefix string) ([]string, error) {
+	dir := path.Dir(prefix)
+	if strings.HasSuffix(prefix, "/") {
+		dir = strings.TrimSuffix(prefix, "/")
+	}
+
+	target, err := f.resolve(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		if name := path.Join(dir, entry.Name()); strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+func (f FilesystemStorage) Delete(_ context.Context, name string) error {
+	target, err := f.resolve(name)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(target)
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateArchiveName(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		archive            string
+		tenantControlPlane string
+		valid              bool
+	}{
+		"same tenant control plane":            {archive: "tenants/tcp/nightly-20261017000000.tar.gz", tenantControlPlane: "tcp", valid: true},
+		"any tenant control plane":             {archive: "tenants/other/nightly-20261017000000.tar.gz", valid: true},
+		"different tenant control plane":       {archive: "tenants/other/nightly-20261017000000.tar.gz", tenantControlPlane: "tcp"},
+		"different namespace":                  {archive: "victims/tcp/nightly-20261017000000.tar.gz"},
+		"parent directory":                     {archive: "tenants/tcp/../../victims/tcp/nightly-20261017000000.tar.gz"},
+		"parent directory as tenant":           {archive: "tenants/../nightly-20261017000000.tar.gz"},
+		"absolute path":                        {archive: "/tenants/tcp/nightly-20261017000000.tar.gz"},
+		"nested directories":                   {archive: "tenants/tcp/nested/nightly-20261017000000.tar.gz"},
+		"not an archive":                       {archive: "tenants/tcp/ca.key"},
+		"namespace directory":                  {archive: "tenants/nightly-20261017000000.tar.gz"},
+		"unclean path":                         {archive: "tenants//tcp/nightly-20261017000000.tar.gz"},
+		"current directory as tenant":          {archive: "tenants/./nightly-20261017000000.tar.gz"},
+		"parent directory as archive filename": {archive: "tenants/tcp/..", tenantControlPlane: "tcp"},
+	} {
+		if err := ValidateArchiveName(tc.archive, "tenants", tc.tenantControlPlane); (err == nil) != tc.valid {
+			t.Errorf("%s: unexpected validation result for %s: %v", name, tc.archive, err)
+		}
+	}
+}
+
+func TestFilesystemStorageConfinement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	parent := t.TempDir()
+	storage := FilesystemStorage{Root: filepath.Join(parent, "root")}
+
+	if err := os.WriteFile(filepath.Join(parent, "secret"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"../secret", "tenants/../../secret", "/etc/passwd"} {
+		if _, err := storage.Get(ctx, name); err == nil {
+			t.Errorf("expected %s to be rejected upon retrieval", name)
+		}
+
+		if err := storage.Put(ctx, name, bytes.NewReader([]byte("archive"))); err == nil {
+			t.Errorf("expected %s to be rejected upon storing", name)
+		}
+
+		if err := storage.Delete(ctx, name); err == nil {
+			t.Errorf("expected %s to be rejected upon deletion", name)
+		}
+	}
+
+	if _, err := storage.List(ctx, "../"); err == nil {
+		t.Error("expected listing the parent directory to be rejected")
+	}
+
+	if err := storage.Put(ctx, "tenants/tcp/../tcp/nightly.tar.gz", bytes.NewReader([]byte("archive"))); err != nil {
+		t.Fatalf("expected a name within the root to be stored: %v", err)
+	}
+}
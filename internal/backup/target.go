@@ -0,0 +1,143 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	// VolumeMountPath is where the PersistentVolumeClaim target is mounted in the backup and restore Jobs.
+	VolumeMountPath = "/var/lib/kamaji/backup"
+	volumeName      = "backup"
+
+	// AllowedNamespacesAnnotation lists, comma-separated, the namespaces allowed to use the annotated PersistentVolumeClaim
+	// of the Kamaji namespace as a backup target: claims lacking it can't be referenced by any namespace.
+	AllowedNamespacesAnnotation = "backup.kamaji.clastix.io/allowed-namespaces"
+
+	S3AccessKeyIDKey     = "AWS_ACCESS_KEY_ID"
+	S3SecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+)
+
+// StorageOptions are the command line flags defining the target of the backup and restore commands.
+type StorageOptions struct {
+	Path                string
+	S3Endpoint          string
+	S3Region            string
+	S3Bucket            string
+	S3Prefix            string
+	S3CredentialsSecret string
+}
+
+func (o *StorageOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Path, "path", "", "Local directory where the archives are stored, such as a mounted PersistentVolumeClaim.")
+	flags.StringVar(&o.S3Endpoint, "s3-endpoint", "", "URL of the S3-compatible API where the archives are stored.")
+	flags.StringVar(&o.S3Region, "s3-region", "us-east-1", "Region used to sign the S3 requests.")
+	flags.StringVar(&o.S3Bucket, "s3-bucket", "", "Bucket where the archives are stored.")
+	flags.StringVar(&o.S3Prefix, "s3-prefix", "", "Prefix prepended to the object keys of the archives.")
+	flags.StringVar(&o.S3CredentialsSecret, "s3-credentials-secret", "", fmt.Sprintf("Namespaced-name of the Secret containing the %s and %s keys (e.g.: default/s3-credentials)", S3AccessKeyIDKey, S3SecretAccessKeyKey))
+}
+
+// Storage returns the Storage according to the flags: the S3 access keys are retrieved from the referenced Secret.
+func (o *StorageOptions) Storage(ctx context.Context, c client.Client) (Storage, error) {
+	switch {
+	case o.Path != "" && o.S3Endpoint != "":
+		return nil, fmt.Errorf("--path and --s3-endpoint are mutually exclusive")
+	case o.Path != "":
+		return FilesystemStorage{Root: o.Path}, nil
+	case o.S3Endpoint == "":
+		return nil, fmt.Errorf("expecting a value for either --path or --s3-endpoint")
+	}
+
+	parts := strings.Split(o.S3CredentialsSecret, string(types.Separator))
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("non well-formed namespaced name for the S3 credentials secret, expected <NAMESPACE>/NAME, got %s", o.S3CredentialsSecret)
+	}
+
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Namespace: parts[0], Name: parts[1]}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to retrieve the S3 credentials: %w", err)
+	}
+
+	return S3Storage{
+		Endpoint:        o.S3Endpoint,
+		Region:          o.S3Region,
+		Bucket:          o.S3Bucket,
+		Prefix:          o.S3Prefix,
+		AccessKeyID:     string(secret.Data[S3AccessKeyIDKey]),
+		SecretAccessKey: string(secret.Data[S3SecretAccessKeyKey]),
+	}, nil
+}
+
+// CheckTargetAccess returns an error if the given namespace is not allowed to use the target:
+// the PersistentVolumeClaim, living in the Kamaji namespace, must list the namespace in its allowed ones.
+func CheckTargetAccess(ctx context.Context, c client.Client, kamajiNamespace string, target kamajiv1alpha1.BackupTarget, namespace string) error {
+	pvc := target.PersistentVolumeClaim
+	if pvc == nil {
+		return nil
+	}
+
+	var claim corev1.PersistentVolumeClaim
+	if err := c.Get(ctx, types.NamespacedName{Namespace: kamajiNamespace, Name: pvc.ClaimName}, &claim); err != nil {
+		return fmt.Errorf("unable to retrieve the PersistentVolumeClaim %s/%s: %w", kamajiNamespace, pvc.ClaimName, err)
+	}
+
+	for _, allowed := range strings.Split(claim.GetAnnotations()[AllowedNamespacesAnnotation], ",") {
+		if strings.TrimSpace(allowed) == namespace {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the PersistentVolumeClaim %s/%s is not allowed for the namespace %s, missing from its %s annotation", kamajiNamespace, pvc.ClaimName, namespace, AllowedNamespacesAnnotation)
+}
+
+// JobStorageArgs returns the command line flags matching the given target, and the volume to mount,
+// if any: the namespace is the one of the object referencing the target, and owning the S3 credentials.
+// Only the namespace directory of the PersistentVolumeClaim is mounted, preventing the access to the archives of other namespaces.
+func JobStorageArgs(target kamajiv1alpha1.BackupTarget, namespace string) ([]string, []corev1.Volume, []corev1.VolumeMount) {
+	if pvc := target.PersistentVolumeClaim; pvc != nil {
+		return []string{fmt.Sprintf("--path=%s", VolumeMountPath)},
+			[]corev1.Volume{{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.ClaimName},
+				},
+			}},
+			[]corev1.VolumeMount{{
+				Name:      volumeName,
+				MountPath: path.Join(VolumeMountPath, namespace),
+				SubPath:   path.Join(pvc.SubPath, namespace),
+			}}
+	}
+
+	if s3 := target.S3; s3 != nil {
+		args := []string{
+			fmt.Sprintf("--s3-endpoint=%s", s3.Endpoint),
+			fmt.Sprintf("--s3-bucket=%s", s3.Bucket),
+			fmt.Sprintf("--s3-credentials-secret=%s/%s", namespace, s3.CredentialsSecret.Name),
+		}
+
+		if s3.Region != "" {
+			args = append(args, fmt.Sprintf("--s3-region=%s", s3.Region))
+		}
+
+		if s3.Prefix != "" {
+			args = append(args, fmt.Sprintf("--s3-prefix=%s", s3.Prefix))
+		}
+
+		return args, nil, nil
+	}
+
+	return nil, nil, nil
+}
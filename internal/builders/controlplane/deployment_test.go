@@ -5,10 +5,14 @@ package controlplane
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pointer "k8s.io/utils/ptr"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
@@ -389,4 +393,238 @@ var _ = Describe("Controlplane Deployment", func() {
 			Expect(podSpec.Containers[index].Image).To(Equal("custom-kine:latest"))
 		})
 	})
+
+	Describe("Kine PostgreSQL layout", func() {
+		var tcp kamajiv1alpha1.TenantControlPlane
+		BeforeEach(func() {
+			d.DataStore = kamajiv1alpha1.DataStore{
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver: kamajiv1alpha1.KinePostgreSQLDriver,
+				},
+			}
+			tcp = kamajiv1alpha1.TenantControlPlane{}
+			tcp.Status.Storage.Config.SecretName = "test-secret"
+		})
+
+		It("should point to the tenant database", func() {
+			podSpec := &corev1.PodSpec{}
+
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/$(DB_SCHEMA)"))
+		})
+
+		It("should point to the tenant schema of the shared database", func() {
+			podSpec := &corev1.PodSpec{}
+			d.DataStore.Spec.PostgreSQL = &kamajiv1alpha1.PostgreSQLDataStore{Layout: kamajiv1alpha1.PostgreSQLLayoutSchema, Database: "kamaji"}
+
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/kamaji?search_path=$(DB_SCHEMA)"))
+		})
+	})
+
+	Describe("Kine DataStore defaults", func() {
+		var tcp kamajiv1alpha1.TenantControlPlane
+		BeforeEach(func() {
+			d.KineContainerImage = "rancher/kine:v0.11.0"
+			d.DataStore = kamajiv1alpha1.DataStore{
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver: kamajiv1alpha1.KineMySQLDriver,
+					Kine: &kamajiv1alpha1.DataStoreKine{
+						Image:           "rancher/kine:v0.13.0",
+						CompactInterval: &metav1.Duration{Duration: 10 * time.Minute},
+						ExtraArgs:       []string{"--compact-batch-size=500", "--metrics-bind-address=:8080"},
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+						},
+					},
+				},
+			}
+			tcp = kamajiv1alpha1.TenantControlPlane{}
+			tcp.Status.Storage.Config.SecretName = "test-secret"
+		})
+
+		It("should apply the DataStore defaults", func() {
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "kine", Image: "rancher/kine:v0.11.0"}}}
+
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Image).To(Equal("rancher/kine:v0.13.0"))
+			Expect(podSpec.Containers[index].Args).To(ContainElements("--compact-interval=10m0s", "--compact-batch-size=500", "--metrics-bind-address=:8080"))
+			Expect(podSpec.Containers[index].Resources).To(Equal(*d.DataStore.Spec.Kine.Resources))
+		})
+
+		It("should fall back to the default image once the DataStore one is unset", func() {
+			deployment := &appsv1.Deployment{}
+			deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "kine", Image: "rancher/kine:v0.11.0"}}
+
+			d.resetKineImages(deployment)
+			d.buildKine(&deployment.Spec.Template.Spec, tcp)
+			Expect(deployment.GetAnnotations()).To(HaveKeyWithValue(kineImageAnnotation, "rancher/kine:v0.13.0"))
+
+			d.DataStore.Spec.Kine.Image = ""
+
+			d.resetKineImages(deployment)
+			d.buildKine(&deployment.Spec.Template.Spec, tcp)
+
+			_, index := utilities.HasNamedContainer(deployment.Spec.Template.Spec.Containers, "kine")
+			Expect(deployment.Spec.Template.Spec.Containers[index].Image).To(Equal("rancher/kine:v0.11.0"))
+			Expect(deployment.GetAnnotations()).ToNot(HaveKey(kineImageAnnotation))
+		})
+
+		It("should preserve the image not set from the DataStore once it's unset", func() {
+			deployment := &appsv1.Deployment{}
+			deployment.SetAnnotations(map[string]string{kineImageAnnotation: "rancher/kine:v0.13.0"})
+			deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "kine", Image: "rancher/kine:v0.12.0"}}
+			d.DataStore.Spec.Kine.Image = ""
+
+			d.resetKineImages(deployment)
+			d.buildKine(&deployment.Spec.Template.Spec, tcp)
+
+			_, index := utilities.HasNamedContainer(deployment.Spec.Template.Spec.Containers, "kine")
+			Expect(deployment.Spec.Template.Spec.Containers[index].Image).To(Equal("rancher/kine:v0.12.0"))
+		})
+
+		It("should let the Tenant Control Plane override the DataStore defaults", func() {
+			podSpec := &corev1.PodSpec{}
+			tcp.Spec.ControlPlane.Deployment.ExtraArgs = &kamajiv1alpha1.ControlPlaneExtraArgs{Kine: []string{"--compact-interval=1m"}}
+			tcp.Spec.ControlPlane.Deployment.Resources = &kamajiv1alpha1.ControlPlaneComponentsResources{Kine: &corev1.ResourceRequirements{}}
+			tcp.Spec.ControlPlane.Deployment.AdditionalContainers = []corev1.Container{{Name: "kine", Image: "custom-kine:latest"}}
+
+			d.setAdditionalContainers(podSpec, tcp)
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Image).To(Equal("custom-kine:latest"))
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--compact-interval=1m"))
+			Expect(podSpec.Containers[index].Resources).To(Equal(corev1.ResourceRequirements{}))
+		})
+	})
+
+	Describe("Kine certificate authentication", func() {
+		var tcp kamajiv1alpha1.TenantControlPlane
+		BeforeEach(func() {
+			d.DataStore = kamajiv1alpha1.DataStore{
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver:               kamajiv1alpha1.KineMySQLDriver,
+					TLSConfig:            &kamajiv1alpha1.TLSConfig{},
+					TenantAuthentication: kamajiv1alpha1.DataStoreTenantAuthenticationCertificate,
+				},
+			}
+			tcp = kamajiv1alpha1.TenantControlPlane{}
+			tcp.Status.Storage.Config.SecretName = "test-secret"
+		})
+
+		It("should authenticate with the tenant certificate, rather than with a password", func() {
+			podSpec := &corev1.PodSpec{}
+
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElements(
+				"--endpoint=mysql://$(DB_USER)@tcp($(DB_CONNECTION_STRING))/$(DB_SCHEMA)",
+				"--cert-file=/certs/server.crt",
+				"--key-file=/certs/server.key",
+			))
+		})
+	})
+
+	Describe("Kine connection parameters", func() {
+		var tcp kamajiv1alpha1.TenantControlPlane
+		BeforeEach(func() {
+			d.DataStore = kamajiv1alpha1.DataStore{
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver:     kamajiv1alpha1.KinePostgreSQLDriver,
+					Endpoints:  kamajiv1alpha1.Endpoints{"10.0.0.10:5432"},
+					TLSConfig:  &kamajiv1alpha1.TLSConfig{ServerName: "postgresql.example.com"},
+					Parameters: map[string]string{"connect_timeout": "5"},
+				},
+			}
+			tcp = kamajiv1alpha1.TenantControlPlane{}
+			tcp.Status.Storage.Config.SecretName = "test-secret"
+		})
+
+		It("should append the parameters to the endpoint, keeping the host aliases", func() {
+			podSpec := &corev1.PodSpec{HostAliases: []corev1.HostAlias{{IP: "10.0.0.20", Hostnames: []string{"registry.example.com"}}}}
+
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/$(DB_SCHEMA)?$(DB_PARAMETERS)"))
+			Expect(podSpec.HostAliases).To(Equal([]corev1.HostAlias{{IP: "10.0.0.20", Hostnames: []string{"registry.example.com"}}}))
+		})
+
+		It("should append the parameters to the endpoint for the TLS server name only", func() {
+			podSpec := &corev1.PodSpec{}
+			d.DataStore.Spec.Parameters = nil
+
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/$(DB_SCHEMA)?$(DB_PARAMETERS)"))
+		})
+
+		It("should join the parameters with the search_path of the schema layout", func() {
+			podSpec := &corev1.PodSpec{}
+			d.DataStore.Spec.PostgreSQL = &kamajiv1alpha1.PostgreSQLDataStore{Layout: kamajiv1alpha1.PostgreSQLLayoutSchema, Database: "kamaji"}
+
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--endpoint=postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)/kamaji?search_path=$(DB_SCHEMA)&$(DB_PARAMETERS)"))
+		})
+	})
+
+	Describe("Kine NATS credentials", func() {
+		var tcp kamajiv1alpha1.TenantControlPlane
+		BeforeEach(func() {
+			d.DataStore = kamajiv1alpha1.DataStore{
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver: kamajiv1alpha1.KineNatsDriver,
+				},
+			}
+			tcp = kamajiv1alpha1.TenantControlPlane{}
+			tcp.Status.Storage.Config.SecretName = "test-secret"
+		})
+
+		It("should use the DataStore credentials without a NATS account", func() {
+			podSpec := &corev1.PodSpec{}
+
+			d.buildKineVolume(podSpec, tcp)
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--endpoint=nats://$(DB_USER):$(DB_PASSWORD)@$(DB_CONNECTION_STRING)?bucket=$(DB_SCHEMA)&noEmbed"))
+
+			found, _ := utilities.HasNamedVolume(podSpec.Volumes, kineNATSCredentialsVolumeName)
+			Expect(found).To(BeFalse())
+		})
+
+		It("should mount the tenant credentials issued by the NATS account", func() {
+			podSpec := &corev1.PodSpec{}
+			d.DataStore.Spec.NATSAccount = &kamajiv1alpha1.NATSAccount{}
+
+			d.buildKineVolume(podSpec, tcp)
+			d.buildKine(podSpec, tcp)
+
+			_, index := utilities.HasNamedContainer(podSpec.Containers, "kine")
+			Expect(podSpec.Containers[index].Args).To(ContainElement("--endpoint=nats://$(DB_CONNECTION_STRING)?bucket=$(DB_SCHEMA)&noEmbed&contextFile=/nats/NATS_CONTEXT"))
+			Expect(podSpec.Containers[index].VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: kineNATSCredentialsVolumeName, MountPath: "/nats", ReadOnly: true}))
+
+			found, volumeIndex := utilities.HasNamedVolume(podSpec.Volumes, kineNATSCredentialsVolumeName)
+			Expect(found).To(BeTrue())
+			Expect(podSpec.Volumes[volumeIndex].Secret.SecretName).To(Equal("test-secret"))
+
+			By("removing the NATS account")
+			d.DataStore.Spec.NATSAccount = nil
+			d.buildKineVolume(podSpec, tcp)
+
+			found, _ = utilities.HasNamedVolume(podSpec.Volumes, kineNATSCredentialsVolumeName)
+			Expect(found).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,175 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+// RootUser is the etcd user of the client certificate used by Kamaji,
+// allowed to manage the users and roles of the Tenant Control Planes.
+const RootUser = "root"
+
+// certificateValidity matches the one of the certificates Kamaji generates for the DataStore clients.
+const certificateValidity = 10 * 365 * 24 * time.Hour
+
+func CertificateAuthoritySecretName(ds kamajiv1alpha1.DataStore) string {
+	return Name(ds) + "-ca"
+}
+
+func ServerCertificateSecretName(ds kamajiv1alpha1.DataStore) string {
+	return Name(ds) + "-server-certificate"
+}
+
+func ClientCertificateSecretName(ds kamajiv1alpha1.DataStore) string {
+	return Name(ds) + "-root-client-certificate"
+}
+
+// serverNames returns the names the etcd members are serving, both as server and peer:
+// the wildcard one covers all the members, regardless of the replicas.
+func serverNames(ds kamajiv1alpha1.DataStore, namespace string) []string {
+	return []string{
+		fmt.Sprintf("*.%s.%s.svc", Name(ds), namespace),
+		"localhost",
+		"127.0.0.1",
+	}
+}
+
+// BuildCertificateAuthority generates the Certificate Authority of the etcd cluster, unless already available:
+// it signs the certificates of the members, and the client ones used by Kamaji and the Tenant Control Planes.
+func BuildCertificateAuthority(secret *corev1.Secret, ds kamajiv1alpha1.DataStore) error {
+	secret.SetLabels(CommonLabels(ds))
+
+	if ok, _ := crypto.CheckCertificateAndPrivateKeyPairValidity(secret.Data[kubeadmconstants.CACertName], secret.Data[kubeadmconstants.CAKeyName], 0); ok {
+		return nil
+	}
+
+	crt, key, err := pkiutil.NewCertificateAuthority(&pkiutil.CertConfig{
+		Config: certutil.Config{CommonName: Name(ds)},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot generate the etcd Certificate Authority: %w", err)
+	}
+
+	keyBytes, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return fmt.Errorf("cannot marshal the etcd Certificate Authority private key: %w", err)
+	}
+
+	secret.Data = map[string][]byte{
+		kubeadmconstants.CACertName: pkiutil.EncodeCertPEM(crt),
+		kubeadmconstants.CAKeyName:  keyBytes,
+	}
+
+	return nil
+}
+
+// BuildServerCertificate generates the certificate used by the etcd members both as server and peer,
+// unless the current one is valid and signed by the given Certificate Authority.
+func BuildServerCertificate(secret *corev1.Secret, ds kamajiv1alpha1.DataStore, namespace string, ca *corev1.Secret) error {
+	names := serverNames(ds, namespace)
+
+	return buildCertificate(secret, ds, ca, names, &pkiutil.CertConfig{
+		Config: certutil.Config{
+			CommonName: Name(ds),
+			AltNames:   altNames(names),
+			Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		},
+	})
+}
+
+// BuildClientCertificate generates the client certificate of the etcd root user, used by Kamaji,
+// unless the current one is valid and signed by the given Certificate Authority.
+func BuildClientCertificate(secret *corev1.Secret, ds kamajiv1alpha1.DataStore, ca *corev1.Secret) error {
+	return buildCertificate(secret, ds, ca, nil, &pkiutil.CertConfig{
+		Config: certutil.Config{
+			CommonName: RootUser,
+			Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+	})
+}
+
+func buildCertificate(secret *corev1.Secret, ds kamajiv1alpha1.DataStore, ca *corev1.Secret, names []string, config *pkiutil.CertConfig) error {
+	secret.SetLabels(CommonLabels(ds))
+
+	caCrt, caKey := ca.Data[kubeadmconstants.CACertName], ca.Data[kubeadmconstants.CAKeyName]
+
+	if isValidCertificate(secret, caCrt, names, config.Usages[0]) {
+		return nil
+	}
+
+	caCertificate, err := crypto.ParseCertificateBytes(caCrt)
+	if err != nil {
+		return fmt.Errorf("cannot parse the etcd Certificate Authority: %w", err)
+	}
+
+	caPrivateKey, err := crypto.ParsePrivateKeyBytes(caKey)
+	if err != nil {
+		return fmt.Errorf("cannot parse the etcd Certificate Authority private key: %w", err)
+	}
+
+	config.NotAfter = time.Now().Add(certificateValidity)
+
+	crt, key, err := pkiutil.NewCertAndKey(caCertificate, caPrivateKey, config)
+	if err != nil {
+		return fmt.Errorf("cannot generate the %s certificate: %w", config.CommonName, err)
+	}
+
+	keyBytes, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return fmt.Errorf("cannot marshal the %s private key: %w", config.CommonName, err)
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.ServiceAccountRootCAKey: caCrt,
+		corev1.TLSCertKey:              pkiutil.EncodeCertPEM(crt),
+		corev1.TLSPrivateKeyKey:        keyBytes,
+	}
+
+	return nil
+}
+
+func isValidCertificate(secret *corev1.Secret, ca []byte, names []string, usage x509.ExtKeyUsage) bool {
+	crt, key := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+
+	if ok, _ := crypto.CheckCertificateAndPrivateKeyPairValidity(crt, key, 0); !ok {
+		return false
+	}
+
+	if ok, _ := crypto.VerifyCertificate(crt, ca, usage); !ok {
+		return false
+	}
+
+	ok, _ := crypto.CheckCertificateNamesAndIPs(crt, names)
+
+	return ok
+}
+
+func altNames(names []string) certutil.AltNames {
+	var result certutil.AltNames
+
+	for _, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			result.IPs = append(result.IPs, ip)
+
+			continue
+		}
+
+		result.DNSNames = append(result.DNSNames, name)
+	}
+
+	return result
+}
@@ -0,0 +1,271 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	pointer "k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	ClientPort  = 2379
+	PeerPort    = 2380
+	MetricsPort = 2381
+
+	// InitialClusterStateNew bootstraps the etcd cluster,
+	// while InitialClusterStateExisting lets the members join the bootstrapped one.
+	InitialClusterStateNew      = "new"
+	InitialClusterStateExisting = "existing"
+
+	containerName          = "etcd"
+	dataVolumeName         = "data"
+	dataFolder             = "/var/run/etcd"
+	certificatesVolumeName = "certificates"
+	certificatesFolder     = "/etc/etcd/pki"
+)
+
+// Name returns the name of the resources backing the etcd cluster of the managed DataStore:
+// they're deployed in the Kamaji namespace, since the DataStore is cluster scoped.
+func Name(ds kamajiv1alpha1.DataStore) string {
+	return "datastore-" + ds.GetName()
+}
+
+// MemberName returns the name of the etcd member at the given index, matching its StatefulSet Pod.
+func MemberName(ds kamajiv1alpha1.DataStore, index int32) string {
+	return fmt.Sprintf("%s-%d", Name(ds), index)
+}
+
+// MemberIndex returns the index of the etcd member advertising the given peer URL, if it belongs to the managed DataStore.
+func MemberIndex(ds kamajiv1alpha1.DataStore, peerURL string) (int32, bool) {
+	host := strings.TrimPrefix(peerURL, "https://")
+	host, _, _ = strings.Cut(host, ".")
+
+	suffix, ok := strings.CutPrefix(host, Name(ds)+"-")
+	if !ok {
+		return 0, false
+	}
+
+	index, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return int32(index), true
+}
+
+func memberHost(ds kamajiv1alpha1.DataStore, namespace string, index int32) string {
+	return fmt.Sprintf("%s.%s.%s.svc", MemberName(ds, index), Name(ds), namespace)
+}
+
+// MemberEndpoint returns the client endpoint of the etcd member at the given index, without the protocol.
+func MemberEndpoint(ds kamajiv1alpha1.DataStore, namespace string, index int32) string {
+	return fmt.Sprintf("%s:%d", memberHost(ds, namespace, index), ClientPort)
+}
+
+// MemberPeerURL returns the peer URL of the etcd member at the given index.
+func MemberPeerURL(ds kamajiv1alpha1.DataStore, namespace string, index int32) string {
+	return fmt.Sprintf("https://%s:%d", memberHost(ds, namespace, index), PeerPort)
+}
+
+// Endpoints returns the client endpoints of the given number of etcd members.
+func Endpoints(ds kamajiv1alpha1.DataStore, namespace string, replicas int32) []string {
+	endpoints := make([]string, 0, replicas)
+
+	for i := range replicas {
+		endpoints = append(endpoints, MemberEndpoint(ds, namespace, i))
+	}
+
+	return endpoints
+}
+
+// CommonLabels returns the labels of the resources backing the etcd cluster of the managed DataStore.
+func CommonLabels(ds kamajiv1alpha1.DataStore) map[string]string {
+	return map[string]string{
+		constants.ProjectNameLabelKey:       constants.ProjectNameLabelValue,
+		constants.DataStoreLabelKey:         ds.GetName(),
+		constants.ControlPlaneLabelResource: "managed-etcd",
+	}
+}
+
+type StatefulSet struct {
+	Image string
+}
+
+// BuildService builds the headless Service providing the etcd members their stable network identity:
+// the not ready addresses are published, since the members must reach each other to form the quorum.
+func (s StatefulSet) BuildService(service *corev1.Service, ds kamajiv1alpha1.DataStore) {
+	service.SetLabels(CommonLabels(ds))
+
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	service.Spec.PublishNotReadyAddresses = true
+	service.Spec.Selector = CommonLabels(ds)
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "client",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       ClientPort,
+			TargetPort: intstr.FromInt32(ClientPort),
+		},
+		{
+			Name:       "peer",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       PeerPort,
+			TargetPort: intstr.FromInt32(PeerPort),
+		},
+	}
+}
+
+// Build builds the StatefulSet running the given number of etcd members:
+// the initial cluster lists all of them, and it's used only by the members missing their data.
+func (s StatefulSet) Build(statefulSet *appsv1.StatefulSet, ds kamajiv1alpha1.DataStore, replicas int32, initialClusterState string) {
+	namespace := statefulSet.GetNamespace()
+	labels := CommonLabels(ds)
+
+	statefulSet.SetLabels(labels)
+
+	statefulSet.Spec.Replicas = pointer.To(replicas)
+	statefulSet.Spec.ServiceName = Name(ds)
+	// The members must start altogether to bootstrap the cluster.
+	statefulSet.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+	statefulSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		WhenScaled:  appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+	}
+	// The claim templates are immutable, as the storage of the managed DataStore.
+	if len(statefulSet.Spec.VolumeClaimTemplates) == 0 {
+		statefulSet.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: dataVolumeName,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: ds.Spec.Managed.Storage.StorageClassName,
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: ds.Spec.Managed.Storage.Size,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	template := &statefulSet.Spec.Template
+	template.SetLabels(labels)
+	template.Spec.Affinity = &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+						TopologyKey:   corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+	template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: certificatesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  ServerCertificateSecretName(ds),
+					DefaultMode: pointer.To[int32](420),
+				},
+			},
+		},
+	}
+
+	initialCluster := make([]string, 0, replicas)
+	for i := range replicas {
+		initialCluster = append(initialCluster, fmt.Sprintf("%s=%s", MemberName(ds, i), MemberPeerURL(ds, namespace, i)))
+	}
+
+	advertisedHost := fmt.Sprintf("$(POD_NAME).%s.%s.svc", Name(ds), namespace)
+
+	found, index := utilities.HasNamedContainer(template.Spec.Containers, containerName)
+	if !found {
+		index = len(template.Spec.Containers)
+		template.Spec.Containers = append(template.Spec.Containers, corev1.Container{})
+	}
+
+	container := &template.Spec.Containers[index]
+	container.Name = containerName
+	container.Image = fmt.Sprintf("%s:%s", s.Image, ds.Spec.Managed.Version)
+	container.Command = []string{"etcd"}
+	container.Args = []string{
+		"--name=$(POD_NAME)",
+		"--data-dir=" + dataFolder,
+		fmt.Sprintf("--listen-client-urls=https://0.0.0.0:%d", ClientPort),
+		fmt.Sprintf("--advertise-client-urls=https://%s:%d", advertisedHost, ClientPort),
+		fmt.Sprintf("--listen-peer-urls=https://0.0.0.0:%d", PeerPort),
+		fmt.Sprintf("--initial-advertise-peer-urls=https://%s:%d", advertisedHost, PeerPort),
+		fmt.Sprintf("--listen-metrics-urls=http://0.0.0.0:%d", MetricsPort),
+		"--initial-cluster=" + strings.Join(initialCluster, ","),
+		"--initial-cluster-state=" + initialClusterState,
+		"--initial-cluster-token=" + Name(ds),
+		"--client-cert-auth=true",
+		"--trusted-ca-file=" + path.Join(certificatesFolder, corev1.ServiceAccountRootCAKey),
+		"--cert-file=" + path.Join(certificatesFolder, corev1.TLSCertKey),
+		"--key-file=" + path.Join(certificatesFolder, corev1.TLSPrivateKeyKey),
+		"--peer-client-cert-auth=true",
+		"--peer-trusted-ca-file=" + path.Join(certificatesFolder, corev1.ServiceAccountRootCAKey),
+		"--peer-cert-file=" + path.Join(certificatesFolder, corev1.TLSCertKey),
+		"--peer-key-file=" + path.Join(certificatesFolder, corev1.TLSPrivateKeyKey),
+		"--auto-compaction-mode=periodic",
+		"--auto-compaction-retention=5m",
+	}
+	container.Env = []corev1.EnvVar{
+		{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"},
+			},
+		},
+	}
+	container.Ports = []corev1.ContainerPort{
+		{Name: "client", ContainerPort: ClientPort, Protocol: corev1.ProtocolTCP},
+		{Name: "peer", ContainerPort: PeerPort, Protocol: corev1.ProtocolTCP},
+		{Name: "metrics", ContainerPort: MetricsPort, Protocol: corev1.ProtocolTCP},
+	}
+	container.VolumeMounts = []corev1.VolumeMount{
+		{Name: dataVolumeName, MountPath: dataFolder},
+		{Name: certificatesVolumeName, MountPath: certificatesFolder, ReadOnly: true},
+	}
+	container.LivenessProbe = probe("/livez", 8)
+	container.ReadinessProbe = probe("/readyz", 3)
+}
+
+// probe returns the etcd health probe, served on the metrics port without authentication.
+func probe(path string, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   path,
+				Port:   intstr.FromInt32(MetricsPort),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		InitialDelaySeconds: 0,
+		TimeoutSeconds:      15,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    failureThreshold,
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+func TestManagedEtcd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Managed etcd Suite")
+}
+
+var _ = Describe("Managed etcd", func() {
+	const namespace = "kamaji-system"
+
+	var ds kamajiv1alpha1.DataStore
+
+	BeforeEach(func() {
+		ds = kamajiv1alpha1.DataStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "managed"},
+			Spec: kamajiv1alpha1.DataStoreSpec{
+				Driver: kamajiv1alpha1.EtcdDriver,
+				Managed: &kamajiv1alpha1.ManagedDataStore{
+					Replicas: 3,
+					Version:  "v3.6.13",
+					Storage: kamajiv1alpha1.ManagedDataStoreStorage{
+						Size: resource.MustParse("8Gi"),
+					},
+				},
+			},
+		}
+	})
+
+	Describe("members", func() {
+		It("should resolve the index from the peer URL", func() {
+			index, ok := MemberIndex(ds, MemberPeerURL(ds, namespace, 2))
+			Expect(ok).To(BeTrue())
+			Expect(index).To(BeEquivalentTo(2))
+		})
+		It("should ignore the peer URL of other clusters", func() {
+			_, ok := MemberIndex(ds, "https://datastore-other-0.datastore-other.kamaji-system.svc:2380")
+			Expect(ok).To(BeFalse())
+		})
+		It("should generate the endpoints of the given replicas", func() {
+			Expect(Endpoints(ds, namespace, 2)).To(Equal([]string{
+				"datastore-managed-0.datastore-managed.kamaji-system.svc:2379",
+				"datastore-managed-1.datastore-managed.kamaji-system.svc:2379",
+			}))
+		})
+	})
+
+	Describe("StatefulSet", func() {
+		It("should list all the members in the initial cluster", func() {
+			sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: Name(ds), Namespace: namespace}}
+			StatefulSet{Image: "quay.io/coreos/etcd"}.Build(sts, ds, 3, InitialClusterStateExisting)
+
+			Expect(*sts.Spec.Replicas).To(BeEquivalentTo(3))
+			Expect(sts.Spec.Template.Spec.Containers).To(HaveLen(1))
+
+			container := sts.Spec.Template.Spec.Containers[0]
+			Expect(container.Image).To(Equal("quay.io/coreos/etcd:v3.6.13"))
+			Expect(container.Args).To(ContainElements(
+				"--initial-cluster=datastore-managed-0=https://datastore-managed-0.datastore-managed.kamaji-system.svc:2380,"+
+					"datastore-managed-1=https://datastore-managed-1.datastore-managed.kamaji-system.svc:2380,"+
+					"datastore-managed-2=https://datastore-managed-2.datastore-managed.kamaji-system.svc:2380",
+				"--initial-cluster-state=existing",
+			))
+		})
+		It("should preserve the volume claim templates", func() {
+			sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: Name(ds), Namespace: namespace}}
+			StatefulSet{Image: "quay.io/coreos/etcd"}.Build(sts, ds, 3, InitialClusterStateNew)
+
+			ds.Spec.Managed.Storage.Size = resource.MustParse("16Gi")
+			StatefulSet{Image: "quay.io/coreos/etcd"}.Build(sts, ds, 3, InitialClusterStateNew)
+
+			Expect(sts.Spec.VolumeClaimTemplates).To(HaveLen(1))
+			Expect(sts.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String()).To(Equal("8Gi"))
+		})
+	})
+
+	Describe("certificates", func() {
+		var ca *corev1.Secret
+
+		BeforeEach(func() {
+			ca = &corev1.Secret{}
+			Expect(BuildCertificateAuthority(ca, ds)).To(Succeed())
+		})
+
+		It("should not regenerate a valid Certificate Authority", func() {
+			data := ca.Data
+			Expect(BuildCertificateAuthority(ca, ds)).To(Succeed())
+			Expect(ca.Data).To(Equal(data))
+		})
+		It("should serve all the members", func() {
+			server := &corev1.Secret{}
+			Expect(BuildServerCertificate(server, ds, namespace, ca)).To(Succeed())
+
+			crt, err := crypto.ParseCertificateBytes(server.Data[corev1.TLSCertKey])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(crt.VerifyHostname("datastore-managed-4.datastore-managed.kamaji-system.svc")).To(Succeed())
+			Expect(crt.VerifyHostname("127.0.0.1")).To(Succeed())
+		})
+		It("should regenerate the client certificate when the Certificate Authority changes", func() {
+			client := &corev1.Secret{}
+			Expect(BuildClientCertificate(client, ds, ca)).To(Succeed())
+
+			data := client.Data
+			Expect(BuildClientCertificate(client, ds, ca)).To(Succeed())
+			Expect(client.Data).To(Equal(data))
+
+			other := &corev1.Secret{}
+			Expect(BuildCertificateAuthority(other, ds)).To(Succeed())
+			Expect(BuildClientCertificate(client, ds, other)).To(Succeed())
+			Expect(client.Data).ToNot(Equal(data))
+		})
+	})
+})
@@ -0,0 +1,12 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FreezeStatusReason is the reason of the requests denied by the freeze webhook, returned by the API Server
+// along with the status error: it allows detecting the enforcement of the freeze regardless of the message.
+const FreezeStatusReason metav1.StatusReason = "TenantControlPlaneFrozen"
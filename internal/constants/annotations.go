@@ -7,4 +7,7 @@ const (
 	// Checksum is the annotation label that we use to store the checksum for the resource:
 	// it allows to check by comparing it if the resource has been changed and must be aligned with the reconciliation.
 	Checksum = "kamaji.clastix.io/checksum"
+	// CertificateAuthoritySource is the annotation of the Certificate Authority Secret referencing the external one it's issued by,
+	// such as the Secret, or the cert-manager issuer: a change of the reference rotates the Certificate Authority.
+	CertificateAuthoritySource = "kamaji.clastix.io/certificate-authority-source"
 )
@@ -0,0 +1,30 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+const (
+	// CABundleKeyName is the key of the Certificate Authority Secret holding its certificate followed by the chain
+	// of the external Certificate Authority it's issued by: it's the bundle handed to the clients.
+	CABundleKeyName = "ca-bundle.crt"
+	// CARequestKeyName is the key of the Certificate Authority Secret holding the private key
+	// of the pending cert-manager CertificateRequest, until the certificate is issued.
+	CARequestKeyName = "ca-request.key"
+	// CATrustBundleKeyName is the key of the Certificate Authority Secret holding the Certificate Authorities
+	// trusted by the API Server to authenticate the clients, and published to the workloads by the Controller Manager:
+	// during a Graceful rotation, it contains both the current and the new Certificate Authority.
+	CATrustBundleKeyName = "ca-trust-bundle.crt"
+	// CANextCertName and CANextKeyName are the keys of the Certificate Authority Secret holding the new Certificate Authority,
+	// until it replaces the current one during a Graceful rotation.
+	CANextCertName = "ca-next.crt"
+	CANextKeyName  = "ca-next.key"
+	// CAPreviousCertName is the key of the Certificate Authority Secret holding the replaced Certificate Authority,
+	// still trusted until the Graceful rotation is completed.
+	CAPreviousCertName = "ca-previous.crt"
+	// ServiceAccountVerificationKeysName is the key of the Service Account Secret holding the public keys
+	// verifying the tokens: the current one, followed by the retired ones still within their retention period.
+	ServiceAccountVerificationKeysName = "sa-verification.pub"
+	// ServiceAccountRetiredKeyPrefix prefixes the keys of the Service Account Secret holding the replaced public keys,
+	// suffixed by the Unix time of their retirement.
+	ServiceAccountRetiredKeyPrefix = "sa-retired-"
+)
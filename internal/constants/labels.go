@@ -10,4 +10,6 @@ const (
 	ControlPlaneLabelKey      = "kamaji.clastix.io/name"
 	ControlPlaneLabelResource = "kamaji.clastix.io/component"
 	ControllerLabelResource   = "kamaji.clastix.io/certificate_lifecycle_controller"
+
+	DataStoreLabelKey = "kamaji.clastix.io/datastore"
 )
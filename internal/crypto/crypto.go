@@ -6,15 +6,21 @@ package crypto
 import (
 	"bytes"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	cryptorand "crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"math/big"
 	mathrand "math/rand"
 	"net"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -82,9 +88,43 @@ func CheckCertificateAndPrivateKeyPairValidity(certificate, privateKey []byte, t
 	}
 }
 
+// CheckPrivateKeyAlgorithm checks if the given private key has been generated with the given algorithm,
+// such as RSA-2048 or ECDSA-P256: an empty algorithm matches any key.
+func CheckPrivateKeyAlgorithm(privateKey []byte, algorithm string) (bool, error) {
+	if algorithm == "" {
+		return true, nil
+	}
+
+	key, err := ParsePrivateKeyBytes(privateKey)
+	if err != nil {
+		return false, err
+	}
+
+	return publicKeyAlgorithm(key.Public()) == algorithm, nil
+}
+
+// GeneratePrivateKey generates a private key with the given algorithm, named after the kubeadm encryption ones:
+// RSA-2048, RSA-3072, RSA-4096, ECDSA-P256, and ECDSA-P384, an RSA-2048 key being generated when empty.
+func GeneratePrivateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case "", "RSA-2048":
+		return rsa.GenerateKey(cryptorand.Reader, 2048)
+	case "RSA-3072":
+		return rsa.GenerateKey(cryptorand.Reader, 3072)
+	case "RSA-4096":
+		return rsa.GenerateKey(cryptorand.Reader, 4096)
+	case "ECDSA-P256":
+		return ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	case "ECDSA-P384":
+		return ecdsa.GenerateKey(elliptic.P384(), cryptorand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %s", algorithm)
+	}
+}
+
 // GenerateCertificatePrivateKeyPair starts from the Certificate Authority bytes a certificate using the provided
-// template, returning the bytes both for the certificate and its key.
-func GenerateCertificatePrivateKeyPair(template *x509.Certificate, caCertificate []byte, caPrivateKey []byte) (*bytes.Buffer, *bytes.Buffer, error) {
+// template, returning the bytes both for the certificate and its key, generated with the given algorithm.
+func GenerateCertificatePrivateKeyPair(template *x509.Certificate, caCertificate []byte, caPrivateKey []byte, algorithm string) (*bytes.Buffer, *bytes.Buffer, error) {
 	caCertBytes, err := ParseCertificateBytes(caCertificate)
 	if err != nil {
 		return nil, nil, err
@@ -95,7 +135,7 @@ func GenerateCertificatePrivateKeyPair(template *x509.Certificate, caCertificate
 		return nil, nil, fmt.Errorf("provided CA private key for certificate generation cannot be parsed: %w", err)
 	}
 
-	return generateCertificateKeyPairBytes(template, caCertBytes, caPrivKeyBytes)
+	return generateCertificateKeyPairBytes(template, caCertBytes, caPrivKeyBytes, algorithm)
 }
 
 // ParseCertificateBytes takes the certificate bytes returning a x509 certificate by parsing it.
@@ -113,7 +153,7 @@ func ParseCertificateBytes(content []byte) (*x509.Certificate, error) {
 	return crt, nil
 }
 
-// ParsePrivateKeyBytes takes the private key bytes returning an RSA private key by parsing it.
+// ParsePrivateKeyBytes takes the private key bytes returning the private key by parsing it.
 func ParsePrivateKeyBytes(content []byte) (crypto.Signer, error) {
 	pemContent, _ := pem.Decode(content)
 	if pemContent == nil {
@@ -151,8 +191,8 @@ func ParsePrivateKeyBytes(content []byte) (crypto.Signer, error) {
 	return privateKey, nil
 }
 
-// ParsePublicKeyBytes takes the public key bytes returning an RSA public key by parsing it.
-func ParsePublicKeyBytes(content []byte) (*rsa.PublicKey, error) {
+// ParsePublicKeyBytes takes the public key bytes returning an RSA, ECDSA, or Ed25519 public key by parsing it.
+func ParsePublicKeyBytes(content []byte) (crypto.PublicKey, error) {
 	pemContent, _ := pem.Decode(content)
 	if pemContent == nil {
 		return nil, fmt.Errorf("no right PEM block")
@@ -163,12 +203,30 @@ func ParsePublicKeyBytes(content []byte) (*rsa.PublicKey, error) {
 		return nil, err
 	}
 
-	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("expected *rsa.PublicKey, got %T", rsaPublicKey)
+	switch publicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// PublicKeyID returns the identifier of the public key, as computed by the API Server for the Service Account tokens:
+// it's the URL-safe base64 encoding of the SHA-256 hash of its PKIX form.
+func PublicKeyID(content []byte) (string, error) {
+	publicKey, err := ParsePublicKeyBytes(content)
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
 	}
 
-	return rsaPublicKey, nil
+	hash := sha256.Sum256(der)
+
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
 }
 
 // IsValidCertificateKeyPairBytes checks if the certificate matches the private key bounded to it.
@@ -193,6 +251,110 @@ func IsValidCertificateKeyPairBytes(certificateBytes, privateKeyBytes []byte, ex
 	}
 }
 
+// ParseCertificateChainBytes takes the bytes of a PEM bundle returning all the x509 certificates it contains, in order.
+func ParseCertificateChainBytes(content []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+
+	for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		crt, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse x509 Certificate: %w", err)
+		}
+
+		chain = append(chain, crt)
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no right PEM block")
+	}
+
+	return chain, nil
+}
+
+// EncodeCertificateChain returns the PEM bundle of the given certificates.
+func EncodeCertificateChain(chain []*x509.Certificate) []byte {
+	bundle := &bytes.Buffer{}
+
+	for _, crt := range chain {
+		_ = pem.Encode(bundle, &pem.Block{Type: "CERTIFICATE", Bytes: crt.Raw})
+	}
+
+	return bundle.Bytes()
+}
+
+// VerifyCertificateAuthorityChain checks if the first certificate of the bundle is a Certificate Authority
+// chaining to the last one, the certificates in between being the intermediate ones:
+// a bundle made of a single certificate must be a self-signed Certificate Authority.
+func VerifyCertificateAuthorityChain(bundle []byte) (bool, error) {
+	chain, err := ParseCertificateChainBytes(bundle)
+	if err != nil {
+		return false, err
+	}
+
+	if !chain[0].IsCA || chain[0].KeyUsage&x509.KeyUsageCertSign == 0 {
+		return false, fmt.Errorf("the certificate %q is not a Certificate Authority", chain[0].Subject.CommonName)
+	}
+
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	roots.AddCert(chain[len(chain)-1])
+
+	for _, crt := range chain[1:max(len(chain)-1, 1)] {
+		intermediates.AddCert(crt)
+	}
+
+	chains, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+
+	return len(chains) > 0, err
+}
+
+// GenerateCertificateRequest returns the PEM encoded Certificate Signing Request for the given common name, signed with the private key.
+func GenerateCertificateRequest(commonName string, privateKey crypto.Signer) ([]byte, error) {
+	request, err := x509.CreateCertificateRequest(cryptorand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: commonName}}, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the certificate request: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request}), nil
+}
+
+// CheckCertificateRequestPrivateKey checks if the PEM encoded Certificate Signing Request has been signed with the given private key.
+func CheckCertificateRequestPrivateKey(request, privateKey []byte) (bool, error) {
+	pemContent, _ := pem.Decode(request)
+	if pemContent == nil {
+		return false, fmt.Errorf("no right PEM block")
+	}
+
+	csr, err := x509.ParseCertificateRequest(pemContent.Bytes)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse the certificate request: %w", err)
+	}
+
+	key, err := ParsePrivateKeyBytes(privateKey)
+	if err != nil {
+		return false, err
+	}
+
+	return checkPublicKeys(csr.PublicKey, key), nil
+}
+
+// EncodePrivateKey returns the PEM encoded private key, PKCS1 for RSA keys, and SEC 1 for ECDSA ones.
+func EncodePrivateKey(privateKey crypto.Signer) ([]byte, error) {
+	block, err := privateKeyPEMBlock(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(block), nil
+}
+
 func VerifyCertificate(cert, ca []byte, usages ...x509.ExtKeyUsage) (bool, error) {
 	if len(usages) == 0 {
 		return false, fmt.Errorf("missing usages for certificate verification")
@@ -219,13 +381,13 @@ func VerifyCertificate(cert, ca []byte, usages ...x509.ExtKeyUsage) (bool, error
 	return len(chains) > 0, err
 }
 
-func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Certificate, caKey crypto.Signer) (*bytes.Buffer, *bytes.Buffer, error) {
-	certPrivKey, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Certificate, caKey crypto.Signer, algorithm string) (*bytes.Buffer, *bytes.Buffer, error) {
+	certPrivKey, err := GeneratePrivateKey(algorithm)
 	if err != nil {
-		return nil, nil, fmt.Errorf("cannot generate an RSA key: %w", err)
+		return nil, nil, fmt.Errorf("cannot generate the private key: %w", err)
 	}
 
-	certBytes, err := x509.CreateCertificate(cryptorand.Reader, template, caCert, &certPrivKey.PublicKey, caKey)
+	certBytes, err := x509.CreateCertificate(cryptorand.Reader, template, caCert, certPrivKey.Public(), caKey)
 	if err != nil {
 		return nil, nil, fmt.Errorf("cannot create the certificate: %w", err)
 	}
@@ -239,18 +401,50 @@ func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Ce
 		return nil, nil, fmt.Errorf("cannot encode the generate certificate bytes: %w", err)
 	}
 
+	privateKeyBlock, err := privateKeyPEMBlock(certPrivKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	certPrivKeyPEM := &bytes.Buffer{}
-	if err = pem.Encode(certPrivKeyPEM, &pem.Block{
-		Type:    "RSA PRIVATE KEY",
-		Headers: nil,
-		Bytes:   x509.MarshalPKCS1PrivateKey(certPrivKey),
-	}); err != nil {
+	if err = pem.Encode(certPrivKeyPEM, privateKeyBlock); err != nil {
 		return nil, nil, fmt.Errorf("cannot encode private key: %w", err)
 	}
 
 	return certPEM, certPrivKeyPEM, nil
 }
 
+// privateKeyPEMBlock returns the PEM block of the private key, PKCS1 for RSA keys, and SEC 1 for ECDSA ones.
+func privateKeyPEMBlock(key crypto.Signer) (*pem.Block, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}, nil
+	case *ecdsa.PrivateKey:
+		keyBytes, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal the EC private key: %w", err)
+		}
+
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// publicKeyAlgorithm returns the name of the algorithm used to generate the key, such as RSA-2048 or ECDSA-P256.
+func publicKeyAlgorithm(key crypto.PublicKey) string {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA-" + strings.ReplaceAll(k.Curve.Params().Name, "-", "")
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return ""
+	}
+}
+
 func checkCertificateValidity(cert x509.Certificate, threshold time.Duration) bool {
 	// Avoiding waiting for the exact expiration date by creating a one-day gap
 	notAfter := cert.NotAfter.After(time.Now().Add(threshold))
@@ -271,8 +465,7 @@ func checkPublicKeys(a crypto.PublicKey, b crypto.Signer) bool {
 // used to perform the authentication against the DataStore.
 func NewCertificateTemplate(commonName string) *x509.Certificate {
 	return &x509.Certificate{
-		PublicKeyAlgorithm: x509.RSA,
-		SerialNumber:       big.NewInt(mathrand.Int63()),
+		SerialNumber: big.NewInt(mathrand.Int63()),
 		Subject: pkix.Name{
 			CommonName:   commonName,
 			Organization: []string{"system:masters"},
@@ -4,12 +4,15 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/pem"
 	"math/big"
 	"testing"
@@ -92,6 +95,163 @@ func TestParsePrivateKeyBytes(t *testing.T) {
 	}
 }
 
+func TestGenerateCertificatePrivateKeyPairAlgorithm(t *testing.T) {
+	caCert, caKey, err := GenerateSelfSignedCA()
+	if err != nil {
+		t.Fatalf("failed to generate the CA: %v", err)
+	}
+
+	for _, algorithm := range []string{"", "RSA-3072", "ECDSA-P256", "ECDSA-P384"} {
+		t.Run(algorithm, func(t *testing.T) {
+			crt, key, err := GenerateCertificatePrivateKeyPair(NewCertificateTemplate("test"), caCert, caKey, algorithm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ok, err := IsValidCertificateKeyPairBytes(crt.Bytes(), key.Bytes(), time.Hour); !ok || err != nil {
+				t.Fatalf("expected a valid certificate-private_key pair, got %v", err)
+			}
+
+			expected := algorithm
+			if expected == "" {
+				expected = "RSA-2048"
+			}
+
+			if ok, err := CheckPrivateKeyAlgorithm(key.Bytes(), expected); !ok || err != nil {
+				t.Fatalf("expected a %s private key, got %v", expected, err)
+			}
+		})
+	}
+
+	if ok, _ := CheckPrivateKeyAlgorithm(caKey, "ECDSA-P256"); ok {
+		t.Fatal("expected the RSA key not to match the ECDSA-P256 algorithm")
+	}
+
+	if ok, _ := CheckPrivateKeyAlgorithm(caKey, ""); !ok {
+		t.Fatal("expected an empty algorithm to match any key")
+	}
+
+	if _, _, err = GenerateCertificatePrivateKeyPair(NewCertificateTemplate("test"), caCert, caKey, "DSA-1024"); err == nil {
+		t.Fatal("expected an unsupported algorithm to be rejected")
+	}
+}
+
+func TestParsePublicKeyBytes(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		t.Fatalf("failed to marshal the public key: %v", err)
+	}
+
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal EC: %v", err)
+	}
+
+	ok, err := CheckPublicAndPrivateKeyValidity(
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes}),
+	)
+	if !ok || err != nil {
+		t.Fatalf("expected a valid ECDSA public_key-private_key pair, got %v", err)
+	}
+}
+
+func TestPublicKeyID(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		t.Fatalf("failed to marshal the public key: %v", err)
+	}
+
+	keyID, err := PublicKeyID(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}))
+	if err != nil {
+		t.Fatalf("failed to compute the key identifier: %v", err)
+	}
+
+	hash := sha256.Sum256(publicKeyBytes)
+	if expected := base64.RawURLEncoding.EncodeToString(hash[:]); keyID != expected {
+		t.Fatalf("expected the key identifier %s, got %s", expected, keyID)
+	}
+
+	if _, err = PublicKeyID([]byte("not a key")); err == nil {
+		t.Fatal("expected an invalid public key to be rejected")
+	}
+}
+
+func TestVerifyCertificateAuthorityChain(t *testing.T) {
+	rootCert, rootKey, err := GenerateSelfSignedCA()
+	if err != nil {
+		t.Fatalf("failed to generate the CA: %v", err)
+	}
+
+	template := NewCertificateTemplate("intermediate")
+	template.IsCA, template.BasicConstraintsValid = true, true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+
+	intermediateCert, intermediateKey, err := GenerateCertificatePrivateKeyPair(template, rootCert, rootKey, "ECDSA-P256")
+	if err != nil {
+		t.Fatalf("failed to generate the intermediate CA: %v", err)
+	}
+
+	bundle := append(intermediateCert.Bytes(), rootCert...)
+	if ok, err := VerifyCertificateAuthorityChain(bundle); !ok || err != nil {
+		t.Fatalf("expected the chain to be valid, got %v", err)
+	}
+
+	if chain, err := ParseCertificateChainBytes(bundle); err != nil || len(chain) != 2 || !bytes.Equal(EncodeCertificateChain(chain), bundle) {
+		t.Fatalf("expected the chain to be made of 2 certificates, got %v", err)
+	}
+
+	if ok, _ := VerifyCertificateAuthorityChain(rootCert); !ok {
+		t.Fatal("expected a self-signed Certificate Authority to be a valid chain")
+	}
+
+	otherCert, _, err := GenerateSelfSignedCA()
+	if err != nil {
+		t.Fatalf("failed to generate the CA: %v", err)
+	}
+
+	if ok, _ := VerifyCertificateAuthorityChain(append(intermediateCert.Bytes(), otherCert...)); ok {
+		t.Fatal("expected the intermediate not to chain to another root")
+	}
+
+	leafCert, _, err := GenerateCertificatePrivateKeyPair(NewCertificateTemplate("leaf"), rootCert, rootKey, "")
+	if err != nil {
+		t.Fatalf("failed to generate the certificate: %v", err)
+	}
+
+	if ok, _ := VerifyCertificateAuthorityChain(append(leafCert.Bytes(), rootCert...)); ok {
+		t.Fatal("expected a leaf certificate not to be a Certificate Authority")
+	}
+
+	key, err := ParsePrivateKeyBytes(intermediateKey.Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse the private key: %v", err)
+	}
+
+	request, err := GenerateCertificateRequest("kubernetes", key)
+	if err != nil {
+		t.Fatalf("failed to generate the certificate request: %v", err)
+	}
+
+	if ok, err := CheckCertificateRequestPrivateKey(request, intermediateKey.Bytes()); !ok || err != nil {
+		t.Fatalf("expected the certificate request to match the private key, got %v", err)
+	}
+
+	if ok, _ := CheckCertificateRequestPrivateKey(request, rootKey); ok {
+		t.Fatal("expected the certificate request not to match another private key")
+	}
+}
+
 func GenerateSelfSignedCA() ([]byte, []byte, error) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
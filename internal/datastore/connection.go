@@ -6,6 +6,7 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -18,12 +19,13 @@ func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1
 		return nil, fmt.Errorf("unable to create connection config object: %w", err)
 	}
 
+	return newStorageConnection(ds, *cc)
+}
+
+func newStorageConnection(ds kamajiv1alpha1.DataStore, cc ConnectionConfig) (Connection, error) {
 	switch ds.Spec.Driver {
 	case kamajiv1alpha1.KineMySQLDriver:
-
-		if ds.Spec.TLSConfig != nil {
-			cc.TLSConfig.ServerName = cc.Endpoints[0].Host
-		}
+		cc.TLSConfig = cc.serverNameTLSConfig()
 
 		// NOTE: multiStatements is intentionally NOT enabled here. Only the dump
 		// import performed during Migrate needs to execute a batch of statements
@@ -31,25 +33,55 @@ func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1
 		// Keeping the primary connection single-statement prevents any SQL
 		// injection on the interpolated DDL statements from escalating into
 		// stacked queries.
-		return NewMySQLConnection(*cc)
+		return NewMySQLConnection(cc)
 	case kamajiv1alpha1.KinePostgreSQLDriver:
-		if ds.Spec.TLSConfig != nil {
-			cc.TLSConfig.ServerName = cc.Endpoints[0].Host
-		}
+		cc.TLSConfig = cc.serverNameTLSConfig()
 
-		return NewPostgreSQLConnection(*cc)
+		return NewPostgreSQLConnection(cc)
 	case kamajiv1alpha1.EtcdDriver:
-		return NewETCDConnection(*cc)
+		return NewETCDConnection(cc)
 	case kamajiv1alpha1.KineNatsDriver:
-		return NewNATSConnection(*cc)
+		return NewNATSConnection(cc)
 	default:
 		return nil, fmt.Errorf("%s is not a valid driver", ds.Spec.Driver)
 	}
 }
 
+// Usage reports the storage consumed by a tenant on the DataStore.
+type Usage struct {
+	// Bytes is the size of the tenant data, including indexes for SQL drivers.
+	Bytes int64
+	// Keys is the number of keys for etcd and NATS, or the number of kine rows for SQL drivers,
+	// thus including the revisions history not yet compacted.
+	Keys int64
+	// LiveBytes is the size of the keys and values at their latest revision, excluding the deleted ones:
+	// unlike Bytes, it doesn't account for the revisions history and the storage not yet reclaimed,
+	// thus it drops as soon as the keys are deleted.
+	LiveBytes int64
+}
+
+// UserLimits caps the resources consumed by a tenant user, the zero values meaning no limit.
+type UserLimits struct {
+	MaxConnections    int32
+	MaxQueriesPerHour int32
+	StatementTimeout  time.Duration
+}
+
 type Connection interface {
 	CreateUser(ctx context.Context, user, password string) error
+	// CreateCertificateUser creates the user authenticating with a client certificate, having the user as Common Name,
+	// rather than with a password.
+	CreateCertificateUser(ctx context.Context, user string) error
 	UpdateUser(ctx context.Context, user, password string) error
+	// RotateUser sets the new password of the login user, returning true if the current one is still accepted,
+	// when supported by the driver, until DiscardPreviousPassword is called.
+	// The login user is the user itself, unless the driver alternates the credentials across login users members of it.
+	RotateUser(ctx context.Context, user, loginUser, password string) (bool, error)
+	// DiscardPreviousPassword stops accepting the credentials retained by RotateUser, other than the login user ones.
+	DiscardPreviousPassword(ctx context.Context, user, loginUser string) error
+	// SetUserLimits enforces the given limits on the user, lifting the unset ones:
+	// the drivers not supporting a limit ignore it.
+	SetUserLimits(ctx context.Context, user string, limits UserLimits) error
 	CreateDB(ctx context.Context, dbName string) error
 	GrantPrivileges(ctx context.Context, user, dbName string) error
 	UserExists(ctx context.Context, user string) (bool, error)
@@ -62,5 +94,23 @@ type Connection interface {
 	Close() error
 	Check(ctx context.Context) error
 	Driver() string
-	Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error
+	// Usage returns the storage consumed by the tenant identified by the given database name.
+	Usage(ctx context.Context, dbName string) (Usage, error)
+	// ListDatabases returns the tenant databases stored on the DataStore: the SQL databases holding the kine table,
+	// the etcd key prefixes and roles, or the NATS key-value buckets.
+	ListDatabases(ctx context.Context) ([]string, error)
+	// ListUsers returns the tenant users of the DataStore, excluding the one used by Kamaji and the reserved ones.
+	// Both listings could include objects not provisioned by Kamaji: these must be matched against the DataStore inventory.
+	ListUsers(ctx context.Context) ([]string, error)
+	// Migrate copies the tenant keyspace to the target DataStore, backed by the same driver, storing it in the given schema:
+	// this is the Tenant Control Plane one when migrating, or a different one when cloning.
+	Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string) error
+	// Export walks the tenant keyspace, invoking the given function with the driver-neutral
+	// representation of each key, allowing migrations across different drivers.
+	Export(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, fn func(kv KeyValue) error) error
+	// Truncate removes all the key-values of the tenant keyspace, if any, preventing stale keys from surviving an import.
+	Truncate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error
+	// Import writes the given driver-neutral key-values into the tenant keyspace,
+	// creating the storage if missing: the keys must not be already stored.
+	Import(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs []KeyValue) error
 }
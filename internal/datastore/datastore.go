@@ -16,6 +16,10 @@ import (
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 )
 
+// KineTLSServerNameParameter is the kine endpoint parameter carrying the TLS server name of the data store,
+// verifying its certificate when it differs from the endpoints host.
+const KineTLSServerNameParameter = "tls-server-name"
+
 type ConnectionEndpoint struct {
 	Host string
 	Port int
@@ -32,6 +36,13 @@ type ConnectionConfig struct {
 	DBName     string
 	TLSConfig  *tls.Config
 	Parameters map[string][]string
+	// NATSSigningKey and NATSAccount are the NATS account issuing the users, if any.
+	NATSSigningKey []byte
+	NATSAccount    string
+	// Pool configures the connection pool of the SQL drivers.
+	Pool PoolOptions
+	// SharedDatabase is the PostgreSQL database storing the tenants as schemas, rather than as databases, if any.
+	SharedDatabase string
 }
 
 func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1alpha1.DataStore) (*ConnectionConfig, error) {
@@ -48,8 +59,10 @@ func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1a
 			return nil, fmt.Errorf("error create root CA for the DB connector")
 		}
 
+		// The server certificate is always verified, against the host of the connected endpoint when no server name is set.
 		tlsConfig = &tls.Config{
-			RootCAs: rootCAs,
+			RootCAs:    rootCAs,
+			ServerName: ds.Spec.TLSConfig.ServerName,
 		}
 	}
 
@@ -87,9 +100,21 @@ func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1a
 		password = string(p)
 	}
 
-	eps := make([]ConnectionEndpoint, 0, len(ds.Spec.Endpoints))
+	var natsSigningKey []byte
+	var natsAccount string
+	if account := ds.Spec.NATSAccount; account != nil {
+		key, err := account.SigningKey.GetContent(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		natsSigningKey = key
+		natsAccount = account.PublicKey
+	}
+
+	endpoints := ds.ActiveEndpoints()
+	eps := make([]ConnectionEndpoint, 0, len(endpoints))
 
-	for _, ep := range ds.Spec.Endpoints {
+	for _, ep := range endpoints {
 		host, stringPort, err := net.SplitHostPort(ep)
 		if err != nil {
 			return nil, fmt.Errorf("cannot retrieve host-port pair from DataStore endpoints: %w", err)
@@ -106,11 +131,24 @@ func NewConnectionConfig(ctx context.Context, client client.Client, ds kamajiv1a
 		})
 	}
 
+	var parameters map[string][]string
+	if len(ds.Spec.Parameters) > 0 {
+		parameters = make(map[string][]string, len(ds.Spec.Parameters))
+
+		for k, v := range ds.Spec.Parameters {
+			parameters[k] = []string{v}
+		}
+	}
+
 	return &ConnectionConfig{
-		User:      user,
-		Password:  password,
-		Endpoints: eps,
-		TLSConfig: tlsConfig,
+		User:           user,
+		Password:       password,
+		Endpoints:      eps,
+		TLSConfig:      tlsConfig,
+		Parameters:     parameters,
+		NATSSigningKey: natsSigningKey,
+		NATSAccount:    natsAccount,
+		SharedDatabase: ds.PostgreSQLSharedDatabase(),
 	}, nil
 }
 
@@ -125,3 +163,16 @@ func (config ConnectionConfig) getDataSourceNameUserPassword() string {
 
 	return fmt.Sprintf("%s:%s@", config.User, config.Password)
 }
+
+// serverNameTLSConfig returns the TLS configuration verifying the server certificate against the host of the first endpoint,
+// unless an explicit server name is set: it's cloned, since the configuration is shared with the connection pool.
+func (config ConnectionConfig) serverNameTLSConfig() *tls.Config {
+	if config.TLSConfig == nil || config.TLSConfig.ServerName != "" {
+		return config.TLSConfig
+	}
+
+	tlsConfig := config.TLSConfig.Clone()
+	tlsConfig.ServerName = config.Endpoints[0].Host
+
+	return tlsConfig
+}
@@ -9,6 +9,14 @@ func NewUpdateUserError(err error) error {
 	return fmt.Errorf("cannot update user: %w", err)
 }
 
+func NewDiscardPreviousPasswordError(err error) error {
+	return fmt.Errorf("cannot discard previous password: %w", err)
+}
+
+func NewSetUserLimitsError(err error) error {
+	return fmt.Errorf("cannot set user limits: %w", err)
+}
+
 func NewCreateUserError(err error) error {
 	return fmt.Errorf("cannot create user: %w", err)
 }
@@ -52,3 +60,15 @@ func NewCheckConnectionError(err error) error {
 func NewCreateDBError(err error) error {
 	return fmt.Errorf("cannot create database: %w", err)
 }
+
+func NewRetrieveUsageError(err error) error {
+	return fmt.Errorf("cannot retrieve usage: %w", err)
+}
+
+func NewListDatabasesError(err error) error {
+	return fmt.Errorf("cannot list databases: %w", err)
+}
+
+func NewListUsersError(err error) error {
+	return fmt.Errorf("cannot list users: %w", err)
+}
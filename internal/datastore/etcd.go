@@ -6,6 +6,9 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"go.etcd.io/etcd/api/v3/authpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
@@ -15,6 +18,13 @@ import (
 	dserrors "github.com/clastix/kamaji/internal/datastore/errors"
 )
 
+const (
+	// etcdRootName is the reserved user and role of etcd, used by Kamaji.
+	etcdRootName = "root"
+	// etcdProgressRequestInterval is the amount of time to wait between two progress requests of the replication watch.
+	etcdProgressRequestInterval = 500 * time.Millisecond
+)
+
 func NewETCDConnection(config ConnectionConfig) (Connection, error) {
 	endpoints := make([]string, 0, len(config.Endpoints))
 
@@ -49,10 +59,26 @@ func (e *EtcdClient) CreateUser(ctx context.Context, user, password string) erro
 	return nil
 }
 
+func (e *EtcdClient) CreateCertificateUser(ctx context.Context, user string) error {
+	return e.CreateUser(ctx, user, "")
+}
+
 func (e *EtcdClient) UpdateUser(ctx context.Context, user, password string) error {
 	return nil
 }
 
+func (e *EtcdClient) RotateUser(context.Context, string, string, string) (bool, error) {
+	return false, nil
+}
+
+func (e *EtcdClient) DiscardPreviousPassword(context.Context, string, string) error {
+	return nil
+}
+
+func (e *EtcdClient) SetUserLimits(context.Context, string, UserLimits) error {
+	return nil
+}
+
 func (e *EtcdClient) CreateDB(context.Context, string) error {
 	return nil
 }
@@ -137,10 +163,72 @@ func (e *EtcdClient) DeleteDB(ctx context.Context, dbName string) error {
 	if _, err := e.Client.Delete(ctx, prefix, etcdclient.WithPrefix()); err != nil {
 		return dserrors.NewCannotDeleteDatabaseError(err)
 	}
+	// The role granting the access to the prefix is usually revoked beforehand,
+	// although it could be left behind by an interrupted clean-up.
+	if _, err := e.Client.Auth.RoleDelete(ctx, dbName); err != nil && rpctypes.Error(err) != rpctypes.ErrRoleNotFound { //nolint:errorlint
+		return dserrors.NewCannotDeleteDatabaseError(err)
+	}
 
 	return nil
 }
 
+func (e *EtcdClient) ListDatabases(ctx context.Context) ([]string, error) {
+	var names []string
+	// Skipping from a tenant prefix to the next one, rather than retrieving all the keys.
+	for cursor, end := "/", etcdclient.GetPrefixRangeEnd("/"); ; {
+		response, err := e.Client.Get(ctx, cursor, etcdclient.WithRange(end), etcdclient.WithKeysOnly(), etcdclient.WithLimit(1))
+		if err != nil {
+			return nil, dserrors.NewListDatabasesError(err)
+		}
+
+		if len(response.Kvs) == 0 {
+			break
+		}
+
+		key := string(response.Kvs[0].Key)
+
+		name, _, found := strings.Cut(strings.TrimPrefix(key, "/"), "/")
+		if !found || name == "" {
+			cursor = key + "\x00"
+
+			continue
+		}
+
+		names = append(names, name)
+		cursor = etcdclient.GetPrefixRangeEnd(e.buildKey(name))
+	}
+
+	roles, err := e.Client.Auth.RoleList(ctx)
+	if err != nil {
+		return nil, dserrors.NewListDatabasesError(err)
+	}
+
+	for _, role := range roles.Roles {
+		if role != etcdRootName && !slices.Contains(names, role) {
+			names = append(names, role)
+		}
+	}
+
+	return names, nil
+}
+
+func (e *EtcdClient) ListUsers(ctx context.Context) ([]string, error) {
+	response, err := e.Client.Auth.UserList(ctx)
+	if err != nil {
+		return nil, dserrors.NewListUsersError(err)
+	}
+
+	users := make([]string, 0, len(response.Users))
+
+	for _, user := range response.Users {
+		if user != etcdRootName {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
 func (e *EtcdClient) RevokePrivileges(ctx context.Context, _, dbName string) error {
 	if _, err := e.Client.Auth.RoleDelete(ctx, dbName); err != nil {
 		return dserrors.NewRevokePrivilegesError(err)
@@ -186,23 +274,199 @@ func (e *EtcdClient) buildKey(key string) string {
 	return fmt.Sprintf("/%s/", key)
 }
 
-func (e *EtcdClient) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
-	targetClient := target.(*EtcdClient) //nolint:forcetypeassert
+func (e *EtcdClient) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string) error {
+	targetClient := unwrap(target).(*EtcdClient) //nolint:forcetypeassert
 
 	if err := target.Check(ctx); err != nil {
 		return err
 	}
 
-	response, err := e.Client.Get(ctx, e.buildKey(tcp.Status.Storage.Setup.Schema), etcdclient.WithPrefix())
+	prefix, targetPrefix := e.buildKey(tcp.Status.Storage.Setup.Schema), e.buildKey(targetSchema)
+
+	response, err := e.Client.Get(ctx, prefix, etcdclient.WithPrefix())
 	if err != nil {
 		return err
 	}
 
 	for _, kv := range response.Kvs {
-		if _, err = targetClient.Client.Put(ctx, string(kv.Key), string(kv.Value)); err != nil {
+		if _, err = targetClient.Client.Put(ctx, targetPrefix+strings.TrimPrefix(string(kv.Key), prefix), string(kv.Value)); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+func (e *EtcdClient) Checkpoint(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (int64, error) {
+	response, err := e.Client.Get(ctx, e.buildKey(tcp.Status.Storage.Setup.Schema), etcdclient.WithPrefix(), etcdclient.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+
+	return response.Header.Revision, nil
+}
+
+func (e *EtcdClient) Replicate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string, fromRevision int64) (int64, int, error) {
+	targetClient := unwrap(target).(*EtcdClient) //nolint:forcetypeassert
+
+	prefix, targetPrefix := e.buildKey(tcp.Status.Storage.Setup.Schema), e.buildKey(targetSchema)
+	// The watch is consumed up to the store revision at the beginning of the round.
+	checkpoint, err := e.Checkpoint(ctx, tcp)
+	if err != nil {
+		return fromRevision, 0, err
+	}
+
+	if checkpoint <= fromRevision {
+		return fromRevision, 0, nil
+	}
+
+	watchCtx, cancelFn := context.WithCancel(etcdclient.WithRequireLeader(ctx))
+	defer cancelFn()
+	// Unlike a range request, the watch returns the deleted keys too.
+	watchCh := e.Client.Watch(watchCtx, prefix, etcdclient.WithPrefix(), etcdclient.WithRev(fromRevision+1))
+	// The revision of the latest change of the tenant keyspace is unknown, since the store revision is shared
+	// with the other keyspaces: the progress notification reports the revision up to which the watch is synced.
+	if err = e.Client.RequestProgress(watchCtx); err != nil {
+		return fromRevision, 0, err
+	}
+
+	ticker := time.NewTicker(etcdProgressRequestInterval)
+	defer ticker.Stop()
+
+	revision, changes := fromRevision, 0
+
+	for revision < checkpoint {
+		select {
+		case <-ctx.Done():
+			return revision, changes, ctx.Err()
+		case <-ticker.C:
+			// The progress notification is sent only once the watch is synced, it must be requested again.
+			if err = e.Client.RequestProgress(watchCtx); err != nil {
+				return revision, changes, err
+			}
+		case response, ok := <-watchCh:
+			if !ok {
+				return revision, changes, fmt.Errorf("the watch of the origin datastore has been closed")
+			}
+			// The compaction of a revision not yet replicated is returned as error.
+			if err = response.Err(); err != nil {
+				return revision, changes, err
+			}
+
+			if response.IsProgressNotify() {
+				revision = max(revision, response.Header.Revision)
+
+				continue
+			}
+
+			for _, event := range response.Events {
+				key := targetPrefix + strings.TrimPrefix(string(event.Kv.Key), prefix)
+
+				switch event.Type {
+				case etcdclient.EventTypeDelete:
+					_, err = targetClient.Client.Delete(ctx, key)
+				default:
+					_, err = targetClient.Client.Put(ctx, key, string(event.Kv.Value))
+				}
+
+				if err != nil {
+					return revision, changes, err
+				}
+
+				revision = event.Kv.ModRevision
+				changes++
+			}
+		}
+	}
+
+	return revision, changes, nil
+}
+
+func (e *EtcdClient) Export(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, fn func(kv KeyValue) error) error {
+	prefix := e.buildKey(tcp.Status.Storage.Setup.Schema)
+	rangeEnd := etcdclient.GetPrefixRangeEnd(prefix)
+
+	var revision int64
+
+	for key := prefix; ; {
+		opts := []etcdclient.OpOption{etcdclient.WithRange(rangeEnd), etcdclient.WithLimit(keyValueBatchSize)}
+		// Pinning the revision of the first page, ensuring a consistent snapshot of the keyspace.
+		if revision > 0 {
+			opts = append(opts, etcdclient.WithRev(revision))
+		}
+
+		response, err := e.Client.Get(ctx, key, opts...)
+		if err != nil {
+			return err
+		}
+
+		revision = response.Header.Revision
+
+		for _, kv := range response.Kvs {
+			if err = fn(KeyValue{Key: "/" + strings.TrimPrefix(string(kv.Key), prefix), Value: kv.Value}); err != nil {
+				return err
+			}
+		}
+
+		if !response.More || len(response.Kvs) == 0 {
+			return nil
+		}
+
+		key = string(response.Kvs[len(response.Kvs)-1].Key) + "\x00"
+	}
+}
+
+func (e *EtcdClient) Truncate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error {
+	_, err := e.Client.Delete(ctx, e.buildKey(tcp.Status.Storage.Setup.Schema), etcdclient.WithPrefix())
+
+	return err
+}
+
+func (e *EtcdClient) Import(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs []KeyValue) error {
+	prefix := e.buildKey(tcp.Status.Storage.Setup.Schema)
+
+	for _, kv := range kvs {
+		if _, err := e.Client.Put(ctx, prefix+strings.TrimPrefix(kv.Key, "/"), string(kv.Value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *EtcdClient) Usage(ctx context.Context, dbName string) (Usage, error) {
+	prefix := e.buildKey(dbName)
+	rangeEnd := etcdclient.GetPrefixRangeEnd(prefix)
+	// etcd doesn't provide the size of a key range: the values must be walked, paginating the requests.
+	var usage Usage
+
+	var revision int64
+
+	for key := prefix; ; {
+		opts := []etcdclient.OpOption{etcdclient.WithRange(rangeEnd), etcdclient.WithLimit(keyValueBatchSize)}
+		if revision > 0 {
+			opts = append(opts, etcdclient.WithRev(revision))
+		}
+
+		response, err := e.Client.Get(ctx, key, opts...)
+		if err != nil {
+			return Usage{}, dserrors.NewRetrieveUsageError(err)
+		}
+
+		revision = response.Header.Revision
+
+		for _, kv := range response.Kvs {
+			usage.Keys++
+			usage.Bytes += int64(len(kv.Key) + len(kv.Value))
+		}
+
+		if !response.More || len(response.Kvs) == 0 {
+			// The range holds the latest revision of the existing keys only.
+			usage.LiveBytes = usage.Bytes
+
+			return usage, nil
+		}
+
+		key = string(response.Kvs[len(response.Kvs)-1].Key) + "\x00"
+	}
+}
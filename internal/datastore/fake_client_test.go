@@ -0,0 +1,39 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// newFakeClient returns a fake client with the client-go and Kamaji schemes, serving the given objects:
+// the DataStore field indexes of the Tenant Control Planes are registered as by the manager.
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed adding client-go scheme: %v", err)
+	}
+
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed adding kamaji scheme: %v", err)
+	}
+
+	used, assigned := &kamajiv1alpha1.TenantControlPlaneStatusDataStore{}, &kamajiv1alpha1.TenantControlPlaneSpecDataStore{}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(used.Object(), used.Field(), used.ExtractValue()).
+		WithIndex(assigned.Object(), assigned.Field(), assigned.ExtractValue()).
+		Build()
+}
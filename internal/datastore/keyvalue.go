@@ -0,0 +1,93 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	// kineStoragePrefix is the default Kubernetes storage prefix, used by the API Server when backed by kine:
+	// etcd DataStores rely on the Tenant Control Plane schema as prefix instead.
+	kineStoragePrefix = "/registry"
+	// keyValueBatchSize limits the number of key-values kept in memory and written in a single round
+	// by the driver-neutral migration.
+	keyValueBatchSize = 500
+)
+
+// KeyValue is the driver-neutral representation of a Kubernetes object stored in a DataStore:
+// the key is relative to the API Server storage prefix (e.g.: /pods/default/nginx), and the value
+// is the latest revision of the object, as serialized by the API Server.
+type KeyValue struct {
+	Key   string
+	Value []byte
+}
+
+// MigrateKeyValues copies the tenant keyspace between DataStores backed by different drivers,
+// relying on the Export and Import functions: revision history and leases are not retained,
+// and the target keyspace is truncated beforehand.
+func MigrateKeyValues(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, origin, target Connection) error {
+	return copyKeyValues(ctx, tcp, tcp, origin, target)
+}
+
+// CloneKeyValues copies the keyspace of the source Tenant Control Plane into the clone one, relying on the Export
+// and Import functions: unlike LiveClone, it works across different drivers, although the copy is a consistent
+// snapshot of the source keyspace only with the etcd driver.
+func CloneKeyValues(ctx context.Context, source, clone kamajiv1alpha1.TenantControlPlane, origin, target Connection) error {
+	return copyKeyValues(ctx, source, clone, origin, target)
+}
+
+func copyKeyValues(ctx context.Context, originTCP, targetTCP kamajiv1alpha1.TenantControlPlane, origin, target Connection) error {
+	if err := target.Check(ctx); err != nil {
+		return fmt.Errorf("unable to check target datastore: %w", err)
+	}
+
+	if err := target.Truncate(ctx, targetTCP); err != nil {
+		return fmt.Errorf("unable to truncate target keyspace: %w", err)
+	}
+
+	batch := make([]KeyValue, 0, keyValueBatchSize)
+
+	if err := origin.Export(ctx, originTCP, func(kv KeyValue) error {
+		batch = append(batch, kv)
+
+		if len(batch) < keyValueBatchSize {
+			return nil
+		}
+
+		if err := target.Import(ctx, targetTCP, batch); err != nil {
+			return err
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("unable to copy keyspace from %s to %s: %w", origin.Driver(), target.Driver(), err)
+	}
+
+	if err := target.Import(ctx, targetTCP, batch); err != nil {
+		return fmt.Errorf("unable to copy keyspace from %s to %s: %w", origin.Driver(), target.Driver(), err)
+	}
+
+	return nil
+}
+
+// fromKineKey converts a key stored by kine to its driver-neutral representation,
+// returning false for the kine internal keys, such as the compaction one.
+func fromKineKey(name string) (string, bool) {
+	if !strings.HasPrefix(name, kineStoragePrefix+"/") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(name, kineStoragePrefix), true
+}
+
+func toKineKey(key string) string {
+	return kineStoragePrefix + key
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// fakeKeyValueStore is an in-memory DataStore exposing the driver-neutral export and import.
+type fakeKeyValueStore struct {
+	Connection
+
+	keys    []KeyValue
+	imports int
+}
+
+func (f *fakeKeyValueStore) Check(context.Context) error {
+	return nil
+}
+
+func (f *fakeKeyValueStore) Driver() string {
+	return "fake"
+}
+
+func (f *fakeKeyValueStore) Export(_ context.Context, _ kamajiv1alpha1.TenantControlPlane, fn func(kv KeyValue) error) error {
+	for _, kv := range f.keys {
+		if err := fn(kv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeKeyValueStore) Truncate(context.Context, kamajiv1alpha1.TenantControlPlane) error {
+	f.keys = nil
+
+	return nil
+}
+
+func (f *fakeKeyValueStore) Import(_ context.Context, _ kamajiv1alpha1.TenantControlPlane, kvs []KeyValue) error {
+	f.imports++
+	f.keys = append(f.keys, kvs...)
+
+	return nil
+}
+
+func TestMigrateKeyValues(t *testing.T) {
+	origin, target := &fakeKeyValueStore{}, &fakeKeyValueStore{}
+	// The key left behind by a prior migration must not survive the copy.
+	target.keys = []KeyValue{{Key: "/configmaps/default/stale", Value: []byte("v")}}
+
+	for i := range keyValueBatchSize + 1 {
+		origin.keys = append(origin.keys, KeyValue{Key: fmt.Sprintf("/configmaps/default/cm-%d", i), Value: []byte("v")})
+	}
+
+	if err := MigrateKeyValues(context.Background(), kamajiv1alpha1.TenantControlPlane{}, origin, target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(target.keys) != len(origin.keys) || target.keys[0].Key != origin.keys[0].Key {
+		t.Fatalf("migrated %d keys, expected %d", len(target.keys), len(origin.keys))
+	}
+
+	if target.imports != 2 {
+		t.Fatalf("performed %d imports, expected 2", target.imports)
+	}
+}
+
+func TestKineKeyConversion(t *testing.T) {
+	key, ok := fromKineKey("/registry/pods/default/nginx")
+	if !ok || key != "/pods/default/nginx" {
+		t.Fatalf("unexpected conversion: %q, %t", key, ok)
+	}
+
+	if toKineKey(key) != "/registry/pods/default/nginx" {
+		t.Fatalf("unexpected conversion: %q", toKineKey(key))
+	}
+	// kine internal keys must not be exported.
+	if _, ok = fromKineKey("compact_rev_key"); ok {
+		t.Fatal("compaction key is expected to be skipped")
+	}
+}
+
+func TestNATSKineKeyEncoding(t *testing.T) {
+	const key = "/registry/services/endpoints/kube-system/kube-dns.v1"
+
+	encoded := encodeNATSKineKey(key)
+
+	decoded, err := decodeNATSKineKey(encoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded != key {
+		t.Fatalf("decoded key is %q, expected %q", decoded, key)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// kineReplicationBatchSize limits the number of kine rows replicated in a single round,
+	// bounding the memory used by a live migration of a tenant with a high write rate.
+	kineReplicationBatchSize = 1000
+	// kineReplicationGapTimeout is the amount of time a missing row ID is waited for before being skipped,
+	// assuming it belongs to a rolled back transaction, as kine does when polling its own log.
+	kineReplicationGapTimeout = 5 * time.Second
+	// kineReplicationGapPollInterval is the amount of time to wait between two reads of a missing row ID.
+	kineReplicationGapPollInterval = 100 * time.Millisecond
+)
+
+// kineRow is the representation of a row of the kine table, shared by the SQL drivers:
+// the row ID is the revision of the change, since kine stores the history of the keyspace
+// as an append-only log, where deletions are tracked as rows with the deleted flag.
+type kineRow struct {
+	ID             int64
+	Name           string
+	Created        int64
+	Deleted        int64
+	CreateRevision int64
+	PrevRevision   int64
+	Lease          int64
+	Value          []byte
+	OldValue       []byte
+}
+
+// fetchKineChanges returns the kine rows following the given revision, stopping at the first missing row ID.
+// The IDs are allocated upon insert, whilst the rows become visible upon commit: a missing ID belongs to
+// a transaction still open on the origin, whose row would never be replicated once the cursor moves past it.
+// The rows are read again until the gap is filled, or skipped once the gap timeout is elapsed.
+func fetchKineChanges(ctx context.Context, fromRevision int64, fetchFn func(ctx context.Context, fromRevision int64) ([]kineRow, error)) ([]kineRow, error) {
+	deadline := time.Now().Add(kineReplicationGapTimeout)
+
+	for {
+		rows, err := fetchFn(ctx, fromRevision)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		// The rows preceding the first gap can be replicated, the next round starts from the gap.
+		if contiguous := contiguousKineRows(rows, fromRevision); contiguous > 0 {
+			return rows[:contiguous], nil
+		}
+		// The gap has not been filled in time: the transaction has been rolled back.
+		if time.Now().After(deadline) {
+			return rows[:contiguousKineRows(rows, rows[0].ID-1)], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(kineReplicationGapPollInterval):
+		}
+	}
+}
+
+// contiguousKineRows returns the number of rows whose IDs are following the given revision without gaps.
+func contiguousKineRows(rows []kineRow, fromRevision int64) int {
+	for i, row := range rows {
+		if row.ID != fromRevision+int64(i)+1 {
+			return i
+		}
+	}
+
+	return len(rows)
+}
@@ -6,6 +6,7 @@ package datastore
 import (
 	"context"
 	"database/sql"
+	goerrors "errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -22,6 +23,8 @@ import (
 const (
 	defaultProtocol = "tcp"
 	sqlErrorNoRows  = "sql: no rows in result set"
+	// mysqlParseErrorNumber is returned for the statements not supported by the server.
+	mysqlParseErrorNumber = 1064
 )
 
 const (
@@ -33,10 +36,25 @@ const (
 	mysqlCreateDBStatement         = "CREATE DATABASE IF NOT EXISTS %s"
 	mysqlCreateUserStatement       = "CREATE USER %s@`%%` IDENTIFIED BY '%s'"
 	mysqlUpdateUserStatement       = "ALTER USER %s@`%%` IDENTIFIED BY '%s'"
+	mysqlCreateCertUserStatement   = "CREATE USER %s@`%%` REQUIRE SUBJECT '%s'"
+	mysqlRotateUserStatement       = "ALTER USER %s@`%%` IDENTIFIED BY '%s' RETAIN CURRENT PASSWORD"
+	mysqlDiscardPasswordStatement  = "ALTER USER %s@`%%` DISCARD OLD PASSWORD"
+	mysqlUserLimitsStatement       = "ALTER USER %s@`%%` WITH MAX_USER_CONNECTIONS %d MAX_QUERIES_PER_HOUR %d"
 	mysqlGrantPrivilegesStatement  = "GRANT SELECT, INSERT, UPDATE, DELETE, CREATE, ALTER, INDEX ON %s.* TO %s@`%%`"
 	mysqlDropDBStatement           = "DROP DATABASE IF EXISTS %s"
 	mysqlDropUserStatement         = "DROP USER IF EXISTS %s"
 	mysqlRevokePrivilegesStatement = "REVOKE ALL PRIVILEGES ON %s.* FROM %s"
+	mysqlKineMaxRevisionStatement  = "SELECT COALESCE(MAX(id), 0) FROM %s.kine"
+	mysqlKineChangesStatement      = "SELECT id, name, created, deleted, create_revision, prev_revision, lease, value, old_value FROM %s.kine WHERE id > ? ORDER BY id ASC LIMIT ?"
+	mysqlKineInsertStatement       = "INSERT INTO %s.kine (id, name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE id = id"
+	mysqlKineLatestStatement       = "SELECT kv.name, kv.deleted, kv.value FROM %[1]s.kine AS kv JOIN (SELECT MAX(id) AS id FROM %[1]s.kine WHERE name > ? GROUP BY name ORDER BY name ASC LIMIT ?) AS latest ON latest.id = kv.id ORDER BY kv.name ASC"
+	mysqlKineCreateStatement       = "INSERT IGNORE INTO %s.kine (name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES (?, 1, 0, 0, 0, 0, ?, NULL)"
+	mysqlKineTruncateStatement     = "TRUNCATE TABLE %s.kine"
+	mysqlListDBStatement           = "SELECT DISTINCT TABLE_SCHEMA FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_NAME = 'kine'"
+	mysqlListUsersStatement        = "SELECT User FROM mysql.user WHERE Host = '%' AND User <> ?"
+	mysqlSchemaUsageStatement      = "SELECT COALESCE(SUM(DATA_LENGTH + INDEX_LENGTH), 0), COALESCE(SUM(TABLE_ROWS), 0) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ?"
+	mysqlKineTableExistsStatement  = "SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = 'kine'"
+	mysqlKineLiveBytesStatement    = "SELECT COALESCE(SUM(OCTET_LENGTH(kv.name) + COALESCE(OCTET_LENGTH(kv.value), 0)), 0) FROM %[1]s.kine AS kv JOIN (SELECT MAX(id) AS id FROM %[1]s.kine GROUP BY name) AS latest ON latest.id = kv.id WHERE kv.deleted = 0 AND kv.name <> 'compact_rev_key'"
 	mysqlCheckGrantsStatement      = `
 		SELECT 1
 		FROM mysql.db
@@ -49,6 +67,24 @@ const (
 		  AND Alter_priv = 'Y'
 		  AND Index_priv = 'Y'
 	`
+	// mysqlKineSchemaStatement creates the kine table, along with its indexes, if missing.
+	mysqlKineSchemaStatement = `CREATE TABLE IF NOT EXISTS %s.kine (
+		id BIGINT UNSIGNED AUTO_INCREMENT,
+		name VARCHAR(630) CHARACTER SET ascii,
+		created INTEGER,
+		deleted INTEGER,
+		create_revision BIGINT UNSIGNED,
+		prev_revision BIGINT UNSIGNED,
+		lease INTEGER,
+		value MEDIUMBLOB,
+		old_value MEDIUMBLOB,
+		PRIMARY KEY (id),
+		INDEX kine_name_index (name),
+		INDEX kine_name_id_index (name, id),
+		INDEX kine_id_deleted_index (id, deleted),
+		INDEX kine_prev_revision_index (prev_revision),
+		UNIQUE INDEX kine_name_prev_revision_uindex (name, prev_revision)
+	)`
 )
 
 type MySQLConnection struct {
@@ -57,14 +93,14 @@ type MySQLConnection struct {
 	connector ConnectionEndpoint
 }
 
-func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
+func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string) error {
 	// Ensuring the connection is working as expected
 	if err := target.Check(ctx); err != nil {
 		return err
 	}
 	// Creating the target schema if it doesn't exist
-	if ok, _ := target.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
-		if err := target.CreateDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+	if ok, _ := target.DBExists(ctx, targetSchema); !ok {
+		if err := target.CreateDB(ctx, targetSchema); err != nil {
 			return err
 		}
 	}
@@ -95,7 +131,7 @@ func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.Tenant
 		return fmt.Errorf("cannot read dump file for MySQL: %w", err)
 	}
 	// Executing the import to the target datastore
-	targetClient := target.(*MySQLConnection) //nolint:forcetypeassert
+	targetClient := unwrap(target).(*MySQLConnection) //nolint:forcetypeassert
 
 	// The dump is a batch of semicolon-separated statements, so it must run over
 	// a connection with multiStatements enabled. That connection is scoped to
@@ -107,7 +143,8 @@ func (c *MySQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.Tenant
 	}
 	defer importDB.Close()
 
-	if _, err = importDB.ExecContext(ctx, fmt.Sprintf("USE %s", quoteMySQLIdentifier(tcp.Status.Storage.Setup.Schema))); err != nil {
+	// The dump statements are not qualified by the schema name, allowing to import them in a different one.
+	if _, err = importDB.ExecContext(ctx, fmt.Sprintf("USE %s", quoteMySQLIdentifier(targetSchema))); err != nil {
 		return fmt.Errorf("unable to switch DB for MySQL migration: %w", err)
 	}
 
@@ -154,6 +191,18 @@ func NewMySQLConnection(config ConnectionConfig) (Connection, error) {
 		return nil, err
 	}
 
+	if config.Pool.MaxOpenConnections > 0 {
+		db.SetMaxOpenConns(config.Pool.MaxOpenConnections)
+	}
+
+	if config.Pool.MaxIdleConnections > 0 {
+		db.SetMaxIdleConns(config.Pool.MaxIdleConnections)
+	}
+
+	if config.Pool.IdleTimeout > 0 {
+		db.SetConnMaxIdleTime(config.Pool.IdleTimeout)
+	}
+
 	return &MySQLConnection{db: db, config: mysqlConfig, connector: config.Endpoints[0]}, nil
 }
 
@@ -181,6 +230,51 @@ func (c *MySQLConnection) multiStatementConn() (*sql.DB, error) {
 	return db, nil
 }
 
+func (c *MySQLConnection) ListDatabases(ctx context.Context) ([]string, error) {
+	names, err := c.listNames(ctx, mysqlListDBStatement)
+	if err != nil {
+		return nil, errors.NewListDatabasesError(err)
+	}
+
+	return names, nil
+}
+
+func (c *MySQLConnection) ListUsers(ctx context.Context) ([]string, error) {
+	names, err := c.listNames(ctx, mysqlListUsersStatement, c.config.User)
+	if err != nil {
+		return nil, errors.NewListUsersError(err)
+	}
+
+	return names, nil
+}
+
+func (c *MySQLConnection) listNames(ctx context.Context, statement string, args ...any) ([]string, error) {
+	result, err := c.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var names []string
+
+	for result.Next() {
+		var name string
+		if err = result.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	return names, result.Err()
+}
+
+func (c *MySQLConnection) PoolStats() PoolStats {
+	stats := c.db.Stats()
+
+	return PoolStats{InUse: stats.InUse, Idle: stats.Idle}
+}
+
 func (c *MySQLConnection) GetConnectionString() string {
 	return c.connector.String()
 }
@@ -209,6 +303,16 @@ func (c *MySQLConnection) CreateUser(ctx context.Context, user, password string)
 	return nil
 }
 
+// CreateCertificateUser requires the client certificate subject to be made of the user Common Name only,
+// as formatted by OpenSSL, along with being signed by one of the Certificate Authorities trusted by the server.
+func (c *MySQLConnection) CreateCertificateUser(ctx context.Context, user string) error {
+	if err := c.mutate(ctx, mysqlCreateCertUserStatement, quoteMySQLIdentifier(user), escapeMySQLString("/CN="+user)); err != nil {
+		return errors.NewCreateUserError(err)
+	}
+
+	return nil
+}
+
 func (c *MySQLConnection) UpdateUser(ctx context.Context, user, password string) error {
 	if err := c.mutate(ctx, mysqlUpdateUserStatement, quoteMySQLIdentifier(user), escapeMySQLString(password)); err != nil {
 		return errors.NewUpdateUserError(err)
@@ -217,6 +321,41 @@ func (c *MySQLConnection) UpdateUser(ctx context.Context, user, password string)
 	return nil
 }
 
+// SetUserLimits ignores the statement timeout, since MySQL supports it only for the read-only statements.
+func (c *MySQLConnection) SetUserLimits(ctx context.Context, user string, limits UserLimits) error {
+	if err := c.mutate(ctx, mysqlUserLimitsStatement, quoteMySQLIdentifier(user), limits.MaxConnections, limits.MaxQueriesPerHour); err != nil {
+		return errors.NewSetUserLimitsError(err)
+	}
+
+	return nil
+}
+
+// RotateUser relies on the MySQL dual password support, available since 8.0.14:
+// MariaDB, and older MySQL versions, don't support it, thus the current password is replaced.
+// The user is the login one as well, since the credentials don't alternate across login users.
+func (c *MySQLConnection) RotateUser(ctx context.Context, user, _, password string) (bool, error) {
+	err := c.mutate(ctx, mysqlRotateUserStatement, quoteMySQLIdentifier(user), escapeMySQLString(password))
+
+	var mysqlErr *mysql.MySQLError
+	if goerrors.As(err, &mysqlErr) && mysqlErr.Number == mysqlParseErrorNumber {
+		return false, c.UpdateUser(ctx, user, password)
+	}
+
+	if err != nil {
+		return false, errors.NewUpdateUserError(err)
+	}
+
+	return true, nil
+}
+
+func (c *MySQLConnection) DiscardPreviousPassword(ctx context.Context, user, _ string) error {
+	if err := c.mutate(ctx, mysqlDiscardPasswordStatement, quoteMySQLIdentifier(user)); err != nil {
+		return errors.NewDiscardPreviousPasswordError(err)
+	}
+
+	return nil
+}
+
 func (c *MySQLConnection) CreateDB(ctx context.Context, dbName string) error {
 	if err := c.mutate(ctx, mysqlCreateDBStatement, quoteMySQLIdentifier(dbName)); err != nil {
 		return errors.NewCreateDBError(err)
@@ -386,3 +525,198 @@ func escapeMySQLString(value string) string {
 
 	return b.String()
 }
+
+func (c *MySQLConnection) Checkpoint(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (int64, error) {
+	var revision int64
+
+	if err := c.db.QueryRowContext(ctx, fmt.Sprintf(mysqlKineMaxRevisionStatement, quoteMySQLIdentifier(tcp.Status.Storage.Setup.Schema))).Scan(&revision); err != nil {
+		return 0, err
+	}
+
+	return revision, nil
+}
+
+func (c *MySQLConnection) Replicate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string, fromRevision int64) (int64, int, error) {
+	statement := fmt.Sprintf(mysqlKineChangesStatement, quoteMySQLIdentifier(tcp.Status.Storage.Setup.Schema))
+
+	rows, err := fetchKineChanges(ctx, fromRevision, func(ctx context.Context, fromRevision int64) ([]kineRow, error) {
+		return c.kineChanges(ctx, statement, fromRevision)
+	})
+	if err != nil {
+		return fromRevision, 0, fmt.Errorf("unable to retrieve changes from the origin datastore: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return fromRevision, 0, nil
+	}
+
+	targetClient := unwrap(target).(*MySQLConnection) //nolint:forcetypeassert
+
+	tx, err := targetClient.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fromRevision, 0, fmt.Errorf("unable to start transaction on the target datastore: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// The rows written during the bulk copy are both dumped and replicated, being past the checkpoint:
+	// the ones already available on the target are skipped.
+	for _, row := range rows {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(mysqlKineInsertStatement, quoteMySQLIdentifier(targetSchema)), row.ID, row.Name, row.Created, row.Deleted, row.CreateRevision, row.PrevRevision, row.Lease, row.Value, row.OldValue); err != nil {
+			return fromRevision, 0, fmt.Errorf("unable to apply changes to the target datastore: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fromRevision, 0, fmt.Errorf("unable to commit changes to the target datastore: %w", err)
+	}
+
+	return rows[len(rows)-1].ID, len(rows), nil
+}
+
+func (c *MySQLConnection) kineChanges(ctx context.Context, statement string, fromRevision int64) ([]kineRow, error) {
+	result, err := c.db.QueryContext(ctx, statement, fromRevision, kineReplicationBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var rows []kineRow
+
+	for result.Next() {
+		var row kineRow
+
+		if err = result.Scan(&row.ID, &row.Name, &row.Created, &row.Deleted, &row.CreateRevision, &row.PrevRevision, &row.Lease, &row.Value, &row.OldValue); err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, result.Err()
+}
+
+func (c *MySQLConnection) Export(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, fn func(kv KeyValue) error) error {
+	statement := fmt.Sprintf(mysqlKineLatestStatement, quoteMySQLIdentifier(tcp.Status.Storage.Setup.Schema))
+
+	for cursor := ""; ; {
+		rows, err := c.latestKineRows(ctx, statement, cursor)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve keys from the datastore: %w", err)
+		}
+
+		for _, row := range rows {
+			key, ok := fromKineKey(row.Name)
+			if !ok || row.Deleted != 0 {
+				continue
+			}
+
+			if err = fn(KeyValue{Key: key, Value: row.Value}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < keyValueBatchSize {
+			return nil
+		}
+
+		cursor = rows[len(rows)-1].Name
+	}
+}
+
+func (c *MySQLConnection) latestKineRows(ctx context.Context, statement, cursor string) ([]kineRow, error) {
+	result, err := c.db.QueryContext(ctx, statement, cursor, keyValueBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var rows []kineRow
+
+	for result.Next() {
+		var row kineRow
+
+		if err = result.Scan(&row.Name, &row.Deleted, &row.Value); err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, result.Err()
+}
+
+func (c *MySQLConnection) Truncate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error {
+	if ok, _ := c.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
+		return nil
+	}
+
+	schema := quoteMySQLIdentifier(tcp.Status.Storage.Setup.Schema)
+
+	if _, err := c.db.ExecContext(ctx, fmt.Sprintf(mysqlKineSchemaStatement, schema)); err != nil {
+		return fmt.Errorf("unable to perform schema creation: %w", err)
+	}
+
+	if _, err := c.db.ExecContext(ctx, fmt.Sprintf(mysqlKineTruncateStatement, schema)); err != nil {
+		return fmt.Errorf("unable to truncate the kine table: %w", err)
+	}
+
+	return nil
+}
+
+func (c *MySQLConnection) Import(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs []KeyValue) error {
+	schema := quoteMySQLIdentifier(tcp.Status.Storage.Setup.Schema)
+
+	if ok, _ := c.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
+		if err := c.CreateDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.db.ExecContext(ctx, fmt.Sprintf(mysqlKineSchemaStatement, schema)); err != nil {
+		return fmt.Errorf("unable to perform schema creation: %w", err)
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to start import transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, kv := range kvs {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(mysqlKineCreateStatement, schema), toKineKey(kv.Key), kv.Value); err != nil {
+			return fmt.Errorf("unable to import key %s: %w", kv.Key, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Usage relies on the INFORMATION_SCHEMA statistics: with InnoDB, the number of rows is an estimation.
+// The live bytes are summed over the latest revision of the kine keys instead, since the table size doesn't shrink upon deletions.
+func (c *MySQLConnection) Usage(ctx context.Context, dbName string) (Usage, error) {
+	var usage Usage
+
+	if err := c.db.QueryRowContext(ctx, mysqlSchemaUsageStatement, dbName).Scan(&usage.Bytes, &usage.Keys); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+	// The kine table is created by the kine process upon its first start.
+	var tables int
+
+	if err := c.db.QueryRowContext(ctx, mysqlKineTableExistsStatement, dbName).Scan(&tables); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	if tables == 0 {
+		return usage, nil
+	}
+
+	if err := c.db.QueryRowContext(ctx, fmt.Sprintf(mysqlKineLiveBytesStatement, quoteMySQLIdentifier(dbName))).Scan(&usage.LiveBytes); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	return usage, nil
+}
@@ -5,6 +5,8 @@ package datastore
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -45,7 +47,16 @@ func NewNATSConnection(config ConnectionConfig) (*NATSConnection, error) {
 		natsOpts = append(natsOpts, nats.Secure(config.TLSConfig))
 	}
 
-	if config.User != "" && config.Password != "" {
+	switch {
+	case len(config.NATSSigningKey) > 0:
+		// With the decentralized JWT authentication, Kamaji issues its own user from the account.
+		token, seed, credsErr := natsAdminCredentials(config.NATSSigningKey, config.NATSAccount)
+		if credsErr != nil {
+			return nil, credsErr
+		}
+
+		natsOpts = append(natsOpts, nats.UserJWTAndSeed(token, seed))
+	case config.User != "" && config.Password != "":
 		natsOpts = append(natsOpts, nats.UserInfo(config.User, config.Password))
 	}
 
@@ -66,14 +77,37 @@ func NewNATSConnection(config ConnectionConfig) (*NATSConnection, error) {
 	}, nil
 }
 
+// CreateUser, and the other user related functions, are no-op: users are JWTs issued by the NATS account,
+// with permissions scoped to the tenant KV bucket, thus they're not stored by the server.
+// Without a NATS account, the tenant shares the DataStore credentials.
+//
+// The users cannot be deleted, nor their privileges revoked: it would require updating the account JWT,
+// which is signed by the operator. The user JWTs are rather issued with a bounded validity,
+// renewed by the Tenant Control Plane reconciliation, thus expiring once the tenant has been deleted.
 func (nc *NATSConnection) CreateUser(_ context.Context, _, _ string) error {
 	return nil
 }
 
+func (nc *NATSConnection) CreateCertificateUser(_ context.Context, _ string) error {
+	return nil
+}
+
 func (nc *NATSConnection) UpdateUser(_ context.Context, _, _ string) error {
 	return nil
 }
 
+func (nc *NATSConnection) RotateUser(_ context.Context, _, _, _ string) (bool, error) {
+	return false, nil
+}
+
+func (nc *NATSConnection) DiscardPreviousPassword(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (nc *NATSConnection) SetUserLimits(context.Context, string, UserLimits) error {
+	return nil
+}
+
 func (nc *NATSConnection) CreateDB(_ context.Context, dbName string) error {
 	_, err := nc.js.CreateKeyValue(&nats.KeyValueConfig{Bucket: dbName})
 	if err != nil {
@@ -118,6 +152,21 @@ func (nc *NATSConnection) DeleteDB(_ context.Context, dbName string) error {
 	return err
 }
 
+func (nc *NATSConnection) ListDatabases(_ context.Context) ([]string, error) {
+	var names []string
+
+	for name := range nc.js.KeyValueStoreNames() {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// ListUsers returns no users, since the NATS tenant users are either the DataStore ones, or issued by the account.
+func (nc *NATSConnection) ListUsers(_ context.Context) ([]string, error) {
+	return nil, nil
+}
+
 func (nc *NATSConnection) RevokePrivileges(_ context.Context, _, _ string) error {
 	return nil
 }
@@ -148,11 +197,11 @@ func (nc *NATSConnection) GetConfig() ConnectionConfig {
 	return nc.config
 }
 
-func (nc *NATSConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
-	targetClient := target.(*NATSConnection) //nolint:forcetypeassert
+func (nc *NATSConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string) error {
+	targetClient := unwrap(target).(*NATSConnection) //nolint:forcetypeassert
 	dbName := tcp.Status.Storage.Setup.Schema
 
-	targetKv, err := targetClient.js.KeyValue(dbName)
+	targetKv, err := targetClient.js.KeyValue(targetSchema)
 	if err != nil {
 		return err
 	}
@@ -186,3 +235,254 @@ func (nc *NATSConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.Tenant
 
 	return nil
 }
+
+func (nc *NATSConnection) Checkpoint(_ context.Context, tcp kamajiv1alpha1.TenantControlPlane) (int64, error) {
+	info, err := nc.js.StreamInfo(natsKVStreamName(tcp.Status.Storage.Setup.Schema))
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(info.State.LastSeq), nil //nolint:gosec
+}
+
+func (nc *NATSConnection) Replicate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string, fromRevision int64) (int64, int, error) {
+	targetClient := unwrap(target).(*NATSConnection) //nolint:forcetypeassert
+	dbName := tcp.Status.Storage.Setup.Schema
+	stream := natsKVStreamName(dbName)
+
+	targetKv, err := targetClient.js.KeyValue(targetSchema)
+	if err != nil {
+		return fromRevision, 0, err
+	}
+
+	lastRevision, err := nc.Checkpoint(ctx, tcp)
+	if err != nil {
+		return fromRevision, 0, err
+	}
+
+	lastRevision = min(lastRevision, fromRevision+kineReplicationBatchSize)
+
+	var changes int
+
+	for seq := fromRevision + 1; seq <= lastRevision; seq++ {
+		msg, msgErr := nc.js.GetMsg(stream, uint64(seq), nats.Context(ctx)) //nolint:gosec
+		if msgErr != nil {
+			// Sequences could have been removed due to the bucket history settings:
+			// they're still accounted as changes to avoid reporting a false zero lag.
+			if errors.Is(msgErr, nats.ErrMsgNotFound) {
+				changes++
+
+				continue
+			}
+
+			return seq - 1, changes, msgErr
+		}
+
+		key := strings.TrimPrefix(msg.Subject, fmt.Sprintf("$KV.%s.", dbName))
+
+		switch msg.Header.Get("KV-Operation") {
+		case "DEL", "PURGE":
+			err = targetKv.Delete(key)
+			if err != nil && !errors.Is(err, nats.ErrKeyNotFound) {
+				return seq - 1, changes, err
+			}
+		default:
+			if _, err = targetKv.Put(key, msg.Data); err != nil {
+				return seq - 1, changes, err
+			}
+		}
+
+		changes++
+	}
+
+	return lastRevision, changes, nil
+}
+
+func natsKVStreamName(bucket string) string {
+	return fmt.Sprintf("KV_%s", bucket)
+}
+
+// natsKineValue mirrors the envelope used by the kine NATS backend to store the Kubernetes objects
+// in the KV bucket, where the value is wrapped along with its revision metadata.
+type natsKineValue struct {
+	KV           *natsKineKeyValue `json:"kv"`
+	PrevRevision int64             `json:"prevRevision"`
+	Create       bool              `json:"create,omitempty"`
+	Delete       bool              `json:"delete,omitempty"`
+}
+
+type natsKineKeyValue struct {
+	Key            string
+	CreateRevision int64
+	ModRevision    int64
+	Value          []byte
+	Lease          int64
+}
+
+// encodeNATSKineKey converts a kine key to the KV bucket one, as done by the kine NATS backend:
+// each token of the path is base64 encoded, since KV keys are dot-separated.
+func encodeNATSKineKey(key string) string {
+	parts := strings.Split(strings.Trim(key, "/"), "/")
+	for i, part := range parts {
+		parts[i] = base64.RawURLEncoding.EncodeToString([]byte(part))
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func decodeNATSKineKey(key string) (string, error) {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		decoded, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return "", err
+		}
+
+		parts[i] = string(decoded)
+	}
+
+	return "/" + strings.Join(parts, "/"), nil
+}
+
+func (nc *NATSConnection) Export(_ context.Context, tcp kamajiv1alpha1.TenantControlPlane, fn func(kv KeyValue) error) error {
+	kv, err := nc.js.KeyValue(tcp.Status.Storage.Setup.Schema)
+	if err != nil {
+		return err
+	}
+
+	keys, err := kv.Keys()
+	if err != nil {
+		if errors.Is(err, nats.ErrNoKeysFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, key := range keys {
+		name, decodeErr := decodeNATSKineKey(key)
+		if decodeErr != nil {
+			return fmt.Errorf("unable to decode key %s: %w", key, decodeErr)
+		}
+
+		neutralKey, ok := fromKineKey(name)
+		if !ok {
+			continue
+		}
+
+		entry, getErr := kv.Get(key)
+		if getErr != nil {
+			if errors.Is(getErr, nats.ErrKeyNotFound) {
+				continue
+			}
+
+			return getErr
+		}
+
+		var value natsKineValue
+		if err = json.Unmarshal(entry.Value(), &value); err != nil {
+			return fmt.Errorf("unable to decode value of key %s: %w", name, err)
+		}
+
+		if value.Delete || value.KV == nil {
+			continue
+		}
+
+		if err = fn(KeyValue{Key: neutralKey, Value: value.KV.Value}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Truncate purges the stream backing the key-value bucket, removing the history of the keys too.
+func (nc *NATSConnection) Truncate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error {
+	if ok, _ := nc.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
+		return nil
+	}
+
+	return nc.js.PurgeStream(natsKVStreamName(tcp.Status.Storage.Setup.Schema))
+}
+
+func (nc *NATSConnection) Import(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs []KeyValue) error {
+	if ok, _ := nc.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
+		if err := nc.CreateDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+			return err
+		}
+	}
+
+	kv, err := nc.js.KeyValue(tcp.Status.Storage.Setup.Schema)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range kvs {
+		name := toKineKey(item.Key)
+
+		value, marshalErr := json.Marshal(natsKineValue{
+			KV:     &natsKineKeyValue{Key: name, Value: item.Value},
+			Create: true,
+		})
+		if marshalErr != nil {
+			return marshalErr
+		}
+
+		if _, err = kv.Put(encodeNATSKineKey(name), value); err != nil {
+			return fmt.Errorf("unable to import key %s: %w", item.Key, err)
+		}
+	}
+
+	return nil
+}
+
+func (nc *NATSConnection) Usage(ctx context.Context, dbName string) (Usage, error) {
+	kv, err := nc.js.KeyValue(dbName)
+	if err != nil {
+		return Usage{}, fmt.Errorf("unable to retrieve the KV bucket: %w", err)
+	}
+
+	status, err := kv.Status()
+	if err != nil {
+		return Usage{}, fmt.Errorf("unable to retrieve the KV bucket status: %w", err)
+	}
+
+	liveBytes, err := natsLiveBytes(ctx, kv)
+	if err != nil {
+		return Usage{}, fmt.Errorf("unable to retrieve the KV bucket live bytes: %w", err)
+	}
+
+	return Usage{Bytes: int64(status.Bytes()), Keys: int64(status.Values()), LiveBytes: liveBytes}, nil //nolint:gosec
+}
+
+// natsLiveBytes sums the size of the latest value of the bucket keys, skipping the ones deleted by kine:
+// the watcher delivers the latest value of each key, followed by a nil entry.
+func natsLiveBytes(ctx context.Context, kv nats.KeyValue) (int64, error) {
+	watcher, err := kv.WatchAll(nats.IgnoreDeletes(), nats.Context(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = watcher.Stop()
+	}()
+
+	var bytes int64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case entry := <-watcher.Updates():
+			if entry == nil {
+				return bytes, nil
+			}
+
+			var value natsKineValue
+			if err = json.Unmarshal(entry.Value(), &value); err == nil && value.Delete {
+				continue
+			}
+
+			bytes += int64(len(entry.Key()) + len(entry.Value()))
+		}
+	}
+}
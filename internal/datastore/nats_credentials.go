@@ -0,0 +1,236 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	// NATSCredentialsKey and NATSContextKey are the keys of the Tenant Control Plane DataStore Secret
+	// storing the NATS user credentials, and the NATS context file pointing to them, consumed by kine.
+	NATSCredentialsKey = "NATS_CREDENTIALS"
+	NATSContextKey     = "NATS_CONTEXT"
+	// NATSCredentialsFolder is where the kine container mounts the NATS credentials and context files.
+	NATSCredentialsFolder = "/nats"
+	// natsCredentialsRenewalInterval is the interval at which the Tenant Control Planes using NATS are reconciled,
+	// renewing the user JWTs expiring within natsTenantCredentialsRenewBefore.
+	natsCredentialsRenewalInterval = 12 * time.Hour
+	// natsTenantCredentialsValidity bounds the validity of the tenant user JWTs, since Kamaji cannot revoke them:
+	// the credentials of a deleted Tenant Control Plane are accepted by the NATS server until their expiration.
+	natsTenantCredentialsValidity = 7 * 24 * time.Hour
+	// natsTenantCredentialsRenewBefore is the remaining validity below which the tenant user JWT is issued again.
+	natsTenantCredentialsRenewBefore = 2 * 24 * time.Hour
+)
+
+// NATSInboxPrefix returns the inbox prefix of the tenant using the given KV bucket:
+// the replies of the JetStream API are delivered there, thus it must not be shared across tenants.
+func NATSInboxPrefix(bucket string) string {
+	return "_INBOX_" + bucket
+}
+
+// natsTenantPermissions returns the subjects a kine instance needs to store its data in the given KV bucket,
+// along with the JetStream API subjects scoped to the bucket stream: the bucket is created by Kamaji.
+func natsTenantPermissions(bucket string) jwt.Permissions {
+	stream := natsKVStreamName(bucket)
+
+	var permissions jwt.Permissions
+
+	permissions.Pub.Allow.Add(
+		"$JS.API.INFO",
+		"$JS.API.STREAM.INFO."+stream,
+		"$JS.API.STREAM.MSG.GET."+stream,
+		"$JS.API.DIRECT.GET."+stream,
+		"$JS.API.DIRECT.GET."+stream+".>",
+		"$JS.API.CONSUMER.CREATE."+stream,
+		"$JS.API.CONSUMER.CREATE."+stream+".>",
+		"$JS.API.CONSUMER.DURABLE.CREATE."+stream+".>",
+		"$JS.API.CONSUMER.INFO."+stream+".>",
+		"$JS.API.CONSUMER.DELETE."+stream+".>",
+		"$JS.API.CONSUMER.MSG.NEXT."+stream+".>",
+		"$JS.ACK."+stream+".>",
+		"$JS.FC."+stream+".>",
+		fmt.Sprintf("$KV.%s.>", bucket),
+	)
+	permissions.Sub.Allow.Add(NATSInboxPrefix(bucket) + ".>")
+
+	return permissions
+}
+
+// issueNATSUserJWT signs a NATS user JWT for the given user public key, using the account signing key seed,
+// expiring after the given validity, if any.
+// The current token is returned as it is when it's still matching the claims, and it's not about to expire:
+// the issue time changes at each signature, thus the Secret storing it would change at each reconciliation.
+func issueNATSUserJWT(signingKey []byte, account, name, user string, permissions jwt.Permissions, validity time.Duration, current string) (string, error) {
+	issuer, err := nkeys.FromSeed(signingKey)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode the NATS account signing key: %w", err)
+	}
+
+	issuerPublicKey, err := issuer.PublicKey()
+	if err != nil {
+		return "", err
+	}
+
+	if !nkeys.IsValidPublicAccountKey(issuerPublicKey) {
+		return "", fmt.Errorf("the NATS signing key must belong to an account")
+	}
+
+	claims := jwt.NewUserClaims(user)
+	claims.Name = name
+	claims.Permissions = permissions
+
+	if account != "" && account != issuerPublicKey {
+		claims.IssuerAccount = account
+	}
+
+	if isNATSUserJWTMatching(current, issuerPublicKey, claims, validity > 0) {
+		return current, nil
+	}
+
+	if validity > 0 {
+		claims.Expires = time.Now().Add(validity).Unix()
+	}
+
+	token, err := claims.Encode(issuer)
+	if err != nil {
+		return "", fmt.Errorf("unable to sign the NATS user JWT: %w", err)
+	}
+
+	return token, nil
+}
+
+// isNATSUserJWTMatching returns true if the given token is signed by the issuer, with the expected claims:
+// an expiring token must be valid for more than natsTenantCredentialsRenewBefore.
+func isNATSUserJWTMatching(token, issuer string, expected *jwt.UserClaims, expiring bool) bool {
+	if token == "" {
+		return false
+	}
+
+	claims, err := jwt.DecodeUserClaims(token)
+	if err != nil || claims.Issuer != issuer || claims.Subject != expected.Subject || claims.Name != expected.Name ||
+		claims.IssuerAccount != expected.IssuerAccount {
+		return false
+	}
+
+	switch {
+	case !expiring && claims.Expires != 0:
+		return false
+	case expiring && time.Until(time.Unix(claims.Expires, 0)) <= natsTenantCredentialsRenewBefore:
+		return false
+	}
+	// Comparing the serialized permissions and limits, since empty and missing lists are the same.
+	currentLimits, err := json.Marshal(claims.UserPermissionLimits)
+	if err != nil {
+		return false
+	}
+
+	expectedLimits, err := json.Marshal(expected.UserPermissionLimits)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(currentLimits, expectedLimits)
+}
+
+// NewNATSUserSeed generates the NKey seed of a NATS user.
+func NewNATSUserSeed() ([]byte, error) {
+	user, err := nkeys.CreateUser()
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Seed()
+}
+
+// IsNATSUserSeed returns true if the given content is the NKey seed of a NATS user.
+func IsNATSUserSeed(seed []byte) bool {
+	prefix, _, err := nkeys.DecodeSeed(seed)
+
+	return err == nil && prefix == nkeys.PrefixByteUser
+}
+
+// NATSTenantCredentials returns the content of the NATS credentials file of a Tenant Control Plane,
+// issued by the given account: the user is allowed to access only its own KV bucket.
+// The JWT of the current credentials file, if any, is kept as long as it's matching the expected claims,
+// and it's not about to expire.
+func NATSTenantCredentials(signingKey []byte, account, name string, userSeed []byte, bucket string, current []byte) ([]byte, error) {
+	user, err := nkeys.FromSeed(userSeed)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the NATS user seed: %w", err)
+	}
+
+	userPublicKey, err := user.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var currentToken string
+
+	if len(current) > 0 {
+		if currentToken, err = jwt.ParseDecoratedJWT(current); err != nil {
+			currentToken = ""
+		}
+	}
+
+	token, err := issueNATSUserJWT(signingKey, account, name, userPublicKey, natsTenantPermissions(bucket), natsTenantCredentialsValidity, currentToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwt.FormatUserConfig(token, userSeed)
+}
+
+// NATSCredentialsRequeueAfter returns the interval at which the given Tenant Control Plane must be reconciled
+// to renew its NATS user JWT before the expiration, zero when it's not using the NATS driver.
+func NATSCredentialsRequeueAfter(tcp *kamajiv1alpha1.TenantControlPlane) time.Duration {
+	if tcp.Status.Storage.Driver != string(kamajiv1alpha1.KineNatsDriver) {
+		return 0
+	}
+
+	return natsCredentialsRenewalInterval
+}
+
+// NATSTenantContext returns the content of the NATS context file used by kine,
+// referring to the credentials file stored at the given path.
+func NATSTenantContext(credentialsPath, bucket string) ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"creds":        credentialsPath,
+		"inbox_prefix": NATSInboxPrefix(bucket),
+	})
+}
+
+// natsAdminCredentials issues the JWT of an unrestricted user, along with its seed,
+// used by Kamaji to manage the KV buckets of the tenants: the user is issued upon each connection,
+// and its seed is never stored, thus it doesn't expire.
+func natsAdminCredentials(signingKey []byte, account string) (token string, seed string, err error) {
+	user, err := nkeys.CreateUser()
+	if err != nil {
+		return "", "", err
+	}
+
+	userPublicKey, err := user.PublicKey()
+	if err != nil {
+		return "", "", err
+	}
+
+	userSeed, err := user.Seed()
+	if err != nil {
+		return "", "", err
+	}
+
+	if token, err = issueNATSUserJWT(signingKey, account, "kamaji", userPublicKey, jwt.Permissions{}, 0, ""); err != nil {
+		return "", "", err
+	}
+
+	return token, string(userSeed), nil
+}
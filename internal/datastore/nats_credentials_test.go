@@ -0,0 +1,164 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nats-io/jwt/v2"
+	"github.com/nats-io/nkeys"
+)
+
+func TestNATSTenantCredentials(t *testing.T) {
+	t.Parallel()
+
+	account, err := nkeys.CreateAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accountPublicKey, _ := account.PublicKey()
+
+	signingKey, err := nkeys.CreateAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingSeed, _ := signingKey.Seed()
+	signingPublicKey, _ := signingKey.PublicKey()
+
+	userSeed, err := NewNATSUserSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := NATSTenantCredentials(signingSeed, accountPublicKey, "tenant", userSeed, "default_prod", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token, err := jwt.ParseDecoratedJWT(creds)
+	if err != nil {
+		t.Fatalf("unable to parse the credentials JWT: %v", err)
+	}
+
+	user, err := jwt.ParseDecoratedUserNKey(creds)
+	if err != nil {
+		t.Fatalf("unable to parse the credentials seed: %v", err)
+	}
+
+	userPublicKey, _ := user.PublicKey()
+
+	claims, err := jwt.DecodeUserClaims(token)
+	if err != nil {
+		t.Fatalf("expected a valid user JWT: %v", err)
+	}
+
+	if claims.Issuer != signingPublicKey || claims.IssuerAccount != accountPublicKey || claims.Subject != userPublicKey {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+	// The tenant must be confined to its own bucket, and inbox.
+	if !claims.Pub.Allow.Contains("$KV.default_prod.>") || claims.Pub.Allow.Contains(">") {
+		t.Fatalf("unexpected publish permissions %v", claims.Pub.Allow)
+	}
+
+	if !slices.Equal(claims.Sub.Allow, jwt.StringList{"_INBOX_default_prod.>"}) {
+		t.Fatalf("unexpected subscribe permissions %v", claims.Sub.Allow)
+	}
+	// The users cannot be revoked, thus their validity must be bounded.
+	if expires := time.Until(time.Unix(claims.Expires, 0)); expires <= natsTenantCredentialsRenewBefore || expires > natsTenantCredentialsValidity {
+		t.Fatalf("unexpected expiration in %s", expires)
+	}
+	// The credentials must be stable, since they're stored in a Secret.
+	again, err := NATSTenantCredentials(signingSeed, accountPublicKey, "tenant", userSeed, "default_prod", creds)
+	if err != nil || string(again) != string(creds) {
+		t.Fatalf("expected the same credentials to be issued, got %v", err)
+	}
+	// The credentials must be issued again upon a change of the claims.
+	other, err := NATSTenantCredentials(signingSeed, accountPublicKey, "tenant", userSeed, "default_staging", creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token, _ = jwt.ParseDecoratedJWT(other); token == "" {
+		t.Fatal("expected a JWT to be issued")
+	}
+
+	if claims, err = jwt.DecodeUserClaims(token); err != nil || !claims.Pub.Allow.Contains("$KV.default_staging.>") {
+		t.Fatalf("expected the credentials to be issued for the new bucket, got %v", err)
+	}
+}
+
+func TestNATSTenantCredentialsWithoutAccountKey(t *testing.T) {
+	t.Parallel()
+
+	operator, err := nkeys.CreateOperator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorSeed, _ := operator.Seed()
+
+	userSeed, err := NewNATSUserSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = NATSTenantCredentials(operatorSeed, "", "tenant", userSeed, "default_prod", nil); err == nil {
+		t.Fatal("expected an error when the signing key doesn't belong to an account")
+	}
+}
+
+func TestNATSTenantCredentialsRenewal(t *testing.T) {
+	t.Parallel()
+
+	account, err := nkeys.CreateAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accountSeed, _ := account.Seed()
+
+	user, err := nkeys.CreateUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userPublicKey, _ := user.PublicKey()
+	permissions := natsTenantPermissions("default_prod")
+	// A token expiring within the renewal threshold must be issued again.
+	expiring, err := issueNATSUserJWT(accountSeed, "", "tenant", userPublicKey, permissions, time.Hour, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renewed, err := issueNATSUserJWT(accountSeed, "", "tenant", userPublicKey, permissions, natsTenantCredentialsValidity, expiring)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if renewed == expiring {
+		t.Fatal("expected the expiring token to be renewed")
+	}
+
+	claims, err := jwt.DecodeUserClaims(renewed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Until(time.Unix(claims.Expires, 0)) <= natsTenantCredentialsRenewBefore {
+		t.Fatalf("unexpected expiration of the renewed token %d", claims.Expires)
+	}
+	// A token without expiration must be issued again with the bounded validity.
+	unbounded, err := issueNATSUserJWT(accountSeed, "", "tenant", userPublicKey, permissions, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bounded, _ := issueNATSUserJWT(accountSeed, "", "tenant", userPublicKey, permissions, natsTenantCredentialsValidity, unbounded); bounded == unbounded {
+		t.Fatal("expected the token without expiration to be issued again")
+	}
+}
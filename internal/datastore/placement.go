@@ -0,0 +1,173 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+var ErrNoDataStoreAvailable = errors.New("no ready DataStore with available capacity")
+
+// PlacementRequest describes how a Tenant Control Plane must be placed among the candidate DataStore objects.
+type PlacementRequest struct {
+	Policy      kamajiv1alpha1.PlacementPolicy
+	SpreadLabel string
+	Candidates  []kamajiv1alpha1.DataStore
+	// Tenants is the number of Tenant Control Planes assigned to each candidate, by name:
+	// see AssignedTenantControlPlanes.
+	Tenants map[string]int
+}
+
+// AssignedTenantControlPlanes returns the namespaced names of the Tenant Control Planes assigned to the given DataStore:
+// along with the ones using it, these are the ones referring to it, not listed by the DataStore status until provisioned.
+func AssignedTenantControlPlanes(ctx context.Context, reader client.Reader, dataStoreName string) (sets.Set[string], error) {
+	assigned := sets.New[string]()
+
+	for _, key := range []string{kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, kamajiv1alpha1.TenantControlPlaneAssignedDataStoreKey} {
+		var tcpList kamajiv1alpha1.TenantControlPlaneList
+		if err := reader.List(ctx, &tcpList, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(key, dataStoreName)}); err != nil {
+			return nil, err
+		}
+
+		for _, tcp := range tcpList.Items {
+			assigned.Insert(client.ObjectKeyFromObject(&tcp).String())
+		}
+	}
+
+	return assigned, nil
+}
+
+// AssignedTenants returns the number of Tenant Control Planes assigned to each of the given DataStore objects, by name.
+func AssignedTenants(ctx context.Context, reader client.Reader, dataStores []kamajiv1alpha1.DataStore) (map[string]int, error) {
+	tenants := make(map[string]int, len(dataStores))
+
+	for _, ds := range dataStores {
+		assigned, err := AssignedTenantControlPlanes(ctx, reader, ds.GetName())
+		if err != nil {
+			return nil, fmt.Errorf("cannot count the Tenant Control Planes assigned to the %s DataStore: %w", ds.GetName(), err)
+		}
+
+		tenants[ds.GetName()] = assigned.Len()
+	}
+
+	return tenants, nil
+}
+
+// IsAdmitted returns true when the Tenant Control Plane fits the maxTenants capacity of the DataStore it's assigned to,
+// enforcing it again upon reconciliation, since the concurrent admissions could have exceeded it: the Tenant Control Planes
+// using the DataStore are admitted, while the pending ones are admitted by their creation order, up to the capacity left.
+func IsAdmitted(ctx context.Context, reader client.Reader, ds *kamajiv1alpha1.DataStore, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	if ds.Spec.MaxTenants == nil || tcp.Status.Storage.DataStoreName == ds.GetName() {
+		return true, nil
+	}
+
+	var used, assigned kamajiv1alpha1.TenantControlPlaneList
+	if err := reader.List(ctx, &used, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, ds.GetName())}); err != nil {
+		return false, err
+	}
+
+	if err := reader.List(ctx, &assigned, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneAssignedDataStoreKey, ds.GetName())}); err != nil {
+		return false, err
+	}
+
+	usedKeys := sets.New[string]()
+	for _, item := range used.Items {
+		usedKeys.Insert(client.ObjectKeyFromObject(&item).String())
+	}
+
+	pending := slices.DeleteFunc(assigned.Items, func(item kamajiv1alpha1.TenantControlPlane) bool {
+		return usedKeys.Has(client.ObjectKeyFromObject(&item).String())
+	})
+	// Sorting by namespaced name too, ensuring a deterministic order upon ties.
+	slices.SortFunc(pending, func(a, b kamajiv1alpha1.TenantControlPlane) int {
+		if diff := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); diff != 0 {
+			return diff
+		}
+
+		return strings.Compare(client.ObjectKeyFromObject(&a).String(), client.ObjectKeyFromObject(&b).String())
+	})
+	// A Tenant Control Plane not listed yet is queued after the known ones.
+	rank := slices.IndexFunc(pending, func(item kamajiv1alpha1.TenantControlPlane) bool {
+		return item.GetNamespace() == tcp.GetNamespace() && item.GetName() == tcp.GetName()
+	})
+	if rank < 0 {
+		rank = len(pending)
+	}
+
+	return ds.HasCapacity(usedKeys.Len() + rank), nil
+}
+
+// Place picks the DataStore according to the requested policy, returning the reason of the choice:
+// DataStores which are not ready, cordoned, have no healthy endpoint, or have reached their maxTenants capacity, are discarded.
+func Place(request PlacementRequest) (*kamajiv1alpha1.DataStore, string, error) {
+	if request.Policy == "" {
+		request.Policy = kamajiv1alpha1.LeastUsedPlacementPolicy
+	}
+
+	available := make([]kamajiv1alpha1.DataStore, 0, len(request.Candidates))
+
+	for _, ds := range request.Candidates {
+		if !ds.Status.Ready || ds.Spec.Cordoned || !ds.HasCapacity(request.Tenants[ds.GetName()]) || meta.IsStatusConditionFalse(ds.Status.Conditions, kamajiv1alpha1.DataStoreConditionHealthyType) {
+			continue
+		}
+
+		available = append(available, ds)
+	}
+
+	if len(available) == 0 {
+		return nil, "", ErrNoDataStoreAvailable
+	}
+
+	// Sorting by name first, ensuring a deterministic choice upon ties.
+	slices.SortFunc(available, func(a, b kamajiv1alpha1.DataStore) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	var chosen kamajiv1alpha1.DataStore
+
+	switch request.Policy {
+	case kamajiv1alpha1.BinPackingPlacementPolicy:
+		chosen = slices.MaxFunc(available, func(a, b kamajiv1alpha1.DataStore) int {
+			// MaxFunc returns the first maximal element: inverting ties keeps the name ordering.
+			if diff := request.Tenants[a.GetName()] - request.Tenants[b.GetName()]; diff != 0 {
+				return diff
+			}
+
+			return strings.Compare(b.GetName(), a.GetName())
+		})
+	case kamajiv1alpha1.SpreadPlacementPolicy:
+		usage := make(map[string]int)
+		for _, ds := range request.Candidates {
+			usage[ds.GetLabels()[request.SpreadLabel]] += request.Tenants[ds.GetName()]
+		}
+
+		chosen = slices.MinFunc(available, func(a, b kamajiv1alpha1.DataStore) int {
+			if diff := usage[a.GetLabels()[request.SpreadLabel]] - usage[b.GetLabels()[request.SpreadLabel]]; diff != 0 {
+				return diff
+			}
+
+			return request.Tenants[a.GetName()] - request.Tenants[b.GetName()]
+		})
+
+		return &chosen, fmt.Sprintf("%s policy: %s=%s is the least used group with %d tenants, DataStore %s is serving %d tenants among %d available",
+			request.Policy, request.SpreadLabel, chosen.GetLabels()[request.SpreadLabel], usage[chosen.GetLabels()[request.SpreadLabel]], chosen.GetName(), request.Tenants[chosen.GetName()], len(available)), nil
+	default:
+		chosen = slices.MinFunc(available, func(a, b kamajiv1alpha1.DataStore) int {
+			return request.Tenants[a.GetName()] - request.Tenants[b.GetName()]
+		})
+	}
+
+	return &chosen, fmt.Sprintf("%s policy: DataStore %s is serving %d tenants among %d available", request.Policy, chosen.GetName(), request.Tenants[chosen.GetName()], len(available)), nil
+}
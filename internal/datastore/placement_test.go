@@ -0,0 +1,122 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func placementCandidate(name, zone string, ready bool, maxTenants *int32) kamajiv1alpha1.DataStore {
+	return kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"zone": zone}},
+		Spec:       kamajiv1alpha1.DataStoreSpec{MaxTenants: maxTenants},
+		Status:     kamajiv1alpha1.DataStoreStatus{Ready: ready},
+	}
+}
+
+func TestPlace(t *testing.T) {
+	candidates := []kamajiv1alpha1.DataStore{
+		placementCandidate("ds-a", "a", true, ptr.To(int32(2))),
+		placementCandidate("ds-b", "a", true, nil),
+		placementCandidate("ds-c", "b", true, nil),
+		placementCandidate("ds-d", "b", false, nil),
+	}
+	tenants := map[string]int{"ds-a": 2, "ds-b": 1, "ds-c": 2}
+
+	tests := map[kamajiv1alpha1.PlacementPolicy]string{
+		"":                                       "ds-b",
+		kamajiv1alpha1.LeastUsedPlacementPolicy:  "ds-b",
+		kamajiv1alpha1.BinPackingPlacementPolicy: "ds-c",
+		kamajiv1alpha1.SpreadPlacementPolicy:     "ds-c",
+	}
+
+	for policy, expected := range tests {
+		ds, reason, err := Place(PlacementRequest{Policy: policy, SpreadLabel: "zone", Candidates: candidates, Tenants: tenants})
+		if err != nil {
+			t.Fatalf("%q policy: unexpected error: %v", policy, err)
+		}
+
+		if ds.GetName() != expected {
+			t.Fatalf("%q policy: placed on %s (%s), expected %s", policy, ds.GetName(), reason, expected)
+		}
+	}
+}
+
+func TestPlaceNoDataStoreAvailable(t *testing.T) {
+	candidates := []kamajiv1alpha1.DataStore{
+		placementCandidate("ds-a", "a", true, ptr.To(int32(1))),
+		placementCandidate("ds-b", "a", false, nil),
+	}
+	// The Tenant Control Planes assigned to the DataStore count, although not using it yet.
+	tenants := map[string]int{"ds-a": 1}
+
+	if _, _, err := Place(PlacementRequest{Candidates: candidates, Tenants: tenants}); !errors.Is(err, ErrNoDataStoreAvailable) {
+		t.Fatalf("expected ErrNoDataStoreAvailable, got %v", err)
+	}
+}
+
+func TestPlaceSkipsUnhealthyDataStore(t *testing.T) {
+	unhealthy := placementCandidate("ds-a", "a", true, nil)
+	unhealthy.Status.Conditions = []metav1.Condition{{Type: kamajiv1alpha1.DataStoreConditionHealthyType, Status: metav1.ConditionFalse}}
+
+	degraded := placementCandidate("ds-b", "a", true, nil)
+	degraded.Status.Conditions = []metav1.Condition{{Type: kamajiv1alpha1.DataStoreConditionHealthyType, Status: metav1.ConditionTrue}}
+
+	ds, reason, err := Place(PlacementRequest{Candidates: []kamajiv1alpha1.DataStore{unhealthy, degraded}, Tenants: map[string]int{"ds-b": 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ds.GetName() != "ds-b" {
+		t.Fatalf("placed on %s (%s), expected ds-b", ds.GetName(), reason)
+	}
+}
+
+func TestIsAdmitted(t *testing.T) {
+	created := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+
+	ds := placementCandidate("pg-a", "a", true, ptr.To(int32(3)))
+
+	tenant := func(name string, age time.Duration, provisioned bool) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec:       kamajiv1alpha1.TenantControlPlaneSpec{DataStore: ds.GetName()},
+		}
+		if provisioned {
+			tcp.Status.Storage.DataStoreName = ds.GetName()
+		}
+
+		return tcp
+	}
+	// The concurrent admissions placed four Tenant Control Planes on a DataStore with capacity for three.
+	tenants := []*kamajiv1alpha1.TenantControlPlane{
+		tenant("provisioned", time.Hour, true),
+		tenant("oldest", 3*time.Minute, false),
+		tenant("older", 2*time.Minute, false),
+		tenant("newest", time.Minute, false),
+	}
+
+	c := newFakeClient(t, tenants[0], tenants[1], tenants[2], tenants[3])
+
+	expected := map[string]bool{"provisioned": true, "oldest": true, "older": true, "newest": false}
+
+	for _, tcp := range tenants {
+		admitted, err := IsAdmitted(context.Background(), c, &ds, tcp)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if admitted != expected[tcp.GetName()] {
+			t.Fatalf("expected the admission of %s to be %t", tcp.GetName(), expected[tcp.GetName()])
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/metrics"
+)
+
+// PoolOptions configures the connection pool of the SQL drivers:
+// etcd and NATS multiplex the requests over a single connection per endpoint.
+type PoolOptions struct {
+	// MaxOpenConnections caps the connections opened towards a single DataStore, zero meaning the driver default:
+	// with PostgreSQL, it caps each pool kept per tenant database, or schema, since a pool is bound to a database.
+	MaxOpenConnections int
+	// MaxIdleConnections caps the idle connections kept open, zero meaning the driver default:
+	// it's honoured by MySQL only, PostgreSQL closing the idle ones according to IdleTimeout.
+	MaxIdleConnections int
+	// IdleTimeout is the time after which an idle connection is closed, zero meaning the driver default.
+	IdleTimeout time.Duration
+}
+
+// PoolStats reports the state of the connections pool.
+type PoolStats struct {
+	InUse int
+	Idle  int
+}
+
+// PooledConnection is implemented by the Connection objects backed by a pool of connections.
+type PooledConnection interface {
+	PoolStats() PoolStats
+}
+
+// ConnectionManager keeps a single Connection per DataStore, shared across the reconciliations:
+// the Connection is replaced as soon as the DataStore configuration, or the content of the referenced Secrets, changes.
+type ConnectionManager struct {
+	client      client.Client
+	options     PoolOptions
+	metrics     *metrics.Recorder
+	lock        sync.Mutex
+	connections map[string]*sharedConnection
+}
+
+func NewConnectionManager(client client.Client, options PoolOptions, recorder *metrics.Recorder) *ConnectionManager {
+	return &ConnectionManager{
+		client:      client,
+		options:     options,
+		metrics:     recorder,
+		connections: make(map[string]*sharedConnection),
+	}
+}
+
+type sharedConnection struct {
+	Connection
+	driver kamajiv1alpha1.Driver
+	config ConnectionConfig
+	// leases is the number of Connection objects handed out and not closed yet:
+	// a stale connection is closed once all of them have been released.
+	leases int
+	stale  bool
+}
+
+// leasedConnection is the Connection handed out by the ConnectionManager:
+// closing it releases the lease, rather than the underlying connection.
+type leasedConnection struct {
+	*sharedConnection
+	manager *ConnectionManager
+	once    sync.Once
+}
+
+func (l *leasedConnection) Close() error {
+	l.once.Do(func() {
+		l.manager.release(l.sharedConnection)
+	})
+
+	return nil
+}
+
+// unwrap returns the driver Connection a leased one is backed by: the drivers require it
+// when it's the target of a copy, or the origin of a live migration.
+func unwrap(connection Connection) Connection {
+	if leased, ok := connection.(*leasedConnection); ok {
+		return leased.Connection
+	}
+
+	return connection
+}
+
+// Connection returns the shared Connection for the given DataStore, the caller must close it once done.
+func (m *ConnectionManager) Connection(ctx context.Context, ds kamajiv1alpha1.DataStore) (Connection, error) {
+	config, err := NewConnectionConfig(ctx, m.client, ds)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Pool = m.options
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	defer m.recordMetrics()
+
+	shared, found := m.connections[ds.GetName()]
+	if !found || shared.driver != ds.Spec.Driver || !shared.config.equal(*config) {
+		if found {
+			m.discard(shared)
+		}
+
+		connection, connErr := newStorageConnection(ds, *config)
+		if connErr != nil {
+			return nil, connErr
+		}
+
+		shared = &sharedConnection{Connection: connection, driver: ds.Spec.Driver, config: *config}
+		m.connections[ds.GetName()] = shared
+	}
+
+	shared.leases++
+
+	return &leasedConnection{sharedConnection: shared, manager: m}, nil
+}
+
+// Invalidate discards the Connection of the given DataStore, such as upon its deletion:
+// it's closed once all the leases have been released.
+func (m *ConnectionManager) Invalidate(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if shared, found := m.connections[name]; found {
+		m.discard(shared)
+		delete(m.connections, name)
+	}
+
+	m.recordMetrics()
+}
+
+func (m *ConnectionManager) release(shared *sharedConnection) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	shared.leases--
+
+	if shared.stale && shared.leases == 0 {
+		_ = shared.Connection.Close()
+	}
+
+	m.recordMetrics()
+}
+
+// discard must be called holding the lock.
+func (m *ConnectionManager) discard(shared *sharedConnection) {
+	shared.stale = true
+
+	if shared.leases == 0 {
+		_ = shared.Connection.Close()
+	}
+}
+
+// recordMetrics must be called holding the lock.
+func (m *ConnectionManager) recordMetrics() {
+	if m.metrics == nil {
+		return
+	}
+
+	m.metrics.ResetDataStoreConnections()
+
+	for name, shared := range m.connections {
+		pooled, ok := shared.Connection.(PooledConnection)
+		if !ok {
+			continue
+		}
+
+		stats := pooled.PoolStats()
+		m.metrics.SetDataStoreConnections(name, stats.InUse, stats.Idle)
+	}
+}
+
+// equal reports whether the two configurations lead to the same connection.
+func (config ConnectionConfig) equal(other ConnectionConfig) bool {
+	if config.User != other.User ||
+		config.Password != other.Password ||
+		config.DBName != other.DBName ||
+		config.NATSAccount != other.NATSAccount ||
+		config.SharedDatabase != other.SharedDatabase ||
+		config.Pool != other.Pool ||
+		!bytes.Equal(config.NATSSigningKey, other.NATSSigningKey) ||
+		!slices.Equal(config.Endpoints, other.Endpoints) ||
+		!reflect.DeepEqual(config.Parameters, other.Parameters) {
+		return false
+	}
+
+	if config.TLSConfig == nil || other.TLSConfig == nil {
+		return config.TLSConfig == nil && other.TLSConfig == nil
+	}
+
+	if config.TLSConfig.ServerName != other.TLSConfig.ServerName ||
+		!config.TLSConfig.RootCAs.Equal(other.TLSConfig.RootCAs) || len(config.TLSConfig.Certificates) != len(other.TLSConfig.Certificates) {
+		return false
+	}
+
+	for i := range config.TLSConfig.Certificates {
+		if !slices.EqualFunc(config.TLSConfig.Certificates[i].Certificate, other.TLSConfig.Certificates[i].Certificate, bytes.Equal) {
+			return false
+		}
+		// The certificate could be kept, while its private key is replaced.
+		key, ok := config.TLSConfig.Certificates[i].PrivateKey.(interface {
+			Equal(x crypto.PrivateKey) bool
+		})
+		if !ok || !key.Equal(other.TLSConfig.Certificates[i].PrivateKey) {
+			return false
+		}
+	}
+
+	return true
+}
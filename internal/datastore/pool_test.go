@@ -0,0 +1,151 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestConnectionManager(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgresql-auth", Namespace: "kamaji-system"},
+		Data:       map[string][]byte{"username": []byte("kamaji"), "password": []byte("first")},
+	}
+
+	reference := corev1.SecretReference{Name: secret.GetName(), Namespace: secret.GetNamespace()}
+
+	ds := kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgresql"},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver:    kamajiv1alpha1.KinePostgreSQLDriver,
+			Endpoints: kamajiv1alpha1.Endpoints{"postgresql:5432"},
+			BasicAuth: &kamajiv1alpha1.BasicAuth{
+				Username: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: reference, KeyPath: "username"}},
+				Password: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: reference, KeyPath: "password"}},
+			},
+		},
+	}
+
+	c := newFakeClient(t, secret)
+	manager := NewConnectionManager(c, PoolOptions{MaxOpenConnections: 5}, nil)
+
+	connection := func() *sharedConnection {
+		t.Helper()
+
+		leased, err := manager.Connection(context.Background(), ds)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return leased.(*leasedConnection).sharedConnection //nolint:forcetypeassert
+	}
+
+	first, second := connection(), connection()
+	if first != second {
+		t.Fatal("expected the connection to be shared")
+	}
+
+	if first.leases != 2 {
+		t.Fatalf("expected 2 leases, got %d", first.leases)
+	}
+	// Changing the content of the referenced Secret replaces the connection.
+	secret.Data["password"] = []byte("second")
+	if err := c.Update(context.Background(), secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if third := connection(); third == first {
+		t.Fatal("expected the connection to be replaced upon the Secret change")
+	}
+
+	if !first.stale {
+		t.Fatal("expected the previous connection to be stale")
+	}
+
+	manager.Invalidate(ds.GetName())
+
+	if _, found := manager.connections[ds.GetName()]; found {
+		t.Fatal("expected the connection to be discarded")
+	}
+}
+
+func TestConnectionConfigEqualPrivateKey(t *testing.T) {
+	newKey := func() *ecdsa.PrivateKey {
+		t.Helper()
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return key
+	}
+
+	config := func(key *ecdsa.PrivateKey) ConnectionConfig {
+		return ConnectionConfig{TLSConfig: &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{[]byte("certificate")}, PrivateKey: key}}}}
+	}
+
+	first := newKey()
+
+	if !config(first).equal(config(first)) {
+		t.Fatal("expected the configurations to be equal")
+	}
+	// The Secret could keep the same certificate, with a new private key.
+	if config(first).equal(config(newKey())) {
+		t.Fatal("expected the configurations to differ upon a private key change")
+	}
+}
+
+func TestLeasedConnectionAsTarget(t *testing.T) {
+	originDB, origin, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create the origin mock: %v", err)
+	}
+	defer originDB.Close()
+
+	targetDB, target, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create the target mock: %v", err)
+	}
+	defer targetDB.Close()
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	tcp.Status.Storage.Setup.Schema = "default_prod"
+
+	origin.ExpectQuery("SELECT (.+) FROM `default_prod`.kine WHERE id > \\?").
+		WithArgs(10, kineReplicationBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created", "deleted", "create_revision", "prev_revision", "lease", "value", "old_value"}).
+			AddRow(11, "/registry/leases/kube-node-lease/worker", 1, 0, 0, 0, 0, []byte("a"), nil))
+
+	target.ExpectBegin()
+	target.ExpectExec("INSERT INTO `default_migrated`.kine").WillReturnResult(sqlmock.NewResult(11, 1))
+	target.ExpectCommit()
+	// The Connection handed out by the ConnectionManager is a lease of the driver one.
+	leased := &leasedConnection{sharedConnection: &sharedConnection{Connection: &MySQLConnection{db: targetDB}}}
+
+	if _, _, err = (&MySQLConnection{db: originDB}).Replicate(context.Background(), tcp, leased, "default_migrated", 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, mock := range []sqlmock.Sqlmock{origin, target} {
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The origin of a live migration is a leased Connection as well.
+	if _, ok := unwrap(&leasedConnection{sharedConnection: &sharedConnection{Connection: &MySQLConnection{}}}).(ChangeReplicator); !ok {
+		t.Fatal("expected the leased connection to be unwrapped to the driver one")
+	}
+}
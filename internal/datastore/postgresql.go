@@ -7,7 +7,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-pg/pg/v10"
 
@@ -25,7 +30,16 @@ const (
 	postgresqlUserExists                  = "SELECT 1 FROM pg_roles WHERE rolname = ?"
 	postgresqlCreateUserStatement         = `CREATE ROLE %s LOGIN PASSWORD ?`
 	postgresqlUpdateUserStatement         = `ALTER ROLE %s WITH PASSWORD ?`
-	postgresqlShowGrantsStatement         = "SELECT has_database_privilege(rolname, ?, 'create') from pg_roles where rolcanlogin and rolname = ?"
+	postgresqlCreateCertUserStatement     = `CREATE ROLE %s LOGIN`
+	postgresqlCreateLoginRoleStatement    = `CREATE ROLE %s LOGIN PASSWORD ? IN ROLE %s`
+	postgresqlEnableLoginRoleStatement    = `ALTER ROLE %s WITH LOGIN PASSWORD ?`
+	postgresqlDisableLoginRoleStatement   = `ALTER ROLE %s NOLOGIN`
+	postgresqlSetLoginRoleStatement       = `ALTER ROLE %s SET role = %s`
+	postgresqlReassignOwnedStatement      = `REASSIGN OWNED BY %s TO %s`
+	postgresqlConnectionLimitStatement    = `ALTER ROLE %s CONNECTION LIMIT %d`
+	postgresqlStatementTimeoutStatement   = `ALTER ROLE %s SET statement_timeout = %d`
+	postgresqlResetStatementTimeout       = `ALTER ROLE %s RESET statement_timeout`
+	postgresqlShowGrantsStatement         = "SELECT has_database_privilege(rolname, ?, 'create') from pg_roles where rolname = ?"
 	postgresqlShowOwnershipStatement      = "SELECT 't' FROM pg_catalog.pg_database AS d WHERE d.datname = ? AND pg_catalog.pg_get_userbyid(d.datdba) = ?"
 	postgresqlShowTableOwnershipStatement = "SELECT 't' from pg_tables where tableowner = ? AND tablename = ?"
 	postgresqlKineTableExistsStatement    = "SELECT 't' FROM pg_tables WHERE schemaname = ? AND tablename  = ?"
@@ -33,50 +47,95 @@ const (
 	postgresqlChangeOwnerStatement        = `ALTER DATABASE %s OWNER TO %s`
 	postgresqlRevokePrivilegesStatement   = `REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s`
 	postgresqlDropRoleStatement           = `DROP ROLE %s`
+	postgresqlDropRoleIfExistsStatement   = `DROP ROLE IF EXISTS %s`
 	postgresqlDropDBStatement             = `DROP DATABASE %s WITH (FORCE)`
+	postgresqlKineMaxRevisionStatement    = "SELECT COALESCE(MAX(id), 0) FROM kine"
+	postgresqlKineChangesStatement        = "SELECT id, name, created, deleted, create_revision, prev_revision, lease, value, old_value FROM kine WHERE id > ? ORDER BY id ASC LIMIT ?"
+	postgresqlKineInsertStatement         = "INSERT INTO kine (id, name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO NOTHING"
+	postgresqlKineAlignSequenceStatement  = "SELECT setval(pg_get_serial_sequence('kine', 'id'), (SELECT MAX(id) FROM kine))"
+	postgresqlKineLatestStatement         = "SELECT kv.name, kv.deleted, kv.value FROM kine AS kv JOIN (SELECT MAX(id) AS id FROM kine WHERE name > ? GROUP BY name ORDER BY name ASC LIMIT ?) AS latest ON latest.id = kv.id ORDER BY kv.name ASC"
+	postgresqlKineCreateStatement         = "INSERT INTO kine (name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES (?, 1, 0, 0, 0, 0, ?, NULL)"
+	postgresqlListDBStatement             = "SELECT datname FROM pg_database WHERE NOT datistemplate"
+	postgresqlDatabaseSizeStatement       = "SELECT pg_database_size(?)"
+	postgresqlKineRowsStatement           = "SELECT COUNT(*) FROM kine"
+	postgresqlKineLiveBytesStatement      = "SELECT COALESCE(SUM(OCTET_LENGTH(kv.name) + COALESCE(OCTET_LENGTH(kv.value), 0)), 0) FROM kine AS kv JOIN (SELECT MAX(id) AS id FROM kine GROUP BY name) AS latest ON latest.id = kv.id WHERE kv.deleted = 0 AND kv.name <> 'compact_rev_key'"
+	postgresqlSetSearchPathStatement      = `SET search_path TO %s`
+	// The tenant roles are listed even when their login has been disabled by a rotation,
+	// while their login roles are managed along with them.
+	postgresqlListUsersStatement = `SELECT r.rolname FROM pg_roles AS r WHERE NOT r.rolsuper AND r.rolname <> current_user AND r.rolname NOT LIKE 'pg\_%'
+		AND (r.rolcanlogin OR EXISTS (SELECT FROM pg_auth_members AS m JOIN pg_roles AS l ON l.oid = m.member WHERE m.roleid = r.oid AND l.rolname IN (r.rolname || '_1', r.rolname || '_2')))
+		AND NOT EXISTS (SELECT FROM pg_auth_members AS m JOIN pg_roles AS g ON g.oid = m.roleid WHERE m.member = r.oid AND r.rolname IN (g.rolname || '_1', g.rolname || '_2'))`
+	// postgresqlPublicSchema stores the kine table when each tenant gets its own database.
+	postgresqlPublicSchema = "public"
 )
 
+// TenantRoleKey is the key of the Tenant Control Plane DataStore Secret storing the tenant role,
+// once the credentials have been rotated to one of its login roles, stored as DB_USER.
+const TenantRoleKey = "DB_ROLE"
+
+// postgresqlLoginRoleSuffixes name the two login roles, members of the tenant role, the credentials alternate across:
+// these must match the ones of postgresqlListUsersStatement.
+var postgresqlLoginRoleSuffixes = [2]string{"_1", "_2"}
+
+// postgresqlKineSchemaStatements creates the kine table, along with its indexes, if missing.
+var postgresqlKineSchemaStatements = []string{
+	`CREATE TABLE IF NOT EXISTS kine (
+		id SERIAL PRIMARY KEY,
+		name VARCHAR(630),
+		created INTEGER,
+		deleted INTEGER,
+		create_revision INTEGER,
+		prev_revision INTEGER,
+		lease INTEGER,
+		value bytea,
+		old_value bytea
+	)`,
+	`CREATE INDEX IF NOT EXISTS kine_name_index ON kine (name)`,
+	`CREATE INDEX IF NOT EXISTS kine_name_id_index ON kine (name,id)`,
+	`CREATE INDEX IF NOT EXISTS kine_id_deleted_index ON kine (id,deleted)`,
+	`CREATE INDEX IF NOT EXISTS kine_prev_revision_index ON kine (prev_revision)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS kine_name_prev_revision_uindex ON kine (name, prev_revision)`,
+}
+
 type PostgreSQLConnection struct {
 	db               *pg.DB
 	connection       ConnectionEndpoint
 	rootUser         string
 	switchDatabaseFn func(dbName string) *pg.DB
+	// switchSchemaFn connects to the shared database, with the search_path set to the given schema.
+	switchSchemaFn func(schema string) *pg.DB
+	// sharedDatabase stores the tenants as schemas, rather than as databases, when set.
+	sharedDatabase string
+	// pools holds the pool of connections of each database, or schema, used by the connection:
+	// they're kept along with it, and closed before dropping the database they're connected to.
+	lock  sync.Mutex
+	pools map[postgresqlPoolKey]*pg.DB
+}
+
+type postgresqlPoolKey struct {
+	// schema is set for the pools connecting to the shared database, with the search_path set to the schema.
+	schema bool
+	name   string
 }
 
-func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
+func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string) error {
 	// Ensuring the connection is working as expected
 	if err := target.Check(ctx); err != nil {
 		return fmt.Errorf("unable to check target datastore: %w", err)
 	}
 	// Creating the target schema if it doesn't exist
-	if ok, _ := target.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
-		if err := target.CreateDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+	if ok, _ := target.DBExists(ctx, targetSchema); !ok {
+		if err := target.CreateDB(ctx, targetSchema); err != nil {
 			return err
 		}
 	}
 
-	targetConn := target.(*PostgreSQLConnection).switchDatabaseFn(tcp.Status.Storage.Setup.Schema) //nolint:forcetypeassert
+	targetConn := unwrap(target).(*PostgreSQLConnection).tenantDB(targetSchema) //nolint:forcetypeassert
+
+	originConn := r.tenantDB(tcp.Status.Storage.Setup.Schema)
 
 	err := targetConn.RunInTransaction(ctx, func(tx *pg.Tx) error {
-		for _, stm := range []string{
-			`CREATE TABLE IF NOT EXISTS kine (
-				id SERIAL PRIMARY KEY,
-				name VARCHAR(630),
-				created INTEGER,
-				deleted INTEGER,
-				create_revision INTEGER,
-				prev_revision INTEGER,
-				lease INTEGER,
-				value bytea,
-				old_value bytea
-			)`,
-			`TRUNCATE TABLE kine`,
-			`CREATE INDEX IF NOT EXISTS kine_name_index ON kine (name)`,
-			`CREATE INDEX IF NOT EXISTS kine_name_id_index ON kine (name,id)`,
-			`CREATE INDEX IF NOT EXISTS kine_id_deleted_index ON kine (id,deleted)`,
-			`CREATE INDEX IF NOT EXISTS kine_prev_revision_index ON kine (prev_revision)`,
-			`CREATE UNIQUE INDEX IF NOT EXISTS kine_name_prev_revision_uindex ON kine (name, prev_revision)`,
-		} {
+		for _, stm := range append(postgresqlKineSchemaStatements, `TRUNCATE TABLE kine`) {
 			if _, err := tx.ExecContext(ctx, stm); err != nil {
 				return fmt.Errorf("unable to perform schema creation: %w", err)
 			}
@@ -84,7 +143,7 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 		// Dumping the old datastore in a local buffer
 		var buf bytes.Buffer
 
-		if _, err := r.switchDatabaseFn(tcp.Status.Storage.Setup.Schema).WithContext(ctx).CopyTo(&buf, "COPY kine TO STDOUT"); err != nil {
+		if _, err := originConn.WithContext(ctx).CopyTo(&buf, "COPY kine TO STDOUT"); err != nil {
 			return fmt.Errorf("unable to copy from the origin datastore: %w", err)
 		}
 
@@ -108,18 +167,52 @@ func NewPostgreSQLConnection(config ConnectionConfig) (Connection, error) {
 		User:      config.User,
 		Password:  config.Password,
 		TLSConfig: config.TLSConfig,
+		PoolSize:  config.Pool.MaxOpenConnections,
+	}
+
+	if config.Pool.IdleTimeout > 0 {
+		opt.IdleTimeout = config.Pool.IdleTimeout
+	}
+	// These are the only parameters accepted for PostgreSQL, the other ones being unsupported by go-pg.
+	if values := config.Parameters["connect_timeout"]; len(values) > 0 {
+		seconds, err := strconv.Atoi(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid connect_timeout parameter: %w", err)
+		}
+
+		opt.DialTimeout = time.Duration(seconds) * time.Second
 	}
 
+	if values := config.Parameters["application_name"]; len(values) > 0 {
+		opt.ApplicationName = values[0]
+	}
+	// The connection is shared across the reconciliations:
+	// the options are copied, rather than mutated, when switching database.
 	fn := func(dbName string) *pg.DB {
-		o := opt
+		o := *opt
 		o.Database = dbName
 
-		return pg.Connect(o)
+		return pg.Connect(&o)
+	}
+	// kine is pointed to the tenant schema with the search_path as well,
+	// thus the unqualified statements on the kine table work with both the layouts.
+	schemaFn := func(schema string) *pg.DB {
+		o := *opt
+		o.Database = config.SharedDatabase
+		o.OnConnect = func(ctx context.Context, cn *pg.Conn) error {
+			_, err := cn.ExecContext(ctx, fmt.Sprintf(postgresqlSetSearchPathStatement, quotePostgreSQLIdentifier(schema)))
+
+			return err
+		}
+
+		return pg.Connect(&o)
 	}
 
 	return &PostgreSQLConnection{
 		db:               pg.Connect(opt),
 		switchDatabaseFn: fn,
+		switchSchemaFn:   schemaFn,
+		sharedDatabase:   config.SharedDatabase,
 		rootUser:         config.User,
 		connection:       config.Endpoints[0],
 	}, nil
@@ -147,6 +240,107 @@ func (r *PostgreSQLConnection) CreateUser(ctx context.Context, user, password st
 	return nil
 }
 
+// CreateCertificateUser creates a role without password: the client certificate is checked by the cert authentication method,
+// which must be configured in the pg_hba.conf file, matching the role with the certificate Common Name.
+func (r *PostgreSQLConnection) CreateCertificateUser(ctx context.Context, user string) error {
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateCertUserStatement, quotePostgreSQLIdentifier(user))); err != nil {
+		return errors.NewCreateUserError(err)
+	}
+
+	return nil
+}
+
+// SetUserLimits ignores the queries per hour, not supported by PostgreSQL:
+// the limits are checked when connecting, and when starting a session, thus the established ones keep the previous values.
+func (r *PostgreSQLConnection) SetUserLimits(ctx context.Context, user string, limits UserLimits) error {
+	// A negative connection limit lifts it.
+	connectionLimit := -1
+	if limits.MaxConnections > 0 {
+		connectionLimit = int(limits.MaxConnections)
+	}
+
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlConnectionLimitStatement, quotePostgreSQLIdentifier(user), connectionLimit)); err != nil {
+		return errors.NewSetUserLimitsError(err)
+	}
+
+	statement := fmt.Sprintf(postgresqlResetStatementTimeout, quotePostgreSQLIdentifier(user))
+	if limits.StatementTimeout > 0 {
+		statement = fmt.Sprintf(postgresqlStatementTimeoutStatement, quotePostgreSQLIdentifier(user), limits.StatementTimeout.Milliseconds())
+	}
+
+	if _, err := r.db.ExecContext(ctx, statement); err != nil {
+		return errors.NewSetUserLimitsError(err)
+	}
+
+	return nil
+}
+
+// PostgreSQLLoginRole returns the login role the credentials of the tenant role must be rotated to,
+// alternating between its two login roles, since PostgreSQL doesn't support dual passwords.
+func PostgreSQLLoginRole(role, current string) string {
+	if current == role+postgresqlLoginRoleSuffixes[0] {
+		return role + postgresqlLoginRoleSuffixes[1]
+	}
+
+	return role + postgresqlLoginRoleSuffixes[0]
+}
+
+// RotateUser creates, or enables back, the login role with the new password, as member of the tenant role:
+// the privileges are inherited from the tenant role, and the previous login role keeps working until DiscardPreviousPassword.
+// The sessions of the login role are running as the tenant role, thus the objects created by kine are owned by the latter,
+// rather than by the login role, which is disabled, and eventually dropped, regardless of the tenant data.
+// Without a login role, the current password is replaced, since PostgreSQL doesn't support dual passwords.
+func (r *PostgreSQLConnection) RotateUser(ctx context.Context, user, loginUser, password string) (bool, error) {
+	if loginUser == user {
+		return false, r.UpdateUser(ctx, user, password)
+	}
+
+	exists, err := r.UserExists(ctx, loginUser)
+	if err != nil {
+		return false, err
+	}
+
+	statement := fmt.Sprintf(postgresqlCreateLoginRoleStatement, quotePostgreSQLIdentifier(loginUser), quotePostgreSQLIdentifier(user))
+	if exists {
+		statement = fmt.Sprintf(postgresqlEnableLoginRoleStatement, quotePostgreSQLIdentifier(loginUser))
+	}
+
+	if _, err = r.db.ExecContext(ctx, statement, password); err != nil {
+		return false, errors.NewUpdateUserError(err)
+	}
+
+	if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlSetLoginRoleStatement, quotePostgreSQLIdentifier(loginUser), quotePostgreSQLIdentifier(user))); err != nil {
+		return false, errors.NewUpdateUserError(err)
+	}
+
+	return true, nil
+}
+
+// DiscardPreviousPassword disables the login of the tenant role, and of its login roles, other than the current one:
+// the established connections are kept, since the login is checked only when connecting.
+func (r *PostgreSQLConnection) DiscardPreviousPassword(ctx context.Context, user, loginUser string) error {
+	for _, role := range []string{user, user + postgresqlLoginRoleSuffixes[0], user + postgresqlLoginRoleSuffixes[1]} {
+		if role == loginUser {
+			continue
+		}
+
+		exists, err := r.UserExists(ctx, role)
+		if err != nil {
+			return errors.NewDiscardPreviousPasswordError(err)
+		}
+
+		if !exists {
+			continue
+		}
+
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDisableLoginRoleStatement, quotePostgreSQLIdentifier(role))); err != nil {
+			return errors.NewDiscardPreviousPasswordError(err)
+		}
+	}
+
+	return nil
+}
+
 func (r *PostgreSQLConnection) UpdateUser(ctx context.Context, user, password string) error {
 	_, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlUpdateUserStatement, quotePostgreSQLIdentifier(user)), password)
 	if err != nil {
@@ -157,6 +351,10 @@ func (r *PostgreSQLConnection) UpdateUser(ctx context.Context, user, password st
 }
 
 func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (bool, error) {
+	if r.sharedDatabase != "" {
+		return r.schemaExists(ctx, dbName)
+	}
+
 	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, dbName)
 	if err != nil {
 		return false, errors.NewCheckDatabaseExistError(err)
@@ -166,6 +364,10 @@ func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (boo
 }
 
 func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) error {
+	if r.sharedDatabase != "" {
+		return r.createSchema(ctx, dbName)
+	}
+
 	_, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateDBStatement, quotePostgreSQLIdentifier(dbName)))
 	if err != nil {
 		return errors.NewCreateDBError(err)
@@ -175,6 +377,10 @@ func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) erro
 }
 
 func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error) {
+	if r.sharedDatabase != "" {
+		return r.schemaGrantPrivilegesExists(ctx, user, dbName)
+	}
+
 	var hasDatabasePrivilege string
 
 	_, err := r.db.QueryContext(ctx, pg.Scan(&hasDatabasePrivilege), postgresqlShowGrantsStatement, dbName, user)
@@ -194,10 +400,9 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 
 	var isTableOwner string
 
-	dbConn := r.switchDatabaseFn(dbName)
-	defer dbConn.Close()
+	dbConn := r.database(dbName)
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, postgresqlPublicSchema)
 	if err != nil {
 		return false, errors.NewGrantPrivilegesError(err)
 	}
@@ -214,18 +419,21 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 }
 
 func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName string) error {
+	if r.sharedDatabase != "" {
+		return r.grantSchemaPrivileges(ctx, user, dbName)
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlGrantPrivilegesStatement, quotePostgreSQLIdentifier(dbName), quotePostgreSQLIdentifier(user))); err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
 
-	dbConn := r.switchDatabaseFn(dbName)
-	defer dbConn.Close()
+	dbConn := r.database(dbName)
 
 	if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlChangeOwnerStatement, quotePostgreSQLIdentifier(dbName), quotePostgreSQLIdentifier(user))); err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, postgresqlPublicSchema)
 	if err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
@@ -239,7 +447,28 @@ func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName
 	return nil
 }
 
+// DeleteUser drops the login roles of the tenant role as well, if any:
+// the objects they still own, such as the ones created before running as the tenant role, are reassigned to it.
 func (r *PostgreSQLConnection) DeleteUser(ctx context.Context, user string) error {
+	for _, suffix := range postgresqlLoginRoleSuffixes {
+		exists, err := r.UserExists(ctx, user+suffix)
+		if err != nil {
+			return errors.NewDeleteUserError(err)
+		}
+
+		if !exists {
+			continue
+		}
+
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlReassignOwnedStatement, quotePostgreSQLIdentifier(user+suffix), quotePostgreSQLIdentifier(user))); err != nil {
+			return errors.NewDeleteUserError(err)
+		}
+
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDropRoleIfExistsStatement, quotePostgreSQLIdentifier(user+suffix))); err != nil {
+			return errors.NewDeleteUserError(err)
+		}
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDropRoleStatement, quotePostgreSQLIdentifier(user))); err != nil {
 		return errors.NewDeleteUserError(err)
 	}
@@ -252,6 +481,13 @@ func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) erro
 		return errors.NewCannotDeleteDatabaseError(fmt.Errorf("cannot grant privileges to root user: %w", err))
 	}
 
+	if r.sharedDatabase != "" {
+		return r.dropSchema(ctx, dbName)
+	}
+
+	// The database cannot be dropped as long as there are connections to it.
+	r.closePool(postgresqlPoolKey{name: dbName})
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDropDBStatement, quotePostgreSQLIdentifier(dbName))); err != nil {
 		return errors.NewCannotDeleteDatabaseError(err)
 	}
@@ -260,6 +496,11 @@ func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) erro
 }
 
 func (r *PostgreSQLConnection) RevokePrivileges(ctx context.Context, user, dbName string) error {
+	// The schema is handed over to Kamaji upon deletion, the tenant role must be only denied the shared database access.
+	if r.sharedDatabase != "" {
+		dbName = r.sharedDatabase
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlRevokePrivilegesStatement, quotePostgreSQLIdentifier(dbName), quotePostgreSQLIdentifier(user))); err != nil {
 		return errors.NewRevokePrivilegesError(err)
 	}
@@ -267,11 +508,76 @@ func (r *PostgreSQLConnection) RevokePrivileges(ctx context.Context, user, dbNam
 	return nil
 }
 
+func (r *PostgreSQLConnection) ListDatabases(ctx context.Context) ([]string, error) {
+	if r.sharedDatabase != "" {
+		return r.listSchemas(ctx)
+	}
+
+	var names []string
+
+	if _, err := r.db.QueryContext(ctx, &names, postgresqlListDBStatement); err != nil {
+		return nil, errors.NewListDatabasesError(err)
+	}
+
+	databases := make([]string, 0, len(names))
+
+	for _, name := range names {
+		// A dedicated pool is used, rather than a kept one, since the database could be not managed by Kamaji:
+		// the connections left open would prevent it from being dropped.
+		dbConn := r.switchDatabaseFn(name)
+		// The databases Kamaji cannot connect to are not managed by it.
+		tableExists, err := r.kineTableExists(ctx, dbConn, postgresqlPublicSchema)
+		_ = dbConn.Close()
+
+		if err == nil && tableExists {
+			databases = append(databases, name)
+		}
+	}
+
+	return databases, nil
+}
+
+func (r *PostgreSQLConnection) ListUsers(ctx context.Context) ([]string, error) {
+	var names []string
+
+	if _, err := r.db.QueryContext(ctx, &names, postgresqlListUsersStatement); err != nil {
+		return nil, errors.NewListUsersError(err)
+	}
+
+	return names, nil
+}
+
+// PoolStats reports the connections of the pools of the tenant databases, or schemas, as well.
+func (r *PostgreSQLConnection) PoolStats() PoolStats {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var result PoolStats
+
+	for _, db := range append([]*pg.DB{r.db}, slices.Collect(maps.Values(r.pools))...) {
+		stats := db.PoolStats()
+
+		result.InUse += int(stats.TotalConns - stats.IdleConns)
+		result.Idle += int(stats.IdleConns)
+	}
+
+	return result
+}
+
 func (r *PostgreSQLConnection) GetConnectionString() string {
 	return r.connection.String()
 }
 
 func (r *PostgreSQLConnection) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for key, db := range r.pools {
+		_ = db.Close()
+
+		delete(r.pools, key)
+	}
+
 	if err := r.db.Close(); err != nil {
 		return errors.NewCloseConnectionError(err)
 	}
@@ -287,10 +593,10 @@ func (r *PostgreSQLConnection) Check(ctx context.Context) error {
 	return nil
 }
 
-func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB) (bool, error) {
+func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB, schema string) (bool, error) {
 	var tableExists string
 
-	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, "public", "kine"); err != nil {
+	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, schema, "kine"); err != nil {
 		return false, err
 	}
 
@@ -310,3 +616,213 @@ func quotePostgreSQLIdentifier(identifier string) string {
 
 	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
 }
+
+// tenantDB returns the pool of connections to the storage of the given tenant: either its own database,
+// or the shared one with the search_path set to its schema.
+func (r *PostgreSQLConnection) tenantDB(name string) *pg.DB {
+	if r.sharedDatabase != "" {
+		return r.pool(postgresqlPoolKey{schema: true, name: name})
+	}
+
+	return r.database(name)
+}
+
+// database returns the pool of connections to the given database.
+func (r *PostgreSQLConnection) database(name string) *pg.DB {
+	return r.pool(postgresqlPoolKey{name: name})
+}
+
+// pool returns the pool of connections for the given key, connecting it upon the first use:
+// the returned pool must not be closed by the caller.
+func (r *PostgreSQLConnection) pool(key postgresqlPoolKey) *pg.DB {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if db, found := r.pools[key]; found {
+		return db
+	}
+
+	if r.pools == nil {
+		r.pools = make(map[postgresqlPoolKey]*pg.DB)
+	}
+
+	connect := r.switchDatabaseFn
+	if key.schema {
+		connect = r.switchSchemaFn
+	}
+
+	r.pools[key] = connect(key.name)
+
+	return r.pools[key]
+}
+
+// closePool closes the pool of connections for the given key, if any.
+func (r *PostgreSQLConnection) closePool(key postgresqlPoolKey) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if db, found := r.pools[key]; found {
+		_ = db.Close()
+
+		delete(r.pools, key)
+	}
+}
+
+func (r *PostgreSQLConnection) Checkpoint(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (int64, error) {
+	dbConn := r.tenantDB(tcp.Status.Storage.Setup.Schema)
+
+	var revision int64
+
+	if _, err := dbConn.QueryOneContext(ctx, pg.Scan(&revision), postgresqlKineMaxRevisionStatement); err != nil {
+		return 0, err
+	}
+
+	return revision, nil
+}
+
+func (r *PostgreSQLConnection) Replicate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string, fromRevision int64) (int64, int, error) {
+	originConn := r.tenantDB(tcp.Status.Storage.Setup.Schema)
+
+	rows, err := fetchKineChanges(ctx, fromRevision, func(ctx context.Context, fromRevision int64) ([]kineRow, error) {
+		var rows []kineRow
+
+		_, err := originConn.QueryContext(ctx, &rows, postgresqlKineChangesStatement, fromRevision, kineReplicationBatchSize)
+
+		return rows, err
+	})
+	if err != nil {
+		return fromRevision, 0, fmt.Errorf("unable to retrieve changes from the origin datastore: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return fromRevision, 0, nil
+	}
+
+	targetConn := unwrap(target).(*PostgreSQLConnection).tenantDB(targetSchema) //nolint:forcetypeassert
+
+	err = targetConn.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		for _, row := range rows {
+			if _, err := tx.ExecContext(ctx, postgresqlKineInsertStatement, row.ID, row.Name, row.Created, row.Deleted, row.CreateRevision, row.PrevRevision, row.Lease, row.Value, row.OldValue); err != nil {
+				return err
+			}
+		}
+		// Rows are inserted with their origin ID, which is the kine revision:
+		// the sequence must be aligned, otherwise kine would generate colliding IDs upon cutover.
+		if _, err := tx.ExecContext(ctx, postgresqlKineAlignSequenceStatement); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fromRevision, 0, fmt.Errorf("unable to apply changes to the target datastore: %w", err)
+	}
+
+	return rows[len(rows)-1].ID, len(rows), nil
+}
+
+func (r *PostgreSQLConnection) Export(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, fn func(kv KeyValue) error) error {
+	conn := r.tenantDB(tcp.Status.Storage.Setup.Schema)
+
+	for cursor := ""; ; {
+		var rows []kineRow
+
+		if _, err := conn.QueryContext(ctx, &rows, postgresqlKineLatestStatement, cursor, keyValueBatchSize); err != nil {
+			return fmt.Errorf("unable to retrieve keys from the datastore: %w", err)
+		}
+
+		for _, row := range rows {
+			key, ok := fromKineKey(row.Name)
+			if !ok || row.Deleted != 0 {
+				continue
+			}
+
+			if err := fn(KeyValue{Key: key, Value: row.Value}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < keyValueBatchSize {
+			return nil
+		}
+
+		cursor = rows[len(rows)-1].Name
+	}
+}
+
+func (r *PostgreSQLConnection) Truncate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) error {
+	if ok, _ := r.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
+		return nil
+	}
+
+	conn := r.tenantDB(tcp.Status.Storage.Setup.Schema)
+
+	return conn.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		for _, stm := range append(postgresqlKineSchemaStatements, `TRUNCATE TABLE kine`) {
+			if _, err := tx.ExecContext(ctx, stm); err != nil {
+				return fmt.Errorf("unable to truncate the kine table: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *PostgreSQLConnection) Import(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, kvs []KeyValue) error {
+	if ok, _ := r.DBExists(ctx, tcp.Status.Storage.Setup.Schema); !ok {
+		if err := r.CreateDB(ctx, tcp.Status.Storage.Setup.Schema); err != nil {
+			return err
+		}
+	}
+
+	conn := r.tenantDB(tcp.Status.Storage.Setup.Schema)
+
+	return conn.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		for _, stm := range postgresqlKineSchemaStatements {
+			if _, err := tx.ExecContext(ctx, stm); err != nil {
+				return fmt.Errorf("unable to perform schema creation: %w", err)
+			}
+		}
+
+		for _, kv := range kvs {
+			if _, err := tx.ExecContext(ctx, postgresqlKineCreateStatement, toKineKey(kv.Key), kv.Value); err != nil {
+				return fmt.Errorf("unable to import key %s: %w", kv.Key, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *PostgreSQLConnection) Usage(ctx context.Context, dbName string) (Usage, error) {
+	if r.sharedDatabase != "" {
+		return r.schemaUsage(ctx, dbName)
+	}
+
+	var usage Usage
+
+	if _, err := r.db.QueryOneContext(ctx, pg.Scan(&usage.Bytes), postgresqlDatabaseSizeStatement, dbName); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	dbConn := r.database(dbName)
+	// The kine table is created by the kine process upon its first start.
+	tableExists, err := r.kineTableExists(ctx, dbConn, postgresqlPublicSchema)
+	if err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	if !tableExists {
+		return usage, nil
+	}
+
+	if _, err = dbConn.QueryOneContext(ctx, pg.Scan(&usage.Keys), postgresqlKineRowsStatement); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	if _, err = dbConn.QueryOneContext(ctx, pg.Scan(&usage.LiveBytes), postgresqlKineLiveBytesStatement); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	return usage, nil
+}
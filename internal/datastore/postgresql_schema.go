@@ -0,0 +1,215 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+
+	"github.com/clastix/kamaji/internal/datastore/errors"
+)
+
+// The statements below implement the Schema layout, where each tenant is stored in its own schema,
+// owned by the tenant role, of a database shared among the tenants: the same identifier quoting rules apply.
+const (
+	postgresqlFetchSchemaStatement              = "SELECT FROM pg_namespace WHERE nspname = ?"
+	postgresqlCreateSchemaStatement             = `CREATE SCHEMA %s`
+	postgresqlRevokePublicPrivilegesStatement   = `REVOKE ALL PRIVILEGES ON DATABASE %s FROM PUBLIC`
+	postgresqlShowConnectGrantsStatement        = "SELECT has_database_privilege(rolname, ?, 'connect') from pg_roles where rolcanlogin and rolname = ?"
+	postgresqlShowSchemaOwnershipStatement      = "SELECT 't' FROM pg_catalog.pg_namespace AS n WHERE n.nspname = ? AND pg_catalog.pg_get_userbyid(n.nspowner) = ?"
+	postgresqlShowSchemaTableOwnershipStatement = "SELECT 't' from pg_tables where tableowner = ? AND schemaname = ? AND tablename = ?"
+	postgresqlGrantConnectStatement             = `GRANT CONNECT ON DATABASE %s TO %s`
+	postgresqlChangeSchemaOwnerStatement        = `ALTER SCHEMA %s OWNER TO %s`
+	postgresqlChangeSchemaTableOwnerStatement   = `ALTER TABLE %s.kine OWNER TO %s`
+	postgresqlDropSchemaStatement               = `DROP SCHEMA %s CASCADE`
+	postgresqlListSchemasStatement              = "SELECT schemaname FROM pg_tables WHERE tablename = 'kine' AND schemaname <> 'public'"
+	postgresqlSchemaSizeStatement               = "SELECT COALESCE(SUM(pg_total_relation_size(c.oid)), 0) FROM pg_class AS c JOIN pg_namespace AS n ON n.oid = c.relnamespace WHERE n.nspname = ? AND c.relkind = 'r'"
+)
+
+func (r *PostgreSQLConnection) sharedDBExists(ctx context.Context) (bool, error) {
+	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, r.sharedDatabase)
+	if err != nil {
+		return false, err
+	}
+
+	return rows.RowsReturned() > 0, nil
+}
+
+func (r *PostgreSQLConnection) schemaExists(ctx context.Context, schema string) (bool, error) {
+	exists, err := r.sharedDBExists(ctx)
+	if err != nil {
+		return false, errors.NewCheckDatabaseExistError(err)
+	}
+
+	if !exists {
+		return false, nil
+	}
+
+	dbConn := r.database(r.sharedDatabase)
+
+	rows, err := dbConn.ExecContext(ctx, postgresqlFetchSchemaStatement, schema)
+	if err != nil {
+		return false, errors.NewCheckDatabaseExistError(err)
+	}
+
+	return rows.RowsReturned() > 0, nil
+}
+
+// createSchema creates the shared database if missing, preventing the tenant roles from connecting unless granted.
+func (r *PostgreSQLConnection) createSchema(ctx context.Context, schema string) error {
+	exists, err := r.sharedDBExists(ctx)
+	if err != nil {
+		return errors.NewCreateDBError(err)
+	}
+
+	if !exists {
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateDBStatement, quotePostgreSQLIdentifier(r.sharedDatabase))); err != nil {
+			return errors.NewCreateDBError(err)
+		}
+
+		if _, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlRevokePublicPrivilegesStatement, quotePostgreSQLIdentifier(r.sharedDatabase))); err != nil {
+			return errors.NewCreateDBError(err)
+		}
+	}
+
+	dbConn := r.database(r.sharedDatabase)
+
+	if _, err = dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlCreateSchemaStatement, quotePostgreSQLIdentifier(schema))); err != nil {
+		return errors.NewCreateDBError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) schemaGrantPrivilegesExists(ctx context.Context, user, schema string) (bool, error) {
+	var hasConnectPrivilege string
+
+	_, err := r.db.QueryContext(ctx, pg.Scan(&hasConnectPrivilege), postgresqlShowConnectGrantsStatement, r.sharedDatabase, user)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return false, nil
+		}
+
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	dbConn := r.database(r.sharedDatabase)
+
+	var isOwner string
+
+	if _, err = dbConn.QueryContext(ctx, pg.Scan(&isOwner), postgresqlShowSchemaOwnershipStatement, schema, user); err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, dbConn, schema)
+	if err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	if !tableExists {
+		return hasConnectPrivilege == "t" && isOwner == "t", nil
+	}
+
+	var isTableOwner string
+
+	if _, err = dbConn.QueryContext(ctx, pg.Scan(&isTableOwner), postgresqlShowSchemaTableOwnershipStatement, user, schema, "kine"); err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	return hasConnectPrivilege == "t" && isOwner == "t" && isTableOwner == "t", nil
+}
+
+// grantSchemaPrivileges lets the role connect to the shared database, handing over the schema ownership:
+// the role cannot access the schemas of the other tenants, since it's not granted their usage.
+func (r *PostgreSQLConnection) grantSchemaPrivileges(ctx context.Context, user, schema string) error {
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlGrantConnectStatement, quotePostgreSQLIdentifier(r.sharedDatabase), quotePostgreSQLIdentifier(user))); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	dbConn := r.database(r.sharedDatabase)
+
+	if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlChangeSchemaOwnerStatement, quotePostgreSQLIdentifier(schema), quotePostgreSQLIdentifier(user))); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, dbConn, schema)
+	if err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	if tableExists {
+		if _, err = dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlChangeSchemaTableOwnerStatement, quotePostgreSQLIdentifier(schema), quotePostgreSQLIdentifier(user))); err != nil {
+			return errors.NewGrantPrivilegesError(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) dropSchema(ctx context.Context, schema string) error {
+	dbConn := r.database(r.sharedDatabase)
+
+	if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlDropSchemaStatement, quotePostgreSQLIdentifier(schema))); err != nil {
+		return errors.NewCannotDeleteDatabaseError(err)
+	}
+
+	r.closePool(postgresqlPoolKey{schema: true, name: schema})
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) listSchemas(ctx context.Context) ([]string, error) {
+	exists, err := r.sharedDBExists(ctx)
+	if err != nil {
+		return nil, errors.NewListDatabasesError(err)
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	dbConn := r.database(r.sharedDatabase)
+
+	var schemas []string
+
+	if _, err = dbConn.QueryContext(ctx, &schemas, postgresqlListSchemasStatement); err != nil {
+		return nil, errors.NewListDatabasesError(err)
+	}
+
+	return schemas, nil
+}
+
+func (r *PostgreSQLConnection) schemaUsage(ctx context.Context, schema string) (Usage, error) {
+	dbConn := r.database(r.sharedDatabase)
+
+	var usage Usage
+
+	if _, err := dbConn.QueryOneContext(ctx, pg.Scan(&usage.Bytes), postgresqlSchemaSizeStatement, schema); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, dbConn, schema)
+	if err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	if !tableExists {
+		return usage, nil
+	}
+
+	tenantConn := r.tenantDB(schema)
+
+	if _, err = tenantConn.QueryOneContext(ctx, pg.Scan(&usage.Keys), postgresqlKineRowsStatement); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	if _, err = tenantConn.QueryOneContext(ctx, pg.Scan(&usage.LiveBytes), postgresqlKineLiveBytesStatement); err != nil {
+		return Usage{}, errors.NewRetrieveUsageError(err)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"testing"
+)
+
+func TestPostgreSQLTenantDB(t *testing.T) {
+	config := ConnectionConfig{User: "kamaji", Endpoints: []ConnectionEndpoint{{Host: "postgresql", Port: 5432}}}
+
+	connection, err := NewPostgreSQLConnection(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer connection.Close()
+	// Each tenant is stored in its own database.
+	db := connection.(*PostgreSQLConnection).tenantDB("tenant") //nolint:forcetypeassert
+
+	if options := db.Options(); options.Database != "tenant" || options.OnConnect != nil {
+		t.Fatalf("expected the tenant database, got %s", options.Database)
+	}
+
+	config.SharedDatabase = "kamaji"
+
+	shared, err := NewPostgreSQLConnection(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer shared.Close()
+	// Each tenant is stored in its own schema of the shared database, set as search_path upon connection.
+	db = shared.(*PostgreSQLConnection).tenantDB("tenant") //nolint:forcetypeassert
+
+	if options := db.Options(); options.Database != "kamaji" || options.OnConnect == nil {
+		t.Fatalf("expected the shared database, got %s", options.Database)
+	}
+}
+
+func TestPostgreSQLTenantPools(t *testing.T) {
+	connection, err := NewPostgreSQLConnection(ConnectionConfig{User: "kamaji", Endpoints: []ConnectionEndpoint{{Host: "postgresql", Port: 5432}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := connection.(*PostgreSQLConnection) //nolint:forcetypeassert
+	// The pool of the tenant database is kept across the operations.
+	db := r.tenantDB("tenant")
+	if r.tenantDB("tenant") != db || r.database("tenant") != db {
+		t.Fatal("expected the pool of the tenant database to be kept")
+	}
+
+	if r.tenantDB("other") == db {
+		t.Fatal("expected a pool per database")
+	}
+	// Closing the pool, such as before dropping the database, connects a new one upon the next use.
+	r.closePool(postgresqlPoolKey{name: "tenant"})
+
+	if r.tenantDB("tenant") == db {
+		t.Fatal("expected the closed pool to be replaced")
+	}
+
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.pools) != 0 {
+		t.Fatalf("expected the pools to be closed along with the connection, got %d", len(r.pools))
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// ChangeReplicator is implemented by the Connection drivers able to tail the changes occurred
+// on the tenant keyspace after the bulk copy performed by Migrate, allowing a live migration
+// where the Tenant Control Plane is kept writable for the whole copy.
+//
+// The revision is driver specific: the etcd store revision, the kine table row ID for the
+// SQL drivers, or the JetStream sequence of the NATS KV bucket.
+type ChangeReplicator interface {
+	// Checkpoint returns the latest revision of the tenant keyspace on the origin DataStore.
+	Checkpoint(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane) (int64, error)
+	// Replicate applies to the target DataStore schema the changes occurred on the origin after the
+	// given revision, returning the revision up to which the changes have been replicated,
+	// along with the number of applied changes: zero means there's no replication lag.
+	Replicate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection, targetSchema string, fromRevision int64) (int64, int, error)
+}
+
+// LiveMigrationOptions tunes the change replication loop performed by LiveMigrate.
+type LiveMigrationOptions struct {
+	// PollInterval is the amount of time to wait between two replication rounds.
+	PollInterval time.Duration
+	// LagThreshold is the number of changes replicated by a round below which the replication is considered in sync:
+	// a live tenant keeps writing, such as the Node and components leases, thus a round without changes could never occur.
+	LagThreshold int
+	// MaxRounds is the number of replication rounds after which the replication is considered in sync,
+	// regardless of the lag: zero meaning no limit.
+	MaxRounds int
+	// CutoverFn is invoked once the replication is in sync: it's expected to return once the
+	// Tenant Control Plane has been frozen, so the remaining changes can be drained.
+	CutoverFn func(ctx context.Context) error
+	// ReplicationTimeout limits the bulk copy and the replication preceding the cutover, zero meaning no limit.
+	ReplicationTimeout time.Duration
+	// CutoverTimeout limits the time the Tenant Control Plane is frozen, from the cutover request to the drain
+	// of the remaining changes, zero meaning no limit.
+	CutoverTimeout time.Duration
+	// MaxDrainRounds is the number of replication rounds after which the drain of the remaining changes is failed,
+	// if the origin revision at the freeze time has not been reached yet: zero meaning no limit.
+	MaxDrainRounds int
+	Logger         logr.Logger
+}
+
+// LiveMigrate copies the tenant keyspace from the origin to the target DataStore without requiring
+// the Tenant Control Plane to be frozen for the whole copy: the bulk copy is performed by Migrate,
+// then the changes are replicated until the lag reaches zero, and only at that point the cutover
+// function is invoked to freeze the tenant and drain the latest changes.
+func LiveMigrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, origin, target Connection, opts LiveMigrationOptions) error {
+	replicator, ok := unwrap(origin).(ChangeReplicator)
+	if !ok {
+		return fmt.Errorf("the %s driver doesn't support live migration", origin.Driver())
+	}
+
+	schema := tcp.Status.Storage.Setup.Schema
+
+	revision, err := bulkCopyAndReplicate(ctx, tcp, origin, replicator, target, schema, opts)
+	if err != nil {
+		return err
+	}
+
+	opts.Logger.Info("replication in sync, requesting cutover", "revision", revision)
+
+	if opts.CutoverTimeout > 0 {
+		var cancelFn context.CancelFunc
+
+		ctx, cancelFn = context.WithTimeout(ctx, opts.CutoverTimeout)
+		defer cancelFn()
+	}
+
+	if err = opts.CutoverFn(ctx); err != nil {
+		return fmt.Errorf("unable to perform cutover: %w", err)
+	}
+
+	// The writes not blocked by the freeze webhook keep occurring, such as the Node and leader election leases renewals:
+	// the drain is bounded to the origin revision at the freeze time, the following changes being discarded upon the cutover.
+	drainRevision, err := replicator.Checkpoint(ctx, tcp)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the origin checkpoint upon cutover: %w", err)
+	}
+
+	opts.Logger.Info("cutover in place, draining remaining changes", "revision", drainRevision)
+
+	if revision, err = replicateUntilRevision(ctx, tcp, replicator, target, schema, revision, drainRevision, opts.PollInterval, opts.MaxDrainRounds, opts.Logger); err != nil {
+		return err
+	}
+
+	opts.Logger.Info("remaining changes drained", "revision", revision)
+
+	return nil
+}
+
+// LiveClone copies the keyspace of the source Tenant Control Plane into the clone one, on a DataStore backed by
+// the same driver: as for LiveMigrate, the bulk copy is followed by the changes replication, although no cutover
+// is required since the source is kept running. Once the replication is in sync, according to the lag threshold
+// and the maximum rounds, the clone keyspace is consistent with the source one at the latest replicated revision.
+func LiveClone(ctx context.Context, source, clone kamajiv1alpha1.TenantControlPlane, origin, target Connection, opts LiveMigrationOptions) error {
+	replicator, ok := unwrap(origin).(ChangeReplicator)
+	if !ok {
+		return fmt.Errorf("the %s driver doesn't support live cloning", origin.Driver())
+	}
+
+	revision, err := bulkCopyAndReplicate(ctx, source, origin, replicator, target, clone.Status.Storage.Setup.Schema, opts)
+	if err != nil {
+		return err
+	}
+
+	opts.Logger.Info("replication in sync, clone completed", "revision", revision)
+
+	return nil
+}
+
+func bulkCopyAndReplicate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, origin Connection, replicator ChangeReplicator, target Connection, targetSchema string, opts LiveMigrationOptions) (int64, error) {
+	if opts.ReplicationTimeout > 0 {
+		var cancelFn context.CancelFunc
+
+		ctx, cancelFn = context.WithTimeout(ctx, opts.ReplicationTimeout)
+		defer cancelFn()
+	}
+
+	revision, err := replicator.Checkpoint(ctx, tcp)
+	if err != nil {
+		return 0, fmt.Errorf("unable to retrieve the origin checkpoint: %w", err)
+	}
+
+	opts.Logger.Info("bulk copy started", "revision", revision)
+
+	if err = origin.Migrate(ctx, tcp, target, targetSchema); err != nil {
+		return revision, fmt.Errorf("unable to perform bulk copy: %w", err)
+	}
+
+	opts.Logger.Info("bulk copy completed, replicating changes")
+
+	return replicateUntilSynced(ctx, tcp, replicator, target, targetSchema, revision, opts.PollInterval, opts.LagThreshold, opts.MaxRounds, opts.Logger)
+}
+
+// replicateUntilSynced replicates the changes until a round applies no more than the lag threshold,
+// or the maximum number of rounds is reached, if any.
+func replicateUntilSynced(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, replicator ChangeReplicator, target Connection, targetSchema string, revision int64, pollInterval time.Duration, lagThreshold, maxRounds int, logger logr.Logger) (int64, error) {
+	for round := 1; ; round++ {
+		from := revision
+
+		replicated, changes, err := replicator.Replicate(ctx, tcp, target, targetSchema, from)
+		if err != nil {
+			return revision, fmt.Errorf("unable to replicate changes: %w", err)
+		}
+
+		revision = replicated
+
+		if changes == 0 || changes <= lagThreshold || maxRounds > 0 && round >= maxRounds {
+			return revision, nil
+		}
+
+		logger.V(1).Info("changes replicated", "from", from, "to", revision, "changes", changes)
+
+		select {
+		case <-ctx.Done():
+			return revision, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// replicateUntilRevision replicates the changes until the given revision is reached,
+// failing once the maximum number of rounds is reached, if any.
+func replicateUntilRevision(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, replicator ChangeReplicator, target Connection, targetSchema string, revision, untilRevision int64, pollInterval time.Duration, maxRounds int, logger logr.Logger) (int64, error) {
+	for round := 1; revision < untilRevision; round++ {
+		if maxRounds > 0 && round > maxRounds {
+			return revision, fmt.Errorf("unable to replicate changes up to revision %d within %d rounds, replicated up to %d", untilRevision, maxRounds, revision)
+		}
+
+		from := revision
+
+		replicated, changes, err := replicator.Replicate(ctx, tcp, target, targetSchema, from)
+		if err != nil {
+			return revision, fmt.Errorf("unable to replicate changes: %w", err)
+		}
+
+		revision = replicated
+
+		logger.V(1).Info("changes replicated", "from", from, "to", revision, "changes", changes)
+		// A round could stop before the given revision, such as waiting for the kine row ID gaps.
+		if revision >= untilRevision {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return revision, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,372 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-logr/logr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// fakeReplicator simulates an origin DataStore receiving writes during the migration:
+// pending holds the number of changes still to be replicated for each round, then steady ones keep occurring.
+type fakeReplicator struct {
+	Connection
+
+	revision     int64
+	pending      []int
+	steady       int
+	rounds       int
+	migrated     bool
+	bulkDeadline bool
+	cutoverAt    int64
+	replicated   int64
+	targetSchema string
+}
+
+func (f *fakeReplicator) Migrate(ctx context.Context, _ kamajiv1alpha1.TenantControlPlane, _ Connection, targetSchema string) error {
+	f.migrated = true
+	_, f.bulkDeadline = ctx.Deadline()
+	f.targetSchema = targetSchema
+
+	return nil
+}
+
+// Checkpoint returns the revision reached by the next round, once the bulk copy has been performed.
+func (f *fakeReplicator) Checkpoint(context.Context, kamajiv1alpha1.TenantControlPlane) (int64, error) {
+	if !f.migrated {
+		return f.revision, nil
+	}
+
+	return f.replicated + int64(f.next()), nil
+}
+
+func (f *fakeReplicator) next() int {
+	if len(f.pending) == 0 {
+		return f.steady
+	}
+
+	return f.pending[0]
+}
+
+func (f *fakeReplicator) Replicate(_ context.Context, _ kamajiv1alpha1.TenantControlPlane, _ Connection, targetSchema string, fromRevision int64) (int64, int, error) {
+	if targetSchema != f.targetSchema {
+		return fromRevision, 0, fmt.Errorf("changes replicated to %s, bulk copy performed to %s", targetSchema, f.targetSchema)
+	}
+
+	f.rounds++
+
+	changes := f.next()
+	if len(f.pending) > 0 {
+		f.pending = f.pending[1:]
+	}
+
+	f.replicated = fromRevision + int64(changes)
+
+	return f.replicated, changes, nil
+}
+
+func TestLiveMigrateCutoverOnceSynced(t *testing.T) {
+	origin := &fakeReplicator{revision: 10, pending: []int{5, 2, 0, 3}}
+
+	err := LiveMigrate(context.Background(), kamajiv1alpha1.TenantControlPlane{}, origin, nil, LiveMigrationOptions{
+		CutoverFn: func(context.Context) error {
+			origin.cutoverAt = origin.replicated
+
+			return nil
+		},
+		Logger: logr.Discard(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !origin.migrated {
+		t.Fatal("bulk copy has not been performed")
+	}
+	// The cutover must be requested only once the replication lag is zero.
+	if origin.cutoverAt != 17 {
+		t.Fatalf("cutover requested at revision %d, expected 17", origin.cutoverAt)
+	}
+	// Changes occurred while freezing the tenant must be drained.
+	if origin.replicated != 20 {
+		t.Fatalf("replicated up to revision %d, expected 20", origin.replicated)
+	}
+}
+
+func TestLiveMigrateCutoverWithinLagThreshold(t *testing.T) {
+	for name, tc := range map[string]struct {
+		pending      []int
+		lagThreshold int
+		maxRounds    int
+		cutoverAt    int64
+		replicated   int64
+	}{
+		// The Node and components leases keep writing: a round without changes could never occur.
+		"lag below the threshold": {pending: []int{5, 3, 1, 4, 0}, lagThreshold: 2, cutoverAt: 19, replicated: 23},
+		"maximum rounds reached":  {pending: []int{5, 5, 5, 5, 0}, lagThreshold: 2, maxRounds: 2, cutoverAt: 20, replicated: 25},
+	} {
+		origin := &fakeReplicator{revision: 10, pending: tc.pending}
+
+		err := LiveMigrate(context.Background(), kamajiv1alpha1.TenantControlPlane{}, origin, nil, LiveMigrationOptions{
+			LagThreshold: tc.lagThreshold,
+			MaxRounds:    tc.maxRounds,
+			CutoverFn: func(context.Context) error {
+				origin.cutoverAt = origin.replicated
+
+				return nil
+			},
+			Logger: logr.Discard(),
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if origin.cutoverAt != tc.cutoverAt {
+			t.Fatalf("%s: cutover requested at revision %d, expected %d", name, origin.cutoverAt, tc.cutoverAt)
+		}
+		// Once frozen, the changes up to the freeze revision must be drained, regardless of the threshold.
+		if origin.replicated != tc.replicated {
+			t.Fatalf("%s: replicated up to revision %d, expected %d", name, origin.replicated, tc.replicated)
+		}
+	}
+}
+
+func TestLiveMigrateDrainWithLeaseWrites(t *testing.T) {
+	// The leases renewals are not blocked by the freeze webhook, thus a round without changes never occurs.
+	origin := &fakeReplicator{revision: 10, pending: []int{5, 2}, steady: 2}
+
+	err := LiveMigrate(context.Background(), kamajiv1alpha1.TenantControlPlane{}, origin, nil, LiveMigrationOptions{
+		LagThreshold: 2,
+		CutoverFn: func(context.Context) error {
+			origin.cutoverAt = origin.replicated
+
+			return nil
+		},
+		CutoverTimeout: time.Second,
+		MaxDrainRounds: 3,
+		Logger:         logr.Discard(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if origin.cutoverAt != 17 {
+		t.Fatalf("cutover requested at revision %d, expected 17", origin.cutoverAt)
+	}
+	// The drain stops at the origin revision at the freeze time, the following lease writes being discarded.
+	if origin.replicated != 19 || origin.rounds != 3 {
+		t.Fatalf("replicated up to revision %d in %d rounds, expected 19 in 3 rounds", origin.replicated, origin.rounds)
+	}
+}
+
+func TestLiveMigrateDrainMaxRounds(t *testing.T) {
+	// The origin revision is never reached, such as waiting for a kine row ID gap.
+	stalled := &stalledReplicator{fakeReplicator: &fakeReplicator{revision: 10}}
+
+	err := LiveMigrate(context.Background(), kamajiv1alpha1.TenantControlPlane{}, stalled, nil, LiveMigrationOptions{
+		PollInterval:   time.Millisecond,
+		CutoverFn:      func(context.Context) error { return nil },
+		MaxDrainRounds: 3,
+		Logger:         logr.Discard(),
+	})
+	if err == nil {
+		t.Fatal("expected the drain to fail once the maximum rounds are reached")
+	}
+	// A round is performed before the cutover, the following ones are the drain.
+	if stalled.rounds != 4 {
+		t.Fatalf("performed %d replication rounds, expected 4", stalled.rounds)
+	}
+}
+
+type stalledReplicator struct {
+	*fakeReplicator
+}
+
+func (s *stalledReplicator) Checkpoint(context.Context, kamajiv1alpha1.TenantControlPlane) (int64, error) {
+	if !s.migrated {
+		return s.revision, nil
+	}
+
+	return s.revision + 1, nil
+}
+
+func (s *stalledReplicator) Replicate(_ context.Context, _ kamajiv1alpha1.TenantControlPlane, _ Connection, _ string, fromRevision int64) (int64, int, error) {
+	s.rounds++
+
+	return fromRevision, 0, nil
+}
+
+func TestLiveMigrateReplicationTimeout(t *testing.T) {
+	pending := make([]int, 1000)
+	for i := range pending {
+		pending[i] = 10
+	}
+
+	origin := &fakeReplicator{revision: 10, pending: pending}
+
+	err := LiveMigrate(context.Background(), kamajiv1alpha1.TenantControlPlane{}, origin, nil, LiveMigrationOptions{
+		PollInterval: 10 * time.Millisecond,
+		CutoverFn: func(context.Context) error {
+			return fmt.Errorf("the cutover must not be requested")
+		},
+		ReplicationTimeout: 50 * time.Millisecond,
+		Logger:             logr.Discard(),
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the replication to time out, got %v", err)
+	}
+}
+
+func TestLiveMigrateCutoverTimeout(t *testing.T) {
+	origin := &fakeReplicator{revision: 10, pending: []int{5, 0, 3}}
+
+	err := LiveMigrate(context.Background(), kamajiv1alpha1.TenantControlPlane{}, origin, nil, LiveMigrationOptions{
+		CutoverFn: func(ctx context.Context) error {
+			if _, ok := ctx.Deadline(); !ok {
+				return fmt.Errorf("expected the cutover to be limited by the timeout")
+			}
+
+			return nil
+		},
+		CutoverTimeout: time.Minute,
+		Logger:         logr.Discard(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The tenant is writable during the bulk copy, whose duration depends on the amount of data.
+	if origin.bulkDeadline {
+		t.Fatal("expected the bulk copy not to be limited by the cutover timeout")
+	}
+}
+
+func TestLiveCloneWithoutCutover(t *testing.T) {
+	origin := &fakeReplicator{revision: 10, pending: []int{5, 2, 0, 3}}
+
+	var source, clone kamajiv1alpha1.TenantControlPlane
+	source.Status.Storage.Setup.Schema, clone.Status.Storage.Setup.Schema = "default_prod", "default_rehearsal"
+
+	if err := LiveClone(context.Background(), source, clone, origin, nil, LiveMigrationOptions{Logger: logr.Discard()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if origin.targetSchema != "default_rehearsal" {
+		t.Fatalf("keyspace copied to %s, expected the clone schema", origin.targetSchema)
+	}
+	// The source is never frozen, the clone is consistent once the replication lag is zero.
+	if origin.replicated != 17 {
+		t.Fatalf("replicated up to revision %d, expected 17", origin.replicated)
+	}
+}
+
+func TestDriversSupportLiveMigration(t *testing.T) {
+	for _, origin := range []Connection{&EtcdClient{}, &PostgreSQLConnection{}, &MySQLConnection{}, &NATSConnection{}} {
+		if _, ok := origin.(ChangeReplicator); !ok {
+			t.Fatalf("%T is expected to support live migration", origin)
+		}
+	}
+}
+
+func TestMySQLReplicateSkipsRowsAlreadyCopied(t *testing.T) {
+	originDB, origin, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create the origin mock: %v", err)
+	}
+	defer originDB.Close()
+
+	targetDB, target, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create the target mock: %v", err)
+	}
+	defer targetDB.Close()
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	tcp.Status.Storage.Setup.Schema = "default_prod"
+	// The row 11 has been written during the bulk copy, thus already dumped to the target.
+	origin.ExpectQuery("SELECT (.+) FROM `default_prod`.kine WHERE id > \\?").
+		WithArgs(10, kineReplicationBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created", "deleted", "create_revision", "prev_revision", "lease", "value", "old_value"}).
+			AddRow(11, "/registry/leases/kube-node-lease/worker", 1, 0, 0, 0, 0, []byte("a"), nil).
+			AddRow(12, "/registry/leases/kube-node-lease/worker", 0, 0, 11, 11, 0, []byte("b"), []byte("a")))
+
+	target.ExpectBegin()
+	target.ExpectExec("INSERT INTO `default_migrated`.kine (.+) ON DUPLICATE KEY UPDATE id = id").
+		WithArgs(11, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	target.ExpectExec("INSERT INTO `default_migrated`.kine (.+) ON DUPLICATE KEY UPDATE id = id").
+		WithArgs(12, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	target.ExpectCommit()
+
+	revision, changes, err := (&MySQLConnection{db: originDB}).Replicate(context.Background(), tcp, &MySQLConnection{db: targetDB}, "default_migrated", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if revision != 12 || changes != 2 {
+		t.Fatalf("replicated up to revision %d with %d changes, expected 12 with 2 changes", revision, changes)
+	}
+
+	for _, mock := range []sqlmock.Sqlmock{origin, target} {
+		if err = mock.ExpectationsWereMet(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFetchKineChangesStopsAtGaps(t *testing.T) {
+	rowsWithIDs := func(ids ...int64) []kineRow {
+		rows := make([]kineRow, 0, len(ids))
+		for _, id := range ids {
+			rows = append(rows, kineRow{ID: id})
+		}
+
+		return rows
+	}
+
+	for name, tc := range map[string]struct {
+		reads    [][]kineRow
+		expected []int64
+	}{
+		"no gaps": {reads: [][]kineRow{rowsWithIDs(11, 12, 13)}, expected: []int64{11, 12, 13}},
+		// The row 13 belongs to a transaction still open: the next round will start from it.
+		"gap after the cursor": {reads: [][]kineRow{rowsWithIDs(11, 12, 14)}, expected: []int64{11, 12}},
+		// The row 11 is committed while waiting for it, it must not be skipped.
+		"gap filled": {reads: [][]kineRow{rowsWithIDs(12, 13), rowsWithIDs(11, 12, 13)}, expected: []int64{11, 12, 13}},
+	} {
+		var reads int
+
+		rows, err := fetchKineChanges(context.Background(), 10, func(_ context.Context, fromRevision int64) ([]kineRow, error) {
+			if fromRevision != 10 {
+				return nil, fmt.Errorf("changes read from revision %d, expected 10", fromRevision)
+			}
+
+			read := tc.reads[reads]
+			reads++
+
+			return read, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		ids := make([]int64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+
+		if !slices.Equal(ids, tc.expected) {
+			t.Fatalf("%s: fetched rows %v, expected %v", name, ids, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMySQLUsageReportsLiveBytes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create the mock: %v", err)
+	}
+	defer db.Close()
+	// The table size doesn't shrink upon deletions, while the live bytes account for the latest revisions only.
+	mock.ExpectQuery("SELECT (.+) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = \\?$").
+		WithArgs("default_prod").
+		WillReturnRows(sqlmock.NewRows([]string{"bytes", "rows"}).AddRow(4096, 42))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = \\? AND TABLE_NAME = 'kine'").
+		WithArgs("default_prod").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM `default_prod`.kine AS kv JOIN (.+) WHERE kv.deleted = 0").
+		WillReturnRows(sqlmock.NewRows([]string{"bytes"}).AddRow(512))
+
+	usage, err := (&MySQLConnection{db: db}).Usage(context.Background(), "default_prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := (Usage{Bytes: 4096, Keys: 42, LiveBytes: 512}); usage != expected {
+		t.Fatalf("expected usage %+v, got %+v", expected, usage)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMySQLUsageWithoutKineTable(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create the mock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = \\?$").
+		WithArgs("default_prod").
+		WillReturnRows(sqlmock.NewRows([]string{"bytes", "rows"}).AddRow(0, 0))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = \\? AND TABLE_NAME = 'kine'").
+		WithArgs("default_prod").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	usage, err := (&MySQLConnection{db: db}).Usage(context.Background(), "default_prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if usage != (Usage{}) {
+		t.Fatalf("expected no usage, got %+v", usage)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -20,3 +20,9 @@ type MissingValidIPError struct{}
 func (m MissingValidIPError) Error() string {
 	return "the actual resource doesn't have yet a valid IP address"
 }
+
+type CertificateAuthorityIssuanceInProgressError struct{}
+
+func (c CertificateAuthorityIssuanceInProgressError) Error() string {
+	return "cannot continue reconciliation, the Certificate Authority is still waiting to be signed by the external issuer"
+}
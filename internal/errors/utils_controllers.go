@@ -10,6 +10,7 @@ func ShouldReconcileErrorBeIgnored(err error) bool {
 		nonExposedLBErr   NonExposedLoadBalancerError
 		missingValidIPErr MissingValidIPError
 		migrationErr      MigrationInProcessError
+		caIssuanceErr     CertificateAuthorityIssuanceInProgressError
 	)
 
 	switch {
@@ -19,6 +20,8 @@ func ShouldReconcileErrorBeIgnored(err error) bool {
 		return true
 	case errors.As(err, &migrationErr):
 		return true
+	case errors.As(err, &caIssuanceErr):
+		return true
 	default:
 		return false
 	}
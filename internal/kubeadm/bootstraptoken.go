@@ -4,6 +4,7 @@
 package kubeadm
 
 import (
+	"bytes"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -48,7 +49,7 @@ func BootstrapToken(client kubernetes.Interface, config *Configuration) error {
 		Clusters: map[string]*clientcmdapi.Cluster{
 			"": {
 				Server:                   config.Kubeconfig.Clusters[0].Cluster.Server,
-				CertificateAuthorityData: config.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData,
+				CertificateAuthorityData: clusterInfoCertificateAuthorityData(config),
 			},
 		},
 	}
@@ -76,3 +77,15 @@ func BootstrapToken(client kubernetes.Interface, config *Configuration) error {
 
 	return nil
 }
+
+// clusterInfoCertificateAuthorityData returns the Certificate Authority of the kubeconfig, followed by the trust bundle
+// unless it's already contained: during a Graceful rotation, the joining nodes are trusting both Certificate Authorities.
+func clusterInfoCertificateAuthorityData(config *Configuration) []byte {
+	ca := config.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData
+
+	if len(config.TrustBundle) == 0 || bytes.Contains(ca, bytes.TrimSpace(config.TrustBundle)) {
+		return ca
+	}
+
+	return bytes.Join([][]byte{bytes.TrimSpace(ca), bytes.TrimSpace(config.TrustBundle), nil}, []byte("\n"))
+}
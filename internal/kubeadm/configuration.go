@@ -69,6 +69,10 @@ func CreateKubeadmInitConfiguration(params Parameters) (*Configuration, error) {
 		{Name: "etcd-prefix", Value: fmt.Sprintf("/%s", params.TenantControlPlaneName)},
 	}
 	conf.ClusterName = params.TenantControlPlaneName
+	// The kubeadm default is retained when no key algorithm has been declared.
+	if params.EncryptionAlgorithm != "" {
+		conf.EncryptionAlgorithm = params.EncryptionAlgorithm
+	}
 
 	return &Configuration{InitConfiguration: *conf}, nil
 }
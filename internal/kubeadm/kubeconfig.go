@@ -45,7 +45,21 @@ func CreateKubeconfig(kubeconfigName string, ca CertificatePrivateKeyPair, confi
 
 	path := filepath.Join(config.InitConfiguration.CertificatesDir, kubeconfigName)
 
-	return os.ReadFile(path)
+	kubeconfigBytes, err := os.ReadFile(path)
+	if err != nil || len(ca.Bundle) == 0 {
+		return kubeconfigBytes, err
+	}
+	// kubeadm only encodes the Certificate Authority certificate, the clients must be aware of the whole chain.
+	kc, err := utilities.DecodeKubeconfigYAML(kubeconfigBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range kc.Clusters {
+		kc.Clusters[i].Cluster.CertificateAuthorityData = ca.Bundle
+	}
+
+	return utilities.EncodeToYaml(kc)
 }
 
 func IsKubeconfigCAValid(in, caCrt []byte) bool {
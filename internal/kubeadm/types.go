@@ -15,6 +15,9 @@ type Configuration struct {
 	InitConfiguration kubeadmapi.InitConfiguration
 	Kubeconfig        clientcmdapiv1.Config
 	Parameters        Parameters
+	// TrustBundle contains the Certificate Authorities trusted by the Tenant Control Plane,
+	// published along with the kubeconfig Certificate Authority in the cluster-info ConfigMap.
+	TrustBundle []byte
 }
 
 func (c *Configuration) Checksum() string {
@@ -46,6 +49,7 @@ type Parameters struct {
 	TenantControlPlaneCGroupDriver  string
 	ETCDs                           []string
 	CertificatesDir                 string
+	EncryptionAlgorithm             kubeadmapi.EncryptionAlgorithmType
 	KubeconfigDir                   string
 	KubeProxyOptions                *AddonOptions
 	CoreDNSOptions                  *AddonOptions
@@ -66,6 +70,9 @@ type CertificatePrivateKeyPair struct {
 	Name        string
 	Certificate []byte
 	PrivateKey  []byte
+	// Bundle is the certificate followed by the chain of the external Certificate Authority it's issued by, if any:
+	// it's the Certificate Authority data of the generated kubeconfig files.
+	Bundle []byte
 }
 
 type PublicKeyPrivateKeyPair struct {
@@ -39,6 +39,9 @@ const (
 	CertificateStrategyX509       = "x509"
 	CertificateStrategyKubeconfig = "kubeconfig"
 
+	ConnectionStateInUse = "in_use"
+	ConnectionStateIdle  = "idle"
+
 	kamajiNamespace = "kamaji"
 
 	buildSubsystem              = "build"
@@ -52,6 +55,13 @@ const (
 	metricNameStatus  = "status"
 	metricNameCurrent = "current"
 
+	metricNameDataStoreBytes = "datastore_bytes"
+	metricNameDataStoreKeys  = "datastore_keys"
+
+	metricNameEndpointUp            = "endpoint_up"
+	metricNameEndpointProbeDuration = "endpoint_probe_duration_seconds"
+	metricNameConnections           = "connections"
+
 	labelTCPNamespace     = "tcp_namespace"
 	labelTCPName          = "tcp_name"
 	labelKubernetesVer    = "kubernetes_version"
@@ -61,6 +71,8 @@ const (
 	labelStatus           = "status"
 	labelReady            = "ready"
 	labelDataStoreName    = "datastore_name"
+	labelEndpoint         = "endpoint"
+	labelState            = "state"
 	labelDriver           = "driver"
 	labelStrategy         = "strategy"
 	labelVersion          = "version"
@@ -129,8 +141,13 @@ type Recorder struct {
 	buildInfo                *prometheus.GaugeVec
 	tenantControlPlaneInfo   *prometheus.GaugeVec
 	tenantControlPlaneStatus *prometheus.GaugeVec
+	dataStoreUsageBytes      *prometheus.GaugeVec
+	dataStoreUsageKeys       *prometheus.GaugeVec
 	datastoreInfo            *prometheus.GaugeVec
 	datastoreStatus          *prometheus.GaugeVec
+	datastoreEndpointUp      *prometheus.GaugeVec
+	datastoreEndpointProbe   *prometheus.HistogramVec
+	datastoreConnections     *prometheus.GaugeVec
 	controlPlanesCount       *prometheus.GaugeVec
 	datastoresCount          *prometheus.GaugeVec
 	certificatesCount        *prometheus.GaugeVec
@@ -168,6 +185,18 @@ func NewRecorder(registerer prometheus.Registerer) *Recorder {
 			Name:      metricNameStatus,
 			Help:      "Current status and readiness of TenantControlPlane resources.",
 		}, []string{labelTCPNamespace, labelTCPName, labelStatus, labelReady}),
+		dataStoreUsageBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: kamajiNamespace,
+			Subsystem: tenantControlPlaneSubsystem,
+			Name:      metricNameDataStoreBytes,
+			Help:      "Bytes used by TenantControlPlane resources on their DataStore.",
+		}, []string{labelTCPNamespace, labelTCPName, labelDataStoreName}),
+		dataStoreUsageKeys: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: kamajiNamespace,
+			Subsystem: tenantControlPlaneSubsystem,
+			Name:      metricNameDataStoreKeys,
+			Help:      "Keys, or kine rows for SQL drivers, used by TenantControlPlane resources on their DataStore.",
+		}, []string{labelTCPNamespace, labelTCPName, labelDataStoreName}),
 		datastoreInfo: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: kamajiNamespace,
 			Subsystem: datastoreSubsystem,
@@ -180,6 +209,25 @@ func NewRecorder(registerer prometheus.Registerer) *Recorder {
 			Name:      metricNameStatus,
 			Help:      "Current status and readiness of DataStore resources.",
 		}, []string{labelDataStoreName, labelStatus, labelReady}),
+		datastoreEndpointUp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: kamajiNamespace,
+			Subsystem: datastoreSubsystem,
+			Name:      metricNameEndpointUp,
+			Help:      "Whether the last health probe of the DataStore endpoint succeeded.",
+		}, []string{labelDataStoreName, labelEndpoint}),
+		datastoreEndpointProbe: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: kamajiNamespace,
+			Subsystem: datastoreSubsystem,
+			Name:      metricNameEndpointProbeDuration,
+			Help:      "Latency of the health probes of the DataStore endpoints, including the connection setup.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{labelDataStoreName, labelEndpoint}),
+		datastoreConnections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: kamajiNamespace,
+			Subsystem: datastoreSubsystem,
+			Name:      metricNameConnections,
+			Help:      "Connections of the pool shared by the controllers towards the DataStore, by state.",
+		}, []string{labelDataStoreName, labelState}),
 		controlPlanesCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: kamajiNamespace,
 			Subsystem: tenantControlPlanesS,
@@ -204,8 +252,13 @@ func NewRecorder(registerer prometheus.Registerer) *Recorder {
 		recorder.buildInfo,
 		recorder.tenantControlPlaneInfo,
 		recorder.tenantControlPlaneStatus,
+		recorder.dataStoreUsageBytes,
+		recorder.dataStoreUsageKeys,
 		recorder.datastoreInfo,
 		recorder.datastoreStatus,
+		recorder.datastoreEndpointUp,
+		recorder.datastoreEndpointProbe,
+		recorder.datastoreConnections,
 		recorder.controlPlanesCount,
 		recorder.datastoresCount,
 		recorder.certificatesCount,
@@ -273,6 +326,16 @@ func (r *Recorder) SetTenantControlPlaneStatus(tcpNamespace, tcpName, status, re
 	r.tenantControlPlaneStatus.WithLabelValues(tcpNamespace, tcpName, status, ready).Set(1)
 }
 
+func (r *Recorder) DeleteTenantControlPlaneDataStoreUsage(tcpNamespace, tcpName, datastoreName string) {
+	r.dataStoreUsageBytes.DeleteLabelValues(tcpNamespace, tcpName, datastoreName)
+	r.dataStoreUsageKeys.DeleteLabelValues(tcpNamespace, tcpName, datastoreName)
+}
+
+func (r *Recorder) SetTenantControlPlaneDataStoreUsage(tcpNamespace, tcpName, datastoreName string, bytes, keys int64) {
+	r.dataStoreUsageBytes.WithLabelValues(tcpNamespace, tcpName, datastoreName).Set(float64(bytes))
+	r.dataStoreUsageKeys.WithLabelValues(tcpNamespace, tcpName, datastoreName).Set(float64(keys))
+}
+
 func (r *Recorder) ResetDataStoreStatus() {
 	r.datastoreStatus.Reset()
 }
@@ -281,6 +344,29 @@ func (r *Recorder) SetDataStoreStatus(datastoreName, status, ready string) {
 	r.datastoreStatus.WithLabelValues(datastoreName, status, ready).Set(1)
 }
 
+func (r *Recorder) ResetDataStoreEndpointUp() {
+	r.datastoreEndpointUp.Reset()
+}
+
+func (r *Recorder) ObserveDataStoreEndpointProbe(datastoreName, endpoint string, latency time.Duration, up bool) {
+	value := 0.0
+	if up {
+		value = 1
+	}
+
+	r.datastoreEndpointUp.WithLabelValues(datastoreName, endpoint).Set(value)
+	r.datastoreEndpointProbe.WithLabelValues(datastoreName, endpoint).Observe(latency.Seconds())
+}
+
+func (r *Recorder) ResetDataStoreConnections() {
+	r.datastoreConnections.Reset()
+}
+
+func (r *Recorder) SetDataStoreConnections(datastoreName string, inUse, idle int) {
+	r.datastoreConnections.WithLabelValues(datastoreName, ConnectionStateInUse).Set(float64(inUse))
+	r.datastoreConnections.WithLabelValues(datastoreName, ConnectionStateIdle).Set(float64(idle))
+}
+
 func (r *Recorder) ResetTenantControlPlaneInfo() {
 	r.tenantControlPlaneInfo.Reset()
 }
@@ -5,6 +5,7 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
@@ -265,6 +266,95 @@ func TestTenantControlPlaneStatusMetric(t *testing.T) {
 	}
 }
 
+func TestTenantControlPlaneDataStoreUsageMetric(t *testing.T) {
+	t.Helper()
+	recorder := testRecorder()
+
+	recorder.SetTenantControlPlaneDataStoreUsage("default", "test", "postgresql", 4096, 42)
+
+	labels := map[string]string{
+		"tcp_namespace":  "default",
+		"tcp_name":       "test",
+		"datastore_name": "postgresql",
+	}
+
+	bytesFamily := mustMetricFamily(t, "kamaji_tenant_control_plane_datastore_bytes")
+	assertMetricFamilyHasLabels(t, bytesFamily, "tcp_namespace", "tcp_name", "datastore_name")
+
+	if got := gaugeValueByLabels(t, bytesFamily, labels); got != 4096 {
+		t.Fatalf("expected tenant_control_plane_datastore_bytes value to be 4096, got %v", got)
+	}
+
+	if got := gaugeValueByLabels(t, mustMetricFamily(t, "kamaji_tenant_control_plane_datastore_keys"), labels); got != 42 {
+		t.Fatalf("expected tenant_control_plane_datastore_keys value to be 42, got %v", got)
+	}
+
+	recorder.SetTenantControlPlaneDataStoreUsage("default", "removed", "postgresql", 1024, 1)
+	recorder.DeleteTenantControlPlaneDataStoreUsage("default", "removed", "postgresql")
+	// The series of the other Tenant Control Planes are left untouched.
+	bytesFamily = mustMetricFamily(t, "kamaji_tenant_control_plane_datastore_bytes")
+
+	if got := gaugeValueByLabels(t, bytesFamily, labels); got != 4096 {
+		t.Fatalf("expected tenant_control_plane_datastore_bytes value to be 4096, got %v", got)
+	}
+
+	for _, metric := range bytesFamily.GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "tcp_name" && label.GetValue() == "removed" {
+				t.Fatal("expected the series of the removed TenantControlPlane to be deleted")
+			}
+		}
+	}
+}
+
+func TestDataStoreEndpointProbeMetrics(t *testing.T) {
+	t.Helper()
+	recorder := testRecorder()
+
+	recorder.ResetDataStoreEndpointUp()
+	recorder.ObserveDataStoreEndpointProbe("postgresql", "postgres-0:5432", 20*time.Millisecond, true)
+	recorder.ObserveDataStoreEndpointProbe("postgresql", "postgres-1:5432", 5*time.Second, false)
+
+	upFamily := mustMetricFamily(t, "kamaji_datastore_endpoint_up")
+	assertMetricFamilyHasLabels(t, upFamily, "datastore_name", "endpoint")
+
+	if got := gaugeValueByLabels(t, upFamily, map[string]string{"datastore_name": "postgresql", "endpoint": "postgres-0:5432"}); got != 1 {
+		t.Fatalf("expected datastore_endpoint_up value to be 1, got %v", got)
+	}
+
+	if got := gaugeValueByLabels(t, upFamily, map[string]string{"datastore_name": "postgresql", "endpoint": "postgres-1:5432"}); got != 0 {
+		t.Fatalf("expected datastore_endpoint_up value to be 0, got %v", got)
+	}
+
+	probeFamily := mustMetricFamily(t, "kamaji_datastore_endpoint_probe_duration_seconds")
+	assertMetricFamilyHasLabels(t, probeFamily, "datastore_name", "endpoint")
+
+	for _, metric := range probeFamily.GetMetric() {
+		if count := metric.GetHistogram().GetSampleCount(); count == 0 {
+			t.Fatalf("expected datastore_endpoint_probe_duration_seconds to have samples")
+		}
+	}
+}
+
+func TestDataStoreConnectionsMetrics(t *testing.T) {
+	t.Helper()
+	recorder := testRecorder()
+
+	recorder.ResetDataStoreConnections()
+	recorder.SetDataStoreConnections("postgresql", 3, 2)
+
+	family := mustMetricFamily(t, "kamaji_datastore_connections")
+	assertMetricFamilyHasLabels(t, family, "datastore_name", "state")
+
+	if got := gaugeValueByLabels(t, family, map[string]string{"datastore_name": "postgresql", "state": ConnectionStateInUse}); got != 3 {
+		t.Fatalf("expected datastore_connections in use value to be 3, got %v", got)
+	}
+
+	if got := gaugeValueByLabels(t, family, map[string]string{"datastore_name": "postgresql", "state": ConnectionStateIdle}); got != 2 {
+		t.Fatalf("expected datastore_connections idle value to be 2, got %v", got)
+	}
+}
+
 func TestCertificatesCountGaugeByStatusAndStrategy(t *testing.T) {
 	t.Helper()
 	recorder := testRecorder()
@@ -41,7 +41,8 @@ func (r *APIServerCertificate) GetHistogram() prometheus.Histogram {
 }
 
 func (r *APIServerCertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Certificates.APIServer.Checksum != utilities.GetObjectChecksum(r.resource)
+	return tenantControlPlane.Status.Certificates.APIServer.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		IsCertificateInventoryChanged(tenantControlPlane.Status.Certificates.APIServer.CertificateInventory, r.certificateInventory())
 }
 
 func (r *APIServerCertificate) ShouldCleanup(_ *kamajiv1alpha1.TenantControlPlane) bool {
@@ -91,10 +92,15 @@ func (r *APIServerCertificate) UpdateTenantControlPlaneStatus(_ context.Context,
 	tenantControlPlane.Status.Certificates.APIServer.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Certificates.APIServer.SecretName = r.resource.GetName()
 	tenantControlPlane.Status.Certificates.APIServer.Checksum = utilities.GetObjectChecksum(r.resource)
+	SetCertificateInventory(tenantControlPlane, &tenantControlPlane.Status.Certificates.APIServer.CertificateInventory, r.certificateInventory())
 
 	return nil
 }
 
+func (r *APIServerCertificate) certificateInventory() kamajiv1alpha1.CertificateInventory {
+	return CertificateInventory(r.resource, r.resource.Data[kubeadmconstants.APIServerCertName], r.CertExpirationThreshold)
+}
+
 func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
@@ -147,6 +153,11 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerCertAndKeyBaseName, err.Error()))
 			}
 
+			isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[kubeadmconstants.APIServerKeyName], tenantControlPlane.KeyAlgorithm())
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", kubeadmconstants.APIServerCertAndKeyBaseName, err.Error()))
+			}
+
 			commonNames := config.InitConfiguration.APIServer.CertSANs
 
 			addr, _, aErr := tenantControlPlane.AssignedControlPlaneAddress()
@@ -159,7 +170,7 @@ func (r *APIServerCertificate) mutate(ctx context.Context, tenantControlPlane *k
 				logger.Info(fmt.Sprintf("%s SAN check returned an error: %s", kubeadmconstants.APIServerCertAndKeyBaseName, err.Error()))
 			}
 
-			if isCAValid && isCertValid && dnsNamesMatches {
+			if isCAValid && isCertValid && isAlgorithmValid && dnsNamesMatches {
 				return nil
 			}
 		}
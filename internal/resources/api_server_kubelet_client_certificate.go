@@ -40,7 +40,8 @@ func (r *APIServerKubeletClientCertificate) GetHistogram() prometheus.Histogram
 }
 
 func (r *APIServerKubeletClientCertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Certificates.APIServerKubeletClient.Checksum != utilities.GetObjectChecksum(r.resource)
+	return tenantControlPlane.Status.Certificates.APIServerKubeletClient.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		IsCertificateInventoryChanged(tenantControlPlane.Status.Certificates.APIServerKubeletClient.CertificateInventory, r.certificateInventory())
 }
 
 func (r *APIServerKubeletClientCertificate) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -86,10 +87,15 @@ func (r *APIServerKubeletClientCertificate) UpdateTenantControlPlaneStatus(_ con
 	tenantControlPlane.Status.Certificates.APIServerKubeletClient.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Certificates.APIServerKubeletClient.SecretName = r.resource.GetName()
 	tenantControlPlane.Status.Certificates.APIServerKubeletClient.Checksum = utilities.GetObjectChecksum(r.resource)
+	SetCertificateInventory(tenantControlPlane, &tenantControlPlane.Status.Certificates.APIServerKubeletClient.CertificateInventory, r.certificateInventory())
 
 	return nil
 }
 
+func (r *APIServerKubeletClientCertificate) certificateInventory() kamajiv1alpha1.CertificateInventory {
+	return CertificateInventory(r.resource, r.resource.Data[kubeadmconstants.APIServerKubeletClientCertName], r.CertExpirationThreshold)
+}
+
 func (r *APIServerKubeletClientCertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
@@ -134,7 +140,12 @@ func (r *APIServerKubeletClientCertificate) mutate(ctx context.Context, tenantCo
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName, err.Error()))
 			}
 
-			if isValid && isCAValid {
+			isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[kubeadmconstants.APIServerKubeletClientKeyName], tenantControlPlane.KeyAlgorithm())
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", kubeadmconstants.APIServerKubeletClientCertAndKeyBaseName, err.Error()))
+			}
+
+			if isValid && isCAValid && isAlgorithmValid {
 				return nil
 			}
 		}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -19,7 +20,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/crypto"
+	kamajierrors "github.com/clastix/kamaji/internal/errors"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -27,6 +30,10 @@ import (
 type CACertificate struct {
 	resource     *corev1.Secret
 	isRotatingCA bool
+	// isPendingIssuance is true when the Certificate Authority is waiting to be signed by the external cert-manager issuer.
+	isPendingIssuance bool
+	// rotationStatus is the progress of the Graceful rotation, if any.
+	rotationStatus *kamajiv1alpha1.CertificateAuthorityRotationStatus
 
 	Client                  client.Client
 	TmpDirectory            string
@@ -41,7 +48,9 @@ func (r *CACertificate) GetHistogram() prometheus.Histogram {
 
 func (r *CACertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
 	return r.isRotatingCA || tenantControlPlane.Status.Certificates.CA.SecretName != r.resource.GetName() ||
-		tenantControlPlane.Status.Certificates.CA.Checksum != utilities.GetObjectChecksum(r.resource)
+		tenantControlPlane.Status.Certificates.CA.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		r.rotationStatus != nil && !equality.Semantic.DeepEqual(tenantControlPlane.Status.Certificates.CARotation, r.rotationStatus) ||
+		IsCertificateInventoryChanged(tenantControlPlane.Status.Certificates.CA.CertificateInventory, r.certificateInventory())
 }
 
 func (r *CACertificate) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -76,7 +85,17 @@ func (r *CACertificate) GetTmpDirectory() string {
 }
 
 func (r *CACertificate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	result, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	if err != nil {
+		return result, err
+	}
+	// The reconciliation cannot proceed until the external issuer signed the Certificate Authority,
+	// the pending private key has been stored nevertheless.
+	if r.isPendingIssuance {
+		return result, kamajierrors.CertificateAuthorityIssuanceInProgressError{}
+	}
+
+	return result, nil
 }
 
 func (r *CACertificate) GetName() string {
@@ -87,17 +106,35 @@ func (r *CACertificate) UpdateTenantControlPlaneStatus(_ context.Context, tenant
 	tenantControlPlane.Status.Certificates.CA.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Certificates.CA.SecretName = r.resource.GetName()
 	tenantControlPlane.Status.Certificates.CA.Checksum = utilities.GetObjectChecksum(r.resource)
+	SetCertificateInventory(tenantControlPlane, &tenantControlPlane.Status.Certificates.CA.CertificateInventory, r.certificateInventory())
+
 	if r.isRotatingCA {
 		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionCARotating
 	}
 
+	if r.rotationStatus != nil {
+		tenantControlPlane.Status.Certificates.CARotation = r.rotationStatus
+	}
+
 	return nil
 }
 
+func (r *CACertificate) certificateInventory() kamajiv1alpha1.CertificateInventory {
+	return CertificateInventory(r.resource, r.resource.Data[kubeadmconstants.CACertName], r.CertExpirationThreshold)
+}
+
 func (r *CACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
 
+		if external := tenantControlPlane.ExternalCertificateAuthority(); external != nil {
+			return r.mutateExternal(ctx, tenantControlPlane, external)
+		}
+
+		if phase, isRotating := r.currentRotationPhase(); isRotating {
+			return r.mutateRotation(ctx, tenantControlPlane, phase)
+		}
+
 		isRotationRequested := utilities.IsRotationRequested(r.resource)
 
 		if checksum := tenantControlPlane.Status.Certificates.CA.Checksum; !isRotationRequested && (len(checksum) > 0 && checksum == utilities.GetObjectChecksum(r.resource) || len(r.resource.UID) > 0) {
@@ -109,12 +146,25 @@ func (r *CACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 			if err != nil {
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.CACertAndKeyBaseName, err.Error()))
 			}
+
+			isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[kubeadmconstants.CAKeyName], tenantControlPlane.KeyAlgorithm())
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", kubeadmconstants.CACertAndKeyBaseName, err.Error()))
+			}
+			// A change of the key algorithm rotates the Certificate Authority,
+			// as well as dropping the reference to the external one it was issued by.
+			_, isExternal := r.resource.GetAnnotations()[constants.CertificateAuthoritySource]
+			isValid = isValid && isAlgorithmValid && !isExternal
 			// Appending the Cluster API required keys if they're missing:
 			// with this we're sure to avoid introducing breaking changes.
 			if isValid && (!bytes.Equal(r.resource.Data[corev1.TLSCertKey], r.resource.Data[kubeadmconstants.CACertName]) || !bytes.Equal(r.resource.Data[kubeadmconstants.CAKeyName], r.resource.Data[corev1.TLSPrivateKeyKey])) {
 				r.resource.Data[corev1.TLSCertKey] = r.resource.Data[kubeadmconstants.CACertName]
 				r.resource.Data[corev1.TLSPrivateKeyKey] = r.resource.Data[kubeadmconstants.CAKeyName]
 			}
+			// The same applies to the trust bundle, made of the sole Certificate Authority when not rotating.
+			if isValid && !bytes.Equal(r.resource.Data[constants.CATrustBundleKeyName], r.resource.Data[kubeadmconstants.CACertName]) {
+				r.resource.Data[constants.CATrustBundleKeyName] = r.resource.Data[kubeadmconstants.CACertName]
+			}
 
 			if isValid {
 				return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
@@ -125,10 +175,6 @@ func (r *CACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 			utilities.SetLastRotationTimestamp(r.resource)
 		}
 
-		if tenantControlPlane.Status.Kubernetes.Version.Status != nil && *tenantControlPlane.Status.Kubernetes.Version.Status != kamajiv1alpha1.VersionProvisioning {
-			r.isRotatingCA = true
-		}
-
 		config, err := getStoredKubeadmConfiguration(ctx, r.Client, r.TmpDirectory, tenantControlPlane)
 		if err != nil {
 			logger.Error(err, "cannot retrieve kubeadm configuration")
@@ -142,6 +188,16 @@ func (r *CACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 
 			return err
 		}
+		// The Graceful rotation keeps trusting the current Certificate Authority until the new one has been distributed.
+		if r.canRotateGracefully(tenantControlPlane) {
+			logger.Info("starting the graceful rotation of the Certificate Authority")
+
+			return r.publishTrustBundle(tenantControlPlane, ca)
+		}
+
+		if tenantControlPlane.Status.Kubernetes.Version.Status != nil && *tenantControlPlane.Status.Kubernetes.Version.Status != kamajiv1alpha1.VersionProvisioning {
+			r.isRotatingCA = true
+		}
 
 		r.resource.Data = map[string][]byte{
 			kubeadmconstants.CACertName: ca.Certificate,
@@ -149,11 +205,15 @@ func (r *CACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 			// Required for Cluster API integration which is reading the basic TLS keys.
 			// We cannot switch over basic corev1.Secret keys for backward compatibility,
 			// it would require a new CA generation breaking all the clusters deployed.
-			corev1.TLSCertKey:       ca.Certificate,
-			corev1.TLSPrivateKeyKey: ca.PrivateKey,
+			corev1.TLSCertKey:              ca.Certificate,
+			corev1.TLSPrivateKeyKey:        ca.PrivateKey,
+			constants.CATrustBundleKeyName: ca.Certificate,
 		}
 
 		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+		// The self-signed Certificate Authority isn't tracked by the certificate lifecycle controller.
+		delete(r.resource.GetLabels(), constants.ControllerLabelResource)
+		delete(r.resource.GetAnnotations(), constants.CertificateAuthoritySource)
 
 		utilities.SetObjectChecksum(r.resource, r.resource.Data)
 
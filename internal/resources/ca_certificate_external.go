@@ -0,0 +1,340 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	certManagerGroup = "cert-manager.io"
+	// caCommonName is the common name of the Certificate Authority generated by kubeadm.
+	caCommonName = "kubernetes"
+	// caCertificateRequestDuration is the validity of the Certificate Authority generated by kubeadm.
+	caCertificateRequestDuration = "87600h0m0s"
+)
+
+var certificateRequestGVK = schema.GroupVersionKind{Group: certManagerGroup, Version: "v1", Kind: "CertificateRequest"}
+
+// externalCertificateAuthoritySource returns the reference to the external Certificate Authority, stored in the Secret annotations.
+func externalCertificateAuthoritySource(external *kamajiv1alpha1.ExternalCertificateAuthority) string {
+	if external.SecretRef != nil {
+		return "Secret/" + external.SecretRef.Name
+	}
+
+	return certManagerIssuerKind(*external.IssuerRef) + "/" + external.IssuerRef.Name
+}
+
+func certManagerIssuerKind(issuerRef kamajiv1alpha1.CertManagerIssuerReference) string {
+	if issuerRef.Kind == "" {
+		return "Issuer"
+	}
+
+	return issuerRef.Kind
+}
+
+// mutateExternal stores the Certificate Authority issued by the external one, along with the bundle containing its chain:
+// the Secret is tracked by the certificate lifecycle controller, since the chain could expire before the tenant Certificate Authority.
+func (r *CACertificate) mutateExternal(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, external *kamajiv1alpha1.ExternalCertificateAuthority) error {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	var (
+		ca  *kubeadm.CertificatePrivateKeyPair
+		err error
+	)
+
+	switch {
+	case external.SecretRef != nil:
+		ca, err = r.getSecretCertificateAuthority(ctx, tenantControlPlane, external.SecretRef.Name)
+	default:
+		ca, err = r.getIssuedCertificateAuthority(ctx, tenantControlPlane, external)
+	}
+
+	if err != nil {
+		logger.Error(err, "cannot retrieve the external Certificate Authority")
+
+		return err
+	}
+
+	r.resource.SetLabels(utilities.MergeMaps(
+		r.resource.GetLabels(),
+		utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()),
+		map[string]string{
+			constants.ControllerLabelResource: utilities.CertificateX509Label,
+		},
+	))
+
+	if ca == nil {
+		logger.Info("waiting for the Certificate Authority to be signed by the external issuer")
+
+		r.isPendingIssuance = true
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+
+	if utilities.IsRotationRequested(r.resource) {
+		utilities.SetLastRotationTimestamp(r.resource)
+	}
+
+	source := externalCertificateAuthoritySource(external)
+
+	isUpToDate := r.resource.GetAnnotations()[constants.CertificateAuthoritySource] == source &&
+		bytes.Equal(r.resource.Data[kubeadmconstants.CACertName], ca.Certificate) &&
+		bytes.Equal(r.resource.Data[kubeadmconstants.CAKeyName], ca.PrivateKey) &&
+		bytes.Equal(r.resource.Data[constants.CABundleKeyName], ca.Bundle) &&
+		bytes.Equal(r.resource.Data[constants.CATrustBundleKeyName], ca.Certificate)
+	if isUpToDate {
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+
+	if len(r.resource.Data[kubeadmconstants.CACertName]) > 0 && !bytes.Equal(r.resource.Data[kubeadmconstants.CACertName], ca.Certificate) &&
+		tenantControlPlane.Status.Kubernetes.Version.Status != nil && *tenantControlPlane.Status.Kubernetes.Version.Status != kamajiv1alpha1.VersionProvisioning {
+		r.isRotatingCA = true
+	}
+
+	r.resource.Data = map[string][]byte{
+		kubeadmconstants.CACertName: ca.Certificate,
+		kubeadmconstants.CAKeyName:  ca.PrivateKey,
+		constants.CABundleKeyName:   ca.Bundle,
+		// The chain isn't trusted to authenticate the clients, since it could have issued sibling Certificate Authorities.
+		constants.CATrustBundleKeyName: ca.Certificate,
+		corev1.TLSCertKey:              ca.Certificate,
+		corev1.TLSPrivateKeyKey:        ca.PrivateKey,
+	}
+
+	r.resource.SetAnnotations(utilities.MergeMaps(r.resource.GetAnnotations(), map[string]string{constants.CertificateAuthoritySource: source}))
+
+	utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+	return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+}
+
+// getSecretCertificateAuthority returns the intermediate Certificate Authority stored in the referenced Secret,
+// the tls.crt key containing its certificate followed by the chain.
+func (r *CACertificate) getSecretCertificateAuthority(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, name string) (*kubeadm.CertificatePrivateKeyPair, error) {
+	var secret corev1.Secret
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("cannot retrieve the Certificate Authority Secret: %w", err)
+	}
+
+	return newExternalCertificateAuthority(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+}
+
+// getIssuedCertificateAuthority returns the Certificate Authority signed by the cert-manager issuer,
+// requesting a new one when missing, expiring, or upon a change of the issuer or of the key algorithm:
+// a nil Certificate Authority is returned until the request has been fulfilled.
+func (r *CACertificate) getIssuedCertificateAuthority(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, external *kamajiv1alpha1.ExternalCertificateAuthority) (*kubeadm.CertificatePrivateKeyPair, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if r.resource.GetAnnotations()[constants.CertificateAuthoritySource] == externalCertificateAuthoritySource(external) && !utilities.IsRotationRequested(r.resource) {
+		isValid, err := crypto.CheckCertificateAndPrivateKeyPairValidity(r.resource.Data[kubeadmconstants.CACertName], r.resource.Data[kubeadmconstants.CAKeyName], r.CertExpirationThreshold)
+		if err != nil {
+			logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.CACertAndKeyBaseName, err.Error()))
+		}
+
+		isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[kubeadmconstants.CAKeyName], tenantControlPlane.KeyAlgorithm())
+		if err != nil {
+			logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", kubeadmconstants.CACertAndKeyBaseName, err.Error()))
+		}
+
+		if isValid && isAlgorithmValid {
+			return &kubeadm.CertificatePrivateKeyPair{
+				Certificate: r.resource.Data[kubeadmconstants.CACertName],
+				PrivateKey:  r.resource.Data[kubeadmconstants.CAKeyName],
+				Bundle:      r.resource.Data[constants.CABundleKeyName],
+			}, nil
+		}
+	}
+
+	request := &unstructured.Unstructured{}
+	request.SetGroupVersionKind(certificateRequestGVK)
+
+	err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: r.resource.GetName()}, request)
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil, r.createCertificateRequest(ctx, tenantControlPlane, *external.IssuerRef)
+	case err != nil:
+		return nil, fmt.Errorf("cannot retrieve the CertificateRequest: %w", err)
+	}
+
+	ca, err := r.getCertificateRequestResult(request, *external.IssuerRef)
+	if err != nil || ca == nil {
+		// A stale, or failed, request is deleted and created back on the next reconciliation.
+		if deleteErr := r.Client.Delete(ctx, request); deleteErr != nil && !k8serrors.IsNotFound(deleteErr) {
+			return nil, fmt.Errorf("cannot delete the CertificateRequest: %w", deleteErr)
+		}
+
+		return nil, err
+	}
+
+	if ca.Certificate == nil {
+		return nil, nil
+	}
+
+	if err = r.Client.Delete(ctx, request); err != nil && !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("cannot delete the fulfilled CertificateRequest: %w", err)
+	}
+
+	return ca, nil
+}
+
+// getCertificateRequestResult returns the Certificate Authority issued by the CertificateRequest,
+// an empty one when still pending, and a nil one when the request is stale.
+func (r *CACertificate) getCertificateRequestResult(request *unstructured.Unstructured, issuerRef kamajiv1alpha1.CertManagerIssuerReference) (*kubeadm.CertificatePrivateKeyPair, error) {
+	privateKey := r.resource.Data[constants.CARequestKeyName]
+
+	issuerName, _, _ := unstructured.NestedString(request.Object, "spec", "issuerRef", "name")
+	issuerKind, _, _ := unstructured.NestedString(request.Object, "spec", "issuerRef", "kind")
+
+	if len(privateKey) == 0 || issuerName != issuerRef.Name || issuerKind != certManagerIssuerKind(issuerRef) {
+		return nil, nil
+	}
+
+	csr, err := nestedBase64(request, "spec", "request")
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, _ := crypto.CheckCertificateRequestPrivateKey(csr, privateKey); !ok {
+		return nil, nil
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(request.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		conditionType, status := condition["type"], condition["status"]
+
+		isDenied := (conditionType == "Denied" || conditionType == "InvalidRequest") && status == string(corev1.ConditionTrue)
+		isFailed := conditionType == "Ready" && status == string(corev1.ConditionFalse) && condition["reason"] == "Failed"
+
+		if isDenied || isFailed {
+			return nil, fmt.Errorf("the CertificateRequest has not been fulfilled: %v", condition["message"])
+		}
+	}
+
+	certificate, err := nestedBase64(request, "status", "certificate")
+	if err != nil || len(certificate) == 0 {
+		return &kubeadm.CertificatePrivateKeyPair{}, err
+	}
+
+	chain, err := nestedBase64(request, "status", "ca")
+	if err != nil {
+		return nil, err
+	}
+	// Some issuers append the chain to the certificate, the root must not be repeated.
+	if len(chain) > 0 && !bytes.Contains(certificate, bytes.TrimSpace(chain)) {
+		certificate = append(append(bytes.TrimSpace(certificate), '\n'), chain...)
+	}
+
+	return newExternalCertificateAuthority(certificate, privateKey)
+}
+
+// createCertificateRequest requests the cert-manager issuer to sign a Certificate Authority,
+// storing the private key in the Secret until the certificate has been issued.
+func (r *CACertificate) createCertificateRequest(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, issuerRef kamajiv1alpha1.CertManagerIssuerReference) error {
+	key, err := crypto.GeneratePrivateKey(tenantControlPlane.KeyAlgorithm())
+	if err != nil {
+		return fmt.Errorf("cannot generate the private key: %w", err)
+	}
+
+	privateKey, err := crypto.EncodePrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	csr, err := crypto.GenerateCertificateRequest(caCommonName, key)
+	if err != nil {
+		return err
+	}
+
+	request := &unstructured.Unstructured{}
+	request.SetGroupVersionKind(certificateRequestGVK)
+	request.SetNamespace(tenantControlPlane.GetNamespace())
+	request.SetName(r.resource.GetName())
+	request.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+	request.Object["spec"] = map[string]any{
+		"request":  base64.StdEncoding.EncodeToString(csr),
+		"isCA":     true,
+		"duration": caCertificateRequestDuration,
+		"usages":   []any{"digital signature", "key encipherment", "cert sign"},
+		"issuerRef": map[string]any{
+			"group": certManagerGroup,
+			"kind":  certManagerIssuerKind(issuerRef),
+			"name":  issuerRef.Name,
+		},
+	}
+
+	if err = ctrl.SetControllerReference(tenantControlPlane, request, r.Client.Scheme()); err != nil {
+		return err
+	}
+
+	if err = r.Client.Create(ctx, request); err != nil {
+		return fmt.Errorf("cannot create the CertificateRequest: %w", err)
+	}
+
+	if r.resource.Data == nil {
+		r.resource.Data = map[string][]byte{}
+	}
+
+	r.resource.Data[constants.CARequestKeyName] = privateKey
+
+	return nil
+}
+
+// newExternalCertificateAuthority returns the Certificate Authority made of the first certificate of the bundle,
+// checking it's matching the private key, and it's chaining up to the last certificate of the bundle.
+func newExternalCertificateAuthority(bundle, privateKey []byte) (*kubeadm.CertificatePrivateKeyPair, error) {
+	chain, err := crypto.ParseCertificateChainBytes(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the Certificate Authority chain: %w", err)
+	}
+
+	if ok, chainErr := crypto.VerifyCertificateAuthorityChain(bundle); !ok {
+		return nil, fmt.Errorf("the Certificate Authority chain is not valid: %w", chainErr)
+	}
+
+	certificate := crypto.EncodeCertificateChain(chain[:1])
+
+	if ok, keyErr := crypto.CheckCertificateAndPrivateKeyPairValidity(certificate, privateKey, 0); !ok {
+		return nil, fmt.Errorf("the Certificate Authority private key is not matching the certificate: %w", keyErr)
+	}
+
+	return &kubeadm.CertificatePrivateKeyPair{
+		Certificate: certificate,
+		PrivateKey:  privateKey,
+		Bundle:      crypto.EncodeCertificateChain(chain),
+	}, nil
+}
+
+func nestedBase64(obj *unstructured.Unstructured, fields ...string) ([]byte, error) {
+	value, _, err := unstructured.NestedString(obj.Object, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(value)
+}
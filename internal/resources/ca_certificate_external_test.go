@@ -0,0 +1,209 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+type testCertificateAuthority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+func newTestCertificateAuthority(commonName string, parent *testCertificateAuthority, publicKey *ecdsa.PublicKey) testCertificateAuthority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	if publicKey == nil {
+		publicKey = &key.PublicKey
+	}
+
+	signer, signerCertificate := key, template
+	if parent != nil {
+		signer, signerCertificate = parent.key, parent.certificate
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCertificate, publicKey, signer)
+	Expect(err).ToNot(HaveOccurred())
+
+	certificate, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+
+	return testCertificateAuthority{certificate: certificate, key: key}
+}
+
+func (ca testCertificateAuthority) certificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.certificate.Raw})
+}
+
+func (ca testCertificateAuthority) keyPEM() []byte {
+	der, err := x509.MarshalECPrivateKey(ca.key)
+	Expect(err).ToNot(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+var _ = Describe("CACertificate with an external Certificate Authority", func() {
+	var (
+		ctx  context.Context
+		tcp  *kamajiv1alpha1.TenantControlPlane
+		root testCertificateAuthority
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		root = newTestCertificateAuthority("corporate-root", nil, nil)
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default", UID: "tenant-uid"},
+		}
+	})
+
+	reconcile := func(c client.Client) (*corev1.Secret, error) {
+		resource := &resources.CACertificate{Client: c, CertExpirationThreshold: time.Hour}
+		Expect(resource.Define(ctx, tcp)).To(Succeed())
+
+		_, err := resource.CreateOrUpdate(ctx, tcp)
+
+		var secret corev1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tenant-ca"}, &secret)).To(Succeed())
+
+		return &secret, err
+	}
+
+	Context("referencing a Secret", func() {
+		It("should store the intermediate along with its chain", func() {
+			intermediate := newTestCertificateAuthority("corporate-intermediate", &root, nil)
+
+			tcp.Spec.PKI = &kamajiv1alpha1.PKISpec{CertificateAuthority: &kamajiv1alpha1.ExternalCertificateAuthority{
+				SecretRef: &corev1.LocalObjectReference{Name: "intermediate"},
+			}}
+
+			external := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "intermediate", Namespace: tcp.Namespace},
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       append(intermediate.certificatePEM(), root.certificatePEM()...),
+					corev1.TLSPrivateKeyKey: intermediate.keyPEM(),
+				},
+			}
+
+			c := fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(tcp, external).Build()
+
+			secret, err := reconcile(c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data[kubeadmconstants.CACertName]).To(Equal(intermediate.certificatePEM()))
+			Expect(secret.Data[kubeadmconstants.CAKeyName]).To(Equal(intermediate.keyPEM()))
+			Expect(secret.Data[constants.CABundleKeyName]).To(Equal(external.Data[corev1.TLSCertKey]))
+			Expect(secret.GetAnnotations()).To(HaveKeyWithValue(constants.CertificateAuthoritySource, "Secret/intermediate"))
+			Expect(secret.GetLabels()).To(HaveKeyWithValue(constants.ControllerLabelResource, utilities.CertificateX509Label))
+		})
+
+		It("should reject an intermediate not matching its private key", func() {
+			intermediate := newTestCertificateAuthority("corporate-intermediate", &root, nil)
+
+			tcp.Spec.PKI = &kamajiv1alpha1.PKISpec{CertificateAuthority: &kamajiv1alpha1.ExternalCertificateAuthority{
+				SecretRef: &corev1.LocalObjectReference{Name: "intermediate"},
+			}}
+
+			external := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "intermediate", Namespace: tcp.Namespace},
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       append(intermediate.certificatePEM(), root.certificatePEM()...),
+					corev1.TLSPrivateKeyKey: root.keyPEM(),
+				},
+			}
+
+			c := fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(tcp, external).Build()
+
+			resource := &resources.CACertificate{Client: c}
+			Expect(resource.Define(ctx, tcp)).To(Succeed())
+
+			_, err := resource.CreateOrUpdate(ctx, tcp)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("referencing a cert-manager issuer", func() {
+		It("should request the Certificate Authority, storing it once issued", func() {
+			tcp.Spec.PKI = &kamajiv1alpha1.PKISpec{
+				KeyAlgorithm: kamajiv1alpha1.KeyAlgorithmECDSAP256,
+				CertificateAuthority: &kamajiv1alpha1.ExternalCertificateAuthority{
+					IssuerRef: &kamajiv1alpha1.CertManagerIssuerReference{Name: "corporate", Kind: "ClusterIssuer"},
+				},
+			}
+
+			c := fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(tcp).Build()
+
+			secret, err := reconcile(c)
+			Expect(err).To(MatchError(kamajierrors.CertificateAuthorityIssuanceInProgressError{}))
+			Expect(secret.Data).To(HaveKey(constants.CARequestKeyName))
+
+			request := &unstructured.Unstructured{}
+			request.SetGroupVersionKind(schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "CertificateRequest"})
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tenant-ca"}, request)).To(Succeed())
+			Expect(request.Object["spec"]).To(HaveKeyWithValue("isCA", true))
+			// Signing the requested Certificate Authority with the root, as cert-manager would do.
+			encodedCSR, _, _ := unstructured.NestedString(request.Object, "spec", "request")
+			csrPEM, err := base64.StdEncoding.DecodeString(encodedCSR)
+			Expect(err).ToNot(HaveOccurred())
+
+			block, _ := pem.Decode(csrPEM)
+			csr, err := x509.ParseCertificateRequest(block.Bytes)
+			Expect(err).ToNot(HaveOccurred())
+
+			publicKey, ok := csr.PublicKey.(*ecdsa.PublicKey)
+			Expect(ok).To(BeTrue())
+
+			issued := newTestCertificateAuthority("kubernetes", &root, publicKey)
+
+			Expect(unstructured.SetNestedField(request.Object, base64.StdEncoding.EncodeToString(issued.certificatePEM()), "status", "certificate")).To(Succeed())
+			Expect(unstructured.SetNestedField(request.Object, base64.StdEncoding.EncodeToString(root.certificatePEM()), "status", "ca")).To(Succeed())
+			Expect(c.Update(ctx, request)).To(Succeed())
+
+			secret, err = reconcile(c)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).ToNot(HaveKey(constants.CARequestKeyName))
+			Expect(secret.Data[kubeadmconstants.CACertName]).To(Equal(issued.certificatePEM()))
+			Expect(secret.Data[constants.CABundleKeyName]).To(Equal(append(issued.certificatePEM(), root.certificatePEM()...)))
+			Expect(secret.GetAnnotations()).To(HaveKeyWithValue(constants.CertificateAuthoritySource, "ClusterIssuer/corporate"))
+
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tenant-ca"}, request)).ToNot(Succeed())
+		})
+	})
+})
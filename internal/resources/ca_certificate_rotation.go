@@ -0,0 +1,175 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// CertificateAuthorityRotationRequeueAfter returns the remaining soak period of the current phase
+// of the Graceful Certificate Authority rotation, zero when no phase is waiting for it.
+func CertificateAuthorityRotationRequeueAfter(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) time.Duration {
+	soakPeriod, isGraceful := tenantControlPlane.CertificateAuthorityRotationSoakPeriod()
+
+	rotation := tenantControlPlane.Status.Certificates.CARotation
+	if !isGraceful || rotation == nil || rotation.Phase == kamajiv1alpha1.CARotationPhaseCompleted {
+		return 0
+	}
+
+	return max(time.Until(rotation.LastTransitionTime.Add(soakPeriod)), 0)
+}
+
+// canRotateGracefully returns true if the current Certificate Authority can be kept trusted during the rotation:
+// it must be a valid self-generated one, of a provisioned Tenant Control Plane.
+func (r *CACertificate) canRotateGracefully(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if _, isGraceful := tenantControlPlane.CertificateAuthorityRotationSoakPeriod(); !isGraceful {
+		return false
+	}
+
+	if status := tenantControlPlane.Status.Kubernetes.Version.Status; status == nil || *status == kamajiv1alpha1.VersionProvisioning {
+		return false
+	}
+
+	if _, isExternal := r.resource.GetAnnotations()[constants.CertificateAuthoritySource]; isExternal {
+		return false
+	}
+
+	isValid, _ := crypto.CheckCertificateAndPrivateKeyPairValidity(r.resource.Data[kubeadmconstants.CACertName], r.resource.Data[kubeadmconstants.CAKeyName], 0)
+
+	return isValid
+}
+
+// currentRotationPhase returns the phase of the Graceful rotation in progress, if any.
+func (r *CACertificate) currentRotationPhase() (kamajiv1alpha1.CertificateAuthorityRotationPhase, bool) {
+	switch {
+	case len(r.resource.Data[constants.CANextCertName]) > 0:
+		return kamajiv1alpha1.CARotationPhaseTrustBundlePublished, true
+	case len(r.resource.Data[constants.CAPreviousCertName]) > 0:
+		return kamajiv1alpha1.CARotationPhaseCertificatesReissued, true
+	default:
+		return "", false
+	}
+}
+
+// setRotationPhase records the phase of the rotation, retaining the transition time when it's not changed.
+func (r *CACertificate) setRotationPhase(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, phase kamajiv1alpha1.CertificateAuthorityRotationPhase) {
+	if current := tenantControlPlane.Status.Certificates.CARotation; current != nil && current.Phase == phase {
+		r.rotationStatus = current.DeepCopy()
+
+		return
+	}
+
+	r.rotationStatus = &kamajiv1alpha1.CertificateAuthorityRotationStatus{Phase: phase, LastTransitionTime: metav1.Now()}
+}
+
+// isRotationPhaseElapsed returns true if the soak period of the current phase elapsed, or if the phase has been confirmed:
+// the soak period is ignored when the strategy is not Graceful anymore, completing the rotation.
+func (r *CACertificate) isRotationPhaseElapsed(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, phase kamajiv1alpha1.CertificateAuthorityRotationPhase) bool {
+	if r.resource.GetAnnotations()[utilities.ConfirmRotationPhaseAnnotation] == string(phase) {
+		return true
+	}
+
+	soakPeriod, isGraceful := tenantControlPlane.CertificateAuthorityRotationSoakPeriod()
+	if !isGraceful {
+		return true
+	}
+
+	current := tenantControlPlane.Status.Certificates.CARotation
+
+	return current != nil && current.Phase == phase && time.Since(current.LastTransitionTime.Time) >= soakPeriod
+}
+
+// publishTrustBundle starts the Graceful rotation, trusting the new Certificate Authority along with the current one,
+// which is still issuing the certificates.
+func (r *CACertificate) publishTrustBundle(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, next *kubeadm.CertificatePrivateKeyPair) error {
+	current := r.resource.Data[kubeadmconstants.CACertName]
+
+	r.resource.Data[constants.CANextCertName] = next.Certificate
+	r.resource.Data[constants.CANextKeyName] = next.PrivateKey
+	r.resource.Data[constants.CATrustBundleKeyName] = concatPEMBlocks(current, next.Certificate)
+	r.resource.Data[constants.CABundleKeyName] = r.resource.Data[constants.CATrustBundleKeyName]
+
+	r.setRotationPhase(tenantControlPlane, kamajiv1alpha1.CARotationPhaseTrustBundlePublished)
+
+	utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+	return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+}
+
+// mutateRotation moves the Graceful rotation forward once the soak period of the current phase elapsed:
+// the new Certificate Authority replaces the current one, which is finally dropped from the trust bundle.
+func (r *CACertificate) mutateRotation(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, phase kamajiv1alpha1.CertificateAuthorityRotationPhase) error {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if !r.isRotationPhaseElapsed(tenantControlPlane, phase) {
+		r.setRotationPhase(tenantControlPlane, phase)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+
+	switch phase {
+	case kamajiv1alpha1.CARotationPhaseTrustBundlePublished:
+		if ok, err := crypto.CheckCertificateAndPrivateKeyPairValidity(r.resource.Data[constants.CANextCertName], r.resource.Data[constants.CANextKeyName], 0); !ok {
+			return fmt.Errorf("the new Certificate Authority is not valid: %w", err)
+		}
+
+		logger.Info("the new Certificate Authority is replacing the current one")
+
+		previous, next, nextKey := r.resource.Data[kubeadmconstants.CACertName], r.resource.Data[constants.CANextCertName], r.resource.Data[constants.CANextKeyName]
+
+		r.resource.Data[kubeadmconstants.CACertName] = next
+		r.resource.Data[kubeadmconstants.CAKeyName] = nextKey
+		r.resource.Data[corev1.TLSCertKey] = next
+		r.resource.Data[corev1.TLSPrivateKeyKey] = nextKey
+		r.resource.Data[constants.CAPreviousCertName] = previous
+		r.resource.Data[constants.CATrustBundleKeyName] = concatPEMBlocks(next, previous)
+		r.resource.Data[constants.CABundleKeyName] = r.resource.Data[constants.CATrustBundleKeyName]
+
+		delete(r.resource.Data, constants.CANextCertName)
+		delete(r.resource.Data, constants.CANextKeyName)
+
+		r.setRotationPhase(tenantControlPlane, kamajiv1alpha1.CARotationPhaseCertificatesReissued)
+	case kamajiv1alpha1.CARotationPhaseCertificatesReissued:
+		logger.Info("the previous Certificate Authority is dropped from the trust bundle")
+
+		r.resource.Data[constants.CATrustBundleKeyName] = r.resource.Data[kubeadmconstants.CACertName]
+
+		delete(r.resource.Data, constants.CAPreviousCertName)
+		delete(r.resource.Data, constants.CABundleKeyName)
+
+		r.setRotationPhase(tenantControlPlane, kamajiv1alpha1.CARotationPhaseCompleted)
+	}
+
+	delete(r.resource.GetAnnotations(), utilities.ConfirmRotationPhaseAnnotation)
+
+	utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+	return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+}
+
+func concatPEMBlocks(blocks ...[]byte) []byte {
+	bundle := make([]byte, 0)
+
+	for _, block := range blocks {
+		bundle = append(bundle, bytes.TrimSpace(block)...)
+		bundle = append(bundle, '\n')
+	}
+
+	return bundle
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+var _ = Describe("CACertificate Graceful rotation", func() {
+	var (
+		ctx               context.Context
+		tcp               *kamajiv1alpha1.TenantControlPlane
+		current, next     testCertificateAuthority
+		c                 client.Client
+		reconcileRotation func() *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		current = newTestCertificateAuthority("kubernetes", nil, nil)
+		next = newTestCertificateAuthority("kubernetes", nil, nil)
+
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default", UID: "tenant-uid"},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+				PKI: &kamajiv1alpha1.PKISpec{CertificateAuthorityRotation: &kamajiv1alpha1.CertificateAuthorityRotationSpec{
+					Strategy:   kamajiv1alpha1.CertificateAuthorityRotationGraceful,
+					SoakPeriod: metav1.Duration{Duration: time.Hour},
+				}},
+			},
+		}
+		tcp.Status.Certificates.CARotation = &kamajiv1alpha1.CertificateAuthorityRotationStatus{
+			Phase:              kamajiv1alpha1.CARotationPhaseTrustBundlePublished,
+			LastTransitionTime: metav1.Now(),
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-ca", Namespace: tcp.Namespace},
+			Data: map[string][]byte{
+				kubeadmconstants.CACertName:    current.certificatePEM(),
+				kubeadmconstants.CAKeyName:     current.keyPEM(),
+				corev1.TLSCertKey:              current.certificatePEM(),
+				corev1.TLSPrivateKeyKey:        current.keyPEM(),
+				constants.CANextCertName:       next.certificatePEM(),
+				constants.CANextKeyName:        next.keyPEM(),
+				constants.CATrustBundleKeyName: append(current.certificatePEM(), next.certificatePEM()...),
+				constants.CABundleKeyName:      append(current.certificatePEM(), next.certificatePEM()...),
+			},
+		}
+
+		c = fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(tcp, secret).Build()
+
+		reconcileRotation = func() *corev1.Secret {
+			resource := &resources.CACertificate{Client: c}
+			Expect(resource.Define(ctx, tcp)).To(Succeed())
+
+			_, err := resource.CreateOrUpdate(ctx, tcp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+
+			var updated corev1.Secret
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(secret), &updated)).To(Succeed())
+
+			return &updated
+		}
+	})
+
+	It("should keep trusting both the Certificate Authorities during the soak period", func() {
+		secret := reconcileRotation()
+
+		Expect(secret.Data[kubeadmconstants.CACertName]).To(Equal(current.certificatePEM()))
+		Expect(secret.Data).To(HaveKey(constants.CANextCertName))
+		Expect(tcp.Status.Certificates.CARotation.Phase).To(Equal(kamajiv1alpha1.CARotationPhaseTrustBundlePublished))
+		Expect(resources.CertificateAuthorityRotationRequeueAfter(tcp)).To(BeNumerically("~", time.Hour, time.Minute))
+	})
+
+	It("should move through the phases once confirmed, or once the soak period elapsed", func() {
+		var secret corev1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tenant-ca"}, &secret)).To(Succeed())
+
+		secret.SetAnnotations(map[string]string{utilities.ConfirmRotationPhaseAnnotation: string(kamajiv1alpha1.CARotationPhaseTrustBundlePublished)})
+		Expect(c.Update(ctx, &secret)).To(Succeed())
+
+		updated := reconcileRotation()
+
+		Expect(updated.Data[kubeadmconstants.CACertName]).To(Equal(next.certificatePEM()))
+		Expect(updated.Data[kubeadmconstants.CAKeyName]).To(Equal(next.keyPEM()))
+		Expect(updated.Data[constants.CAPreviousCertName]).To(Equal(current.certificatePEM()))
+		Expect(updated.Data[constants.CATrustBundleKeyName]).To(Equal(append(next.certificatePEM(), current.certificatePEM()...)))
+		Expect(updated.Data).ToNot(HaveKey(constants.CANextCertName))
+		Expect(updated.GetAnnotations()).ToNot(HaveKey(utilities.ConfirmRotationPhaseAnnotation))
+		Expect(tcp.Status.Certificates.CARotation.Phase).To(Equal(kamajiv1alpha1.CARotationPhaseCertificatesReissued))
+
+		tcp.Status.Certificates.CARotation.LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		Expect(resources.CertificateAuthorityRotationRequeueAfter(tcp)).To(BeZero())
+
+		updated = reconcileRotation()
+
+		Expect(updated.Data[constants.CATrustBundleKeyName]).To(Equal(next.certificatePEM()))
+		Expect(updated.Data).ToNot(HaveKey(constants.CAPreviousCertName))
+		Expect(updated.Data).ToNot(HaveKey(constants.CABundleKeyName))
+		Expect(tcp.Status.Certificates.CARotation.Phase).To(Equal(kamajiv1alpha1.CARotationPhaseCompleted))
+	})
+})
@@ -0,0 +1,54 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+// CertificateInventory returns the inventory of the given certificate, none when it can't be parsed:
+// the renewal is scheduled once it's within the expiration threshold, if the Secret is checked by the certificate lifecycle controller.
+func CertificateInventory(secret *corev1.Secret, certificate []byte, threshold time.Duration) kamajiv1alpha1.CertificateInventory {
+	crt, err := crypto.ParseCertificateBytes(certificate)
+	if err != nil {
+		return kamajiv1alpha1.CertificateInventory{}
+	}
+
+	inventory := kamajiv1alpha1.CertificateInventory{
+		NotBefore: &metav1.Time{Time: crt.NotBefore},
+		NotAfter:  &metav1.Time{Time: crt.NotAfter},
+		Issuer:    crt.Issuer.String(),
+	}
+
+	inventory.SANs = append(inventory.SANs, crt.DNSNames...)
+	for _, ip := range crt.IPAddresses {
+		inventory.SANs = append(inventory.SANs, ip.String())
+	}
+
+	if _, isChecked := secret.GetLabels()[constants.ControllerLabelResource]; isChecked {
+		inventory.NextRenewal = &metav1.Time{Time: crt.NotAfter.Add(-threshold)}
+	}
+
+	return inventory
+}
+
+// IsCertificateInventoryChanged returns true if the stored inventory doesn't match the given one.
+func IsCertificateInventoryChanged(current, inventory kamajiv1alpha1.CertificateInventory) bool {
+	return !equality.Semantic.DeepEqual(current, inventory)
+}
+
+// SetCertificateInventory stores the inventory of a certificate, refreshing the earliest expiration of the Tenant Control Plane.
+func SetCertificateInventory(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, current *kamajiv1alpha1.CertificateInventory, inventory kamajiv1alpha1.CertificateInventory) {
+	*current = inventory
+
+	tenantControlPlane.Status.Certificates.EarliestExpiry = tenantControlPlane.EarliestCertificateExpiry()
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+var _ = Describe("Certificate inventory", func() {
+	It("should expose the validity of the Certificate Authority, and the earliest expiration", func() {
+		ctx := context.Background()
+
+		root := newTestCertificateAuthority("corporate-root", nil, nil)
+		intermediate := newTestCertificateAuthority("corporate-intermediate", &root, nil)
+
+		tcp := &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default", UID: "tenant-uid"},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+				PKI: &kamajiv1alpha1.PKISpec{CertificateAuthority: &kamajiv1alpha1.ExternalCertificateAuthority{
+					SecretRef: &corev1.LocalObjectReference{Name: "intermediate"},
+				}},
+			},
+		}
+
+		external := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "intermediate", Namespace: tcp.Namespace},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       append(intermediate.certificatePEM(), root.certificatePEM()...),
+				corev1.TLSPrivateKeyKey: intermediate.keyPEM(),
+			},
+		}
+
+		c := fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(tcp, external).Build()
+
+		resource := &resources.CACertificate{Client: c, CertExpirationThreshold: time.Hour}
+		Expect(resource.Define(ctx, tcp)).To(Succeed())
+
+		_, err := resource.CreateOrUpdate(ctx, tcp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resource.ShouldStatusBeUpdated(ctx, tcp)).To(BeTrue())
+		Expect(resource.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+		Expect(resource.ShouldStatusBeUpdated(ctx, tcp)).To(BeFalse())
+
+		inventory := tcp.Status.Certificates.CA.CertificateInventory
+		Expect(inventory.NotBefore.Time).To(BeTemporally("==", intermediate.certificate.NotBefore))
+		Expect(inventory.NotAfter.Time).To(BeTemporally("==", intermediate.certificate.NotAfter))
+		Expect(inventory.Issuer).To(Equal("CN=corporate-root"))
+		Expect(inventory.NextRenewal.Time).To(BeTemporally("==", intermediate.certificate.NotAfter.Add(-time.Hour)))
+		Expect(tcp.Status.Certificates.EarliestExpiry.Time).To(BeTemporally("==", intermediate.certificate.NotAfter))
+	})
+
+	It("should expose the admin client certificate, regardless of the super-admin one", func() {
+		ctx := context.Background()
+
+		ca := newTestCertificateAuthority("kubernetes", nil, nil)
+
+		tcp := &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default", UID: "tenant-uid"},
+		}
+		tcp.Spec.NetworkProfile.Port = 6443
+		tcp.Status.KubeadmConfig.ConfigmapName = "tenant-kubeadmconfig"
+		tcp.Status.Certificates.CA.SecretName = "tenant-ca"
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-kubeadmconfig", Namespace: tcp.Namespace},
+			Data: map[string]string{
+				kubeadmconstants.InitConfigurationKind:    `{"LocalAPIEndpoint": {"AdvertiseAddress": "10.0.0.1", "BindPort": 6443}}`,
+				kubeadmconstants.ClusterConfigurationKind: `{"ControlPlaneEndpoint": "10.0.0.1:6443"}`,
+			},
+		}
+
+		caSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-ca", Namespace: tcp.Namespace},
+			Data: map[string][]byte{
+				kubeadmconstants.CACertName: ca.certificatePEM(),
+				kubeadmconstants.CAKeyName:  ca.keyPEM(),
+			},
+		}
+
+		c := fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(tcp, configMap, caSecret).Build()
+
+		// The super-admin kubeconfig is handled first, while the admin one is still missing from the shared Secret.
+		kubeconfigs := []*resources.KubeconfigResource{
+			{Client: c, Name: "admin-kubeconfig", KubeConfigFileName: resources.SuperAdminKubeConfigFileName, TmpDirectory: GinkgoT().TempDir()},
+			{Client: c, Name: "admin-kubeconfig", KubeConfigFileName: resources.AdminKubeConfigFileName, TmpDirectory: GinkgoT().TempDir()},
+		}
+
+		for _, resource := range kubeconfigs {
+			Expect(resource.Define(ctx, tcp)).To(Succeed())
+
+			result, err := resources.Handle(ctx, resource, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			if result != controllerutil.OperationResultNone {
+				Expect(resource.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+			}
+		}
+
+		var secret corev1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tenant-admin-kubeconfig"}, &secret)).To(Succeed())
+
+		kubeconfig, err := utilities.DecodeKubeconfigYAML(secret.Data[resources.AdminKubeConfigFileName])
+		Expect(err).ToNot(HaveOccurred())
+
+		certificate, err := crypto.ParseCertificateBytes(kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData)
+		Expect(err).ToNot(HaveOccurred())
+
+		inventory := tcp.Status.KubeConfig.Admin.CertificateInventory
+		Expect(inventory.NotAfter).ToNot(BeNil())
+		Expect(inventory.NotAfter.Time).To(BeTemporally("==", certificate.NotAfter))
+		Expect(inventory.Issuer).To(Equal("CN=kubernetes"))
+		// The status is stable, since the super-admin kubeconfig is not overwriting the admin inventory.
+		for _, resource := range kubeconfigs {
+			Expect(resource.ShouldStatusBeUpdated(ctx, tcp)).To(BeFalse())
+		}
+	})
+})
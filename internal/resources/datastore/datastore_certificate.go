@@ -6,6 +6,8 @@ package datastore
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"time"
 
@@ -39,7 +41,13 @@ func (r *Certificate) GetHistogram() prometheus.Histogram {
 }
 
 func (r *Certificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Storage.Certificate.Checksum != utilities.GetObjectChecksum(r.resource)
+	var current kamajiv1alpha1.CertificateInventory
+	if tenantControlPlane.Status.Certificates.ETCD != nil {
+		current = tenantControlPlane.Status.Certificates.ETCD.APIServer.CertificateInventory
+	}
+
+	return tenantControlPlane.Status.Storage.Certificate.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		resources.IsCertificateInventoryChanged(current, r.certificateInventory())
 }
 
 func (r *Certificate) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -82,9 +90,23 @@ func (r *Certificate) UpdateTenantControlPlaneStatus(_ context.Context, tenantCo
 	tenantControlPlane.Status.Storage.Certificate.Checksum = utilities.GetObjectChecksum(r.resource)
 	tenantControlPlane.Status.Storage.Certificate.LastUpdate = metav1.Now()
 
+	if tenantControlPlane.Status.Certificates.ETCD == nil {
+		tenantControlPlane.Status.Certificates.ETCD = &kamajiv1alpha1.ETCDCertificatesStatus{}
+	}
+
+	tenantControlPlane.Status.Certificates.ETCD.APIServer.SecretName = r.resource.GetName()
+	tenantControlPlane.Status.Certificates.ETCD.APIServer.Checksum = utilities.GetObjectChecksum(r.resource)
+	tenantControlPlane.Status.Certificates.ETCD.APIServer.LastUpdate = metav1.Now()
+	resources.SetCertificateInventory(tenantControlPlane, &tenantControlPlane.Status.Certificates.ETCD.APIServer.CertificateInventory, r.certificateInventory())
+
 	return nil
 }
 
+// certificateInventory returns the inventory of the DataStore client certificate, none when the DataStore isn't using TLS.
+func (r *Certificate) certificateInventory() kamajiv1alpha1.CertificateInventory {
+	return resources.CertificateInventory(r.resource, r.resource.Data["server.crt"], r.CertExpirationThreshold)
+}
+
 func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
@@ -120,8 +142,11 @@ func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1al
 			}
 
 			if utilities.GetObjectChecksum(r.resource) == utilities.CalculateMapChecksum(r.resource.Data) {
-				if r.DataStore.Spec.Driver == kamajiv1alpha1.EtcdDriver {
-					if isValid, _ := crypto.IsValidCertificateKeyPairBytes(r.resource.Data["server.crt"], r.resource.Data["server.key"], r.CertExpirationThreshold); isValid && !isRotationRequested {
+				if r.DataStore.Spec.Driver == kamajiv1alpha1.EtcdDriver || r.DataStore.UsesTenantCertificates() {
+					isValid, _ := crypto.IsValidCertificateKeyPairBytes(r.resource.Data["server.crt"], r.resource.Data["server.key"], r.CertExpirationThreshold)
+					isAlgorithmValid, _ := crypto.CheckPrivateKeyAlgorithm(r.resource.Data["server.key"], tenantControlPlane.KeyAlgorithm())
+
+					if isValid && isAlgorithmValid && !isRotationRequested {
 						return nil
 					}
 				}
@@ -131,21 +156,28 @@ func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1al
 
 			switch r.DataStore.Spec.Driver {
 			case kamajiv1alpha1.EtcdDriver:
-				var privateKey []byte
 				// When dealing with the etcd storage we cannot use the basic authentication, thus the generation of a
 				// certificate used for authentication is mandatory, along with the CA private key.
-				if privateKey, err = r.DataStore.Spec.TLSConfig.CertificateAuthority.PrivateKey.GetContent(ctx, r.Client); err != nil {
-					logger.Error(err, "unable to retrieve CA private key content")
+				if crt, key, err = r.generateTenantCertificate(ctx, crypto.NewCertificateTemplate(tenantControlPlane.Status.Storage.Setup.User), ca, tenantControlPlane.KeyAlgorithm()); err != nil {
+					logger.Error(err, "unable to generate certificate and private key")
 
 					return err
 				}
+			case kamajiv1alpha1.KineMySQLDriver, kamajiv1alpha1.KinePostgreSQLDriver, kamajiv1alpha1.KineNatsDriver:
+				if r.DataStore.UsesTenantCertificates() {
+					// The subject is made of the Common Name only, matched by the SQL drivers with the tenant user.
+					template := crypto.NewCertificateTemplate(tenantControlPlane.Status.Storage.Setup.User)
+					template.Subject = pkix.Name{CommonName: tenantControlPlane.Status.Storage.Setup.User}
 
-				if crt, key, err = crypto.GenerateCertificatePrivateKeyPair(crypto.NewCertificateTemplate(tenantControlPlane.Status.Storage.Setup.User), ca, privateKey); err != nil {
-					logger.Error(err, "unable to generate certificate and private key")
+					if crt, key, err = r.generateTenantCertificate(ctx, template, ca, tenantControlPlane.KeyAlgorithm()); err != nil {
+						logger.Error(err, "unable to generate certificate and private key")
 
-					return err
+						return err
+					}
+
+					break
 				}
-			case kamajiv1alpha1.KineMySQLDriver, kamajiv1alpha1.KinePostgreSQLDriver, kamajiv1alpha1.KineNatsDriver:
+
 				var crtBytes, keyBytes []byte
 				// For the SQL drivers we just need to copy the certificate, since the basic authentication is used
 				// to connect to the desired schema and database.
@@ -170,7 +202,7 @@ func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1al
 				return fmt.Errorf("unrecognized driver for Certificate generation")
 			}
 
-			if r.DataStore.Spec.TLSConfig.ClientCertificate != nil {
+			if crt != nil {
 				r.resource.Data["server.crt"] = crt.Bytes()
 				r.resource.Data["server.key"] = key.Bytes()
 			}
@@ -188,3 +220,14 @@ func (r *Certificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1al
 		return nil
 	}
 }
+
+// generateTenantCertificate signs the certificate used by the tenant to authenticate with the DataStore Certificate Authority,
+// generating its key with the algorithm of the Tenant Control Plane.
+func (r *Certificate) generateTenantCertificate(ctx context.Context, template *x509.Certificate, ca []byte, algorithm string) (*bytes.Buffer, *bytes.Buffer, error) {
+	privateKey, err := r.DataStore.Spec.TLSConfig.CertificateAuthority.PrivateKey.GetContent(ctx, r.Client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to retrieve CA private key content: %w", err)
+	}
+
+	return crypto.GenerateCertificatePrivateKeyPair(template, ca, privateKey, algorithm)
+}
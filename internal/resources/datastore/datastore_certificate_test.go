@@ -0,0 +1,99 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/datastore"
+)
+
+var _ = Describe("DatastoreCertificate", func() {
+	It("should expose the validity of the DataStore client certificate, and the earliest expiration", func() {
+		ctx := context.Background()
+
+		Expect(kamajiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "etcd-ca"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().AddDate(1, 0, 0),
+			IsCA:                  true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+			BasicConstraintsValid: true,
+		}
+
+		caDER, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		caKeyDER, err := x509.MarshalECPrivateKey(caKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		tcp := &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "default", UID: "tcp-uid"},
+		}
+		tcp.Status.Storage.Setup.User = "default_tcp"
+
+		ds := kamajiv1alpha1.DataStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+			Spec: kamajiv1alpha1.DataStoreSpec{
+				Driver: kamajiv1alpha1.EtcdDriver,
+				TLSConfig: &kamajiv1alpha1.TLSConfig{
+					CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+						Certificate: kamajiv1alpha1.ContentRef{Content: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})},
+						PrivateKey:  &kamajiv1alpha1.ContentRef{Content: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: caKeyDER})},
+					},
+				},
+			},
+		}
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp).Build()
+
+		resource := &datastore.Certificate{Client: c, DataStore: ds, CertExpirationThreshold: time.Hour}
+		Expect(resource.Define(ctx, tcp)).To(Succeed())
+
+		_, err = resources.Handle(ctx, resource, tcp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resource.ShouldStatusBeUpdated(ctx, tcp)).To(BeTrue())
+		Expect(resource.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+		Expect(resource.ShouldStatusBeUpdated(ctx, tcp)).To(BeFalse())
+
+		var secret corev1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tcp-datastore-certificate"}, &secret)).To(Succeed())
+
+		certificate, err := crypto.ParseCertificateBytes(secret.Data["server.crt"])
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(tcp.Status.Certificates.ETCD).ToNot(BeNil())
+
+		inventory := tcp.Status.Certificates.ETCD.APIServer.CertificateInventory
+		Expect(inventory.NotAfter.Time).To(BeTemporally("==", certificate.NotAfter))
+		Expect(inventory.Issuer).To(Equal("CN=etcd-ca"))
+		Expect(inventory.NextRenewal.Time).To(BeTemporally("==", certificate.NotAfter.Add(-time.Hour)))
+		Expect(tcp.Status.Certificates.EarliestExpiry.Time).To(BeTemporally("==", certificate.NotAfter))
+	})
+})
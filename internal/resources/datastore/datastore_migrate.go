@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -124,6 +125,21 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 			fmt.Sprintf("--target-datastore=%s", tenantControlPlane.Spec.DataStore),
 		}
 
+		if d.isLive(tenantControlPlane) {
+			d.job.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
+				{
+					Name: "MIGRATION_ID",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							APIVersion: "v1",
+							FieldPath:  fmt.Sprintf("metadata.labels['%s']", batchv1.ControllerUidLabel),
+						},
+					},
+				},
+			}
+			d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, "--live", "--cutover-id=$(MIGRATION_ID)")
+		}
+
 		if annotations := tenantControlPlane.GetAnnotations(); annotations != nil {
 			v, _ := strconv.ParseBool(annotations["kamaji.clastix.io/cleanup-prior-migration"])
 			d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--cleanup-prior-migration=%t", v))
@@ -150,18 +166,39 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 
 	switch res {
 	case controllerutil.OperationResultCreated, controllerutil.OperationResultUpdated:
-		d.inProgress = true
+		// With live migration, the Tenant Control Plane is frozen only once the cutover is requested.
+		d.inProgress = !d.isLive(tenantControlPlane)
 
 		return resources.OperationResultEnqueueBack, nil
 	case controllerutil.OperationResultNone:
 
 		// Note: job.Status.Conditions can contain more than one condition on Kubernetes versions greater than v1.30
 		for _, condition := range d.job.Status.Conditions {
-			if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
+			if condition.Status != corev1.ConditionTrue || condition.Type != batchv1.JobComplete && condition.Type != batchv1.JobFailed {
+				continue
+			}
+			// The cutover request refers to the Job UID: it's meaningless once the migration is over.
+			if err = d.clearCutoverRequest(ctx, tenantControlPlane); err != nil {
+				return controllerutil.OperationResultNone, err
+			}
+
+			if condition.Type == batchv1.JobComplete {
 				return controllerutil.OperationResultNone, nil
 			}
 		}
 
+		if d.isLive(tenantControlPlane) {
+			if tenantControlPlane.GetAnnotations()[kamajiv1alpha1.MigrationCutoverAnnotation] != string(d.job.GetUID()) {
+				return controllerutil.OperationResultNone, kamajierrors.MigrationInProcessError{}
+			}
+
+			if ptr.Deref(tenantControlPlane.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionUnknown) != kamajiv1alpha1.VersionMigrating {
+				d.inProgress = true
+
+				return resources.OperationResultEnqueueBack, nil
+			}
+		}
+
 		d.inProgress = true
 
 		return controllerutil.OperationResultNone, kamajierrors.MigrationInProcessError{}
@@ -170,6 +207,33 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 	}
 }
 
+// clearCutoverRequest removes the cutover request of the live migration job from the Tenant Control Plane.
+func (d *Migrate) clearCutoverRequest(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if _, ok := tenantControlPlane.GetAnnotations()[kamajiv1alpha1.MigrationCutoverAnnotation]; !ok {
+		return nil
+	}
+
+	patch := client.MergeFrom(tenantControlPlane.DeepCopy())
+
+	annotations := tenantControlPlane.GetAnnotations()
+	delete(annotations, kamajiv1alpha1.MigrationCutoverAnnotation)
+	tenantControlPlane.SetAnnotations(annotations)
+
+	if err := d.Client.Patch(ctx, tenantControlPlane, patch); err != nil {
+		return fmt.Errorf("unable to remove the migration cutover request: %w", err)
+	}
+
+	return nil
+}
+
+// isLive returns true when the live migration has been requested: this is not available
+// between DataStores backed by different drivers, falling back to the offline migration.
+func (d *Migrate) isLive(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	v, _ := strconv.ParseBool(tenantControlPlane.GetAnnotations()[kamajiv1alpha1.LiveMigrationAnnotation])
+
+	return v && d.actualDatastore.Spec.Driver == d.desiredDatastore.Spec.Driver
+}
+
 func (d *Migrate) GetName() string {
 	return "migrate"
 }
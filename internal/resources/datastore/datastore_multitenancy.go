@@ -39,9 +39,9 @@ func (m *MultiTenancy) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPla
 
 func (m *MultiTenancy) CreateOrUpdate(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	// If the NATS Datastore is already used by a Tenant Control Plane
-	// and a new one is reclaiming it, we need to stop it, since it's not allowed.
-	// TODO(prometherion): remove this after multi-tenancy is implemented for NATS.
-	if m.DataStore.Spec.Driver != kamajiv1alpha1.KineNatsDriver {
+	// and a new one is reclaiming it, we need to stop it, since it's not allowed:
+	// without an account issuing the tenant users, they would share the DataStore credentials.
+	if m.DataStore.Spec.Driver != kamajiv1alpha1.KineNatsDriver || m.DataStore.Spec.NATSAccount != nil {
 		return controllerutil.OperationResultNone, nil
 	}
 
@@ -53,7 +53,7 @@ func (m *MultiTenancy) CreateOrUpdate(_ context.Context, tcp *kamajiv1alpha1.Ten
 	case usedBy.Len() == 0:
 		return controllerutil.OperationResultNone, nil
 	default:
-		return controllerutil.OperationResultNone, errors.New("NATS doesn't support multi-tenancy, the current datastore is already in use")
+		return controllerutil.OperationResultNone, errors.New("NATS supports multi-tenancy only with a NATS account, the current datastore is already in use")
 	}
 }
 
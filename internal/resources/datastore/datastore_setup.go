@@ -8,10 +8,13 @@ import (
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -21,12 +24,25 @@ import (
 	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
 )
 
+// dataStoreConfigChecksumAnnotation is the Deployment template annotation
+// reporting the DataStore configuration used by the Tenant Control Plane pods.
+const dataStoreConfigChecksumAnnotation = "storage.kamaji.clastix.io/config"
+
 type SetupResource struct {
-	schema   string
-	user     string
-	password string
+	schema string
+	user   string
+	// loginUser authenticates with the password: it's a member of the tenant user,
+	// when the driver alternates the credentials across login users upon a rotation.
+	loginUser string
+	password  string
+	// previousPasswordRetained is true when the password replaced by a rotation is still accepted.
+	previousPasswordRetained bool
+	// lastCredentialsRotation is the last rotation reported by the DataStore Secret, if any.
+	lastCredentialsRotation *metav1.Time
+	limits                  *kamajiv1alpha1.DataStoreLimits
 }
 
 type Setup struct {
@@ -46,7 +62,10 @@ func (r *Setup) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kam
 	return tenantControlPlane.Status.Storage.Driver != string(r.DataStore.Spec.Driver) ||
 		tenantControlPlane.Status.Storage.Setup.Checksum != tenantControlPlane.Status.Storage.Config.Checksum ||
 		tenantControlPlane.Status.Storage.Setup.User != r.resource.user ||
-		tenantControlPlane.Status.Storage.Setup.Schema != r.resource.schema
+		tenantControlPlane.Status.Storage.Setup.Schema != r.resource.schema ||
+		tenantControlPlane.Status.Storage.Setup.PreviousPasswordRetained != r.resource.previousPasswordRetained ||
+		!tenantControlPlane.Status.Storage.Setup.LastCredentialsRotation.Equal(r.resource.lastCredentialsRotation) ||
+		!equality.Semantic.DeepEqual(tenantControlPlane.Status.Storage.Setup.Limits, r.resource.limits)
 }
 
 func (r *Setup) ShouldCleanup(_ *kamajiv1alpha1.TenantControlPlane) bool {
@@ -72,9 +91,24 @@ func (r *Setup) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 	}
 
 	r.resource = &SetupResource{
-		schema:   string(secret.Data["DB_SCHEMA"]),
-		user:     string(secret.Data["DB_USER"]),
-		password: string(secret.Data["DB_PASSWORD"]),
+		schema:                   string(secret.Data["DB_SCHEMA"]),
+		user:                     string(secret.Data["DB_USER"]),
+		loginUser:                string(secret.Data["DB_USER"]),
+		password:                 string(secret.Data["DB_PASSWORD"]),
+		previousPasswordRetained: tenantControlPlane.Status.Storage.Setup.PreviousPasswordRetained,
+		limits:                   tenantControlPlane.Spec.DataStoreLimits,
+	}
+
+	if role := secret.Data[datastore.TenantRoleKey]; len(role) > 0 {
+		r.resource.user = string(role)
+	}
+
+	if r.resource.limits == nil {
+		r.resource.limits = r.DataStore.Spec.DefaultLimits
+	}
+
+	if lastRotation, ok := utilities.GetLastCredentialsRotation(secret); ok {
+		r.resource.lastCredentialsRotation = &metav1.Time{Time: lastRotation}
 	}
 
 	return nil
@@ -127,6 +161,14 @@ func (r *Setup) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 
+	operationResult, err = r.setUserLimits(ctx, tenantControlPlane, operationResult != controllerutil.OperationResultNone)
+	if err != nil {
+		logger.Error(err, "unable to set the DataStore user limits")
+
+		return reconciliationResult, err
+	}
+	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+
 	operationResult, err = r.createGrantPrivileges(ctx, tenantControlPlane)
 	if err != nil {
 		logger.Error(err, "unable to create the DataStore user privileges")
@@ -135,6 +177,14 @@ func (r *Setup) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 
+	operationResult, err = r.discardPreviousPassword(ctx, tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "unable to discard the previous DataStore user password")
+
+		return reconciliationResult, err
+	}
+	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+
 	return reconciliationResult, nil
 }
 
@@ -185,6 +235,9 @@ func (r *Setup) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlP
 	tenantControlPlane.Status.Storage.Setup.User = r.resource.user
 	tenantControlPlane.Status.Storage.Setup.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Storage.Setup.Checksum = tenantControlPlane.Status.Storage.Config.Checksum
+	tenantControlPlane.Status.Storage.Setup.PreviousPasswordRetained = r.resource.previousPasswordRetained
+	tenantControlPlane.Status.Storage.Setup.LastCredentialsRotation = r.resource.lastCredentialsRotation
+	tenantControlPlane.Status.Storage.Setup.Limits = r.resource.limits
 
 	return nil
 }
@@ -223,14 +276,35 @@ func (r *Setup) deleteDB(ctx context.Context, _ *kamajiv1alpha1.TenantControlPla
 	return nil
 }
 
-func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+func (r *Setup) createUser(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	exists, err := r.Connection.UserExists(ctx, r.resource.user)
 	if err != nil {
 		return controllerutil.OperationResultNone, fmt.Errorf("unable to check if user exists: %w", err)
 	}
+	// The user authenticating with a certificate has no password to be updated, nor rotated.
+	if r.DataStore.UsesTenantCertificates() {
+		if exists {
+			return controllerutil.OperationResultNone, nil
+		}
+
+		if err = r.Connection.CreateCertificateUser(ctx, r.resource.user); err != nil {
+			return controllerutil.OperationResultNone, fmt.Errorf("unable to create the user: %w", err)
+		}
+
+		return controllerutil.OperationResultCreated, nil
+	}
+	// The password is rotated only upon an explicit request, reported by the DataStore Secret with a new rotation timestamp:
+	// the current password is retained, if supported, since the running pods are still using it.
+	if lastRotation := r.resource.lastCredentialsRotation; exists && lastRotation != nil && !lastRotation.Equal(tenantControlPlane.Status.Storage.Setup.LastCredentialsRotation) {
+		if err = r.rotateUser(ctx); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+
+		return controllerutil.OperationResultUpdated, nil
+	}
 
 	if exists {
-		if updateErr := r.Connection.UpdateUser(ctx, r.resource.user, r.resource.password); updateErr != nil {
+		if updateErr := r.Connection.UpdateUser(ctx, r.resource.loginUser, r.resource.password); updateErr != nil {
 			return controllerutil.OperationResultNone, fmt.Errorf("unable to update the user to : %w", updateErr)
 		}
 
@@ -240,10 +314,28 @@ func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlP
 	if err := r.Connection.CreateUser(ctx, r.resource.user, r.resource.password); err != nil {
 		return controllerutil.OperationResultNone, fmt.Errorf("unable to create the user: %w", err)
 	}
+	// The credentials have been already rotated to a login user, such as when migrating to another DataStore:
+	// the login user must be created as well, discarding the tenant user login once rolled out.
+	if r.resource.loginUser != r.resource.user {
+		if err = r.rotateUser(ctx); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+	}
 
 	return controllerutil.OperationResultCreated, nil
 }
 
+func (r *Setup) rotateUser(ctx context.Context) error {
+	retained, err := r.Connection.RotateUser(ctx, r.resource.user, r.resource.loginUser, r.resource.password)
+	if err != nil {
+		return fmt.Errorf("unable to rotate the user password: %w", err)
+	}
+
+	r.resource.previousPasswordRetained = r.resource.previousPasswordRetained || retained
+
+	return nil
+}
+
 func (r *Setup) deleteUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
 	exists, err := r.Connection.UserExists(ctx, r.resource.user)
 	if err != nil {
@@ -261,6 +353,35 @@ func (r *Setup) deleteUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlP
 	return nil
 }
 
+// setUserLimits enforces the limits upon their change, or on a newly created user, such as on the target of a migration,
+// or on the login user of a rotation: the limits are enforced on the login user, since these are not inherited from the tenant one.
+func (r *Setup) setUserLimits(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, created bool) (controllerutil.OperationResult, error) {
+	if created && r.resource.limits == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	if !created && equality.Semantic.DeepEqual(tenantControlPlane.Status.Storage.Setup.Limits, r.resource.limits) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	var limits datastore.UserLimits
+
+	if l := r.resource.limits; l != nil {
+		limits.MaxConnections = ptr.Deref(l.MaxConnections, 0)
+		limits.MaxQueriesPerHour = ptr.Deref(l.MaxQueriesPerHour, 0)
+
+		if l.StatementTimeout != nil {
+			limits.StatementTimeout = l.StatementTimeout.Duration
+		}
+	}
+
+	if err := r.Connection.SetUserLimits(ctx, r.resource.loginUser, limits); err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("unable to set the user limits: %w", err)
+	}
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
 func (r *Setup) createGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	exists, err := r.Connection.GrantPrivilegesExists(ctx, r.resource.user, r.resource.schema)
 	if err != nil {
@@ -294,3 +415,39 @@ func (r *Setup) revokeGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.Ten
 
 	return nil
 }
+
+// discardPreviousPassword stops accepting the password replaced by a rotation,
+// once the Tenant Control Plane pods using it have been replaced by the ones using the new one.
+func (r *Setup) discardPreviousPassword(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.resource.previousPasswordRetained || tenantControlPlane.Status.Storage.Setup.Checksum != tenantControlPlane.Status.Storage.Config.Checksum {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	var deployment appsv1.Deployment
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.GetName()}, &deployment); err != nil {
+		return controllerutil.OperationResultNone, client.IgnoreNotFound(err)
+	}
+
+	if !isRolledOut(deployment, tenantControlPlane.Status.Storage.Config.Checksum) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	if err := r.Connection.DiscardPreviousPassword(ctx, r.resource.user, r.resource.loginUser); err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf("unable to discard the previous password: %w", err)
+	}
+
+	r.resource.previousPasswordRetained = false
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// isRolledOut returns true when all the Deployment pods are ready, and use the given DataStore configuration.
+func isRolledOut(deployment appsv1.Deployment, checksum string) bool {
+	desired := ptr.Deref(deployment.Spec.Replicas, 1)
+
+	return deployment.Spec.Template.GetAnnotations()[dataStoreConfigChecksumAnnotation] == checksum &&
+		deployment.Status.ObservedGeneration == deployment.GetGeneration() &&
+		deployment.Status.UpdatedReplicas == desired &&
+		deployment.Status.ReadyReplicas == desired &&
+		deployment.Status.Replicas == desired
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajidatastore "github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// setupConnection records the limits enforced on the tenant,
+// and the users created authenticating with a certificate.
+type setupConnection struct {
+	kamajidatastore.Connection
+	missingUser      bool
+	limits           []kamajidatastore.UserLimits
+	certificateUsers []string
+	rotations        int
+	loginUsers       []string
+}
+
+func (c *setupConnection) RotateUser(_ context.Context, _, loginUser, _ string) (bool, error) {
+	c.rotations++
+	c.loginUsers = append(c.loginUsers, loginUser)
+
+	return true, nil
+}
+
+func (c *setupConnection) CreateCertificateUser(_ context.Context, user string) error {
+	c.certificateUsers = append(c.certificateUsers, user)
+	c.missingUser = false
+
+	return nil
+}
+
+func (c *setupConnection) DBExists(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+func (c *setupConnection) UserExists(context.Context, string) (bool, error) {
+	return !c.missingUser, nil
+}
+
+func (c *setupConnection) UpdateUser(context.Context, string, string) error {
+	return nil
+}
+
+func (c *setupConnection) GrantPrivilegesExists(context.Context, string, string) (bool, error) {
+	return true, nil
+}
+
+func (c *setupConnection) SetUserLimits(_ context.Context, _ string, limits kamajidatastore.UserLimits) error {
+	c.limits = append(c.limits, limits)
+
+	return nil
+}
+
+var _ = Describe("DatastoreSetup", func() {
+	var (
+		ctx        context.Context
+		connection *setupConnection
+		setup      *datastore.Setup
+		tcp        *kamajiv1alpha1.TenantControlPlane
+	)
+
+	var secret *corev1.Secret
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "default"},
+		}
+		tcp.Status.Storage.Config.SecretName = "tcp-datastore-config"
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tcp-datastore-config", Namespace: "default"},
+			Data:       map[string][]byte{"DB_SCHEMA": []byte("schema"), "DB_USER": []byte("user"), "DB_PASSWORD": []byte("password")},
+		}
+
+		Expect(kamajiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+		connection = &setupConnection{}
+		setup = &datastore.Setup{
+			Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(tcp, secret).Build(),
+			Connection: connection,
+			DataStore: kamajiv1alpha1.DataStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql"},
+				Spec: kamajiv1alpha1.DataStoreSpec{
+					Driver:        kamajiv1alpha1.KineMySQLDriver,
+					DefaultLimits: &kamajiv1alpha1.DataStoreLimits{MaxConnections: ptr.To[int32](10)},
+				},
+			},
+		}
+	})
+
+	reconcile := func() {
+		_, err := resources.Handle(ctx, setup, tcp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(setup.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+	}
+
+	It("should enforce the user limits only upon their change", func() {
+		reconcile()
+		Expect(connection.limits).To(Equal([]kamajidatastore.UserLimits{{MaxConnections: 10}}))
+		Expect(tcp.Status.Storage.Setup.Limits).To(Equal(setup.DataStore.Spec.DefaultLimits))
+
+		reconcile()
+		Expect(connection.limits).To(HaveLen(1))
+
+		By("overriding the DataStore default limits")
+		tcp.Spec.DataStoreLimits = &kamajiv1alpha1.DataStoreLimits{StatementTimeout: &metav1.Duration{Duration: 30 * time.Second}}
+
+		reconcile()
+		Expect(connection.limits).To(HaveLen(2))
+		Expect(connection.limits[1]).To(Equal(kamajidatastore.UserLimits{StatementTimeout: 30 * time.Second}))
+		Expect(tcp.Status.Storage.Setup.Limits).To(Equal(tcp.Spec.DataStoreLimits))
+	})
+
+	It("should rotate the password only upon an explicit request", func() {
+		reconcile()
+
+		By("changing the DataStore configuration")
+		tcp.Status.Storage.Config.Checksum = "changed"
+
+		reconcile()
+		Expect(connection.rotations).To(BeZero())
+
+		By("rotating the credentials")
+		utilities.SetLastCredentialsRotationTimestamp(secret, time.Now())
+		Expect(setup.Client.Update(ctx, secret)).To(Succeed())
+
+		reconcile()
+		Expect(connection.rotations).To(Equal(1))
+		Expect(tcp.Status.Storage.Setup.PreviousPasswordRetained).To(BeTrue())
+		Expect(tcp.Status.Storage.Setup.LastCredentialsRotation).ToNot(BeNil())
+
+		reconcile()
+		Expect(connection.rotations).To(Equal(1))
+	})
+
+	It("should rotate the password of the login user, member of the tenant one", func() {
+		reconcile()
+
+		By("rotating the credentials to a login user")
+		secret.Data["DB_USER"] = []byte("user_1")
+		secret.Data[kamajidatastore.TenantRoleKey] = []byte("user")
+		utilities.SetLastCredentialsRotationTimestamp(secret, time.Now())
+		Expect(setup.Client.Update(ctx, secret)).To(Succeed())
+
+		reconcile()
+		Expect(connection.loginUsers).To(Equal([]string{"user_1"}))
+		Expect(tcp.Status.Storage.Setup.User).To(Equal("user"))
+		Expect(connection.limits).To(HaveLen(2), "the limits must be enforced on the login user as well")
+	})
+
+	It("should create the user authenticating with a certificate", func() {
+		connection.missingUser = true
+		setup.DataStore.Spec.TenantAuthentication = kamajiv1alpha1.DataStoreTenantAuthenticationCertificate
+
+		reconcile()
+		Expect(connection.certificateUsers).To(Equal([]string{"user"}))
+
+		reconcile()
+		Expect(connection.certificateUsers).To(HaveLen(1))
+	})
+})
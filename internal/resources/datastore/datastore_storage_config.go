@@ -4,8 +4,12 @@
 package datastore
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/url"
+	"path"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
@@ -18,9 +22,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers/finalizers"
+	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -109,6 +115,10 @@ func (r *Config) UpdateTenantControlPlaneStatus(_ context.Context, tenantControl
 		tenantControlPlane.Status.Storage.DataStoreName = r.DataStore.GetName()
 		tenantControlPlane.Status.Storage.Config.SecretName = r.resource.GetName()
 		tenantControlPlane.Status.Storage.Config.Checksum = utilities.GetObjectChecksum(r.resource)
+
+		if lastRotation, ok := utilities.GetLastCredentialsRotation(r.resource); ok {
+			tenantControlPlane.Status.Storage.Config.LastCredentialsRotation = &metav1.Time{Time: lastRotation}
+		}
 	}
 
 	return nil
@@ -119,22 +129,40 @@ func (r *Config) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.
 		var password []byte
 		var username []byte
 
+		// The password is generated again upon a rotation request:
+		// the DataStore user is updated by the datastore-setup resource, and the checksum change rolls out the Deployment.
+		// The request is kept pending until the previous rotation has been completed.
+		isRotationRequested := utilities.IsCredentialsRotationRequested(r.resource) && isCredentialsRotationCompleted(tenantControlPlane)
+		// The NATS users cannot be revoked by Kamaji, since the revocations are stored in the account JWT,
+		// which is signed by the operator: a rotation would leave the previous user valid, thus it's rejected.
+		if r.DataStore.Spec.Driver == kamajiv1alpha1.KineNatsDriver && utilities.IsCredentialsRotationRequested(r.resource) {
+			log.FromContext(ctx).Info("rejecting the credentials rotation request, since the previous NATS user cannot be revoked")
+
+			utilities.RemoveCredentialsRotationRequest(r.resource)
+
+			isRotationRequested = false
+		}
+
 		hash := utilities.GetObjectChecksum(r.resource)
 		switch {
-		case len(hash) > 0 && hash == utilities.CalculateMapChecksum(r.resource.Data):
+		case !isRotationRequested && len(hash) > 0 && hash == utilities.CalculateMapChecksum(r.resource.Data):
 			password = r.resource.Data["DB_PASSWORD"]
 		default:
 			password = []byte(uuid.New().String())
 		}
 
+		if isRotationRequested {
+			utilities.SetLastCredentialsRotationTimestamp(r.resource, time.Now())
+		}
+
 		finalizersList := sets.New[string](r.resource.GetFinalizers()...)
 		finalizersList.Insert(finalizers.DatastoreSecretFinalizer)
 		r.resource.SetFinalizers(finalizersList.UnsortedList())
 
-		// TODO(thecodeassassin): remove this after multi-tenancy is implemented for NATS.
-		// Due to NATS is missing a programmatic approach to create users and password,
-		// we're using the Datastore root password.
-		if r.DataStore.Spec.Driver == kamajiv1alpha1.KineNatsDriver {
+		// Without a NATS account issuing the tenant users,
+		// NATS is missing a programmatic approach to create users and password,
+		// thus we're using the Datastore root password.
+		if r.DataStore.Spec.Driver == kamajiv1alpha1.KineNatsDriver && r.DataStore.Spec.NATSAccount == nil {
 			// set username and password to the basicAuth values of the NATS datastore
 			u, err := r.DataStore.Spec.BasicAuth.Username.GetContent(ctx, r.Client)
 			if err != nil {
@@ -184,13 +212,56 @@ func (r *Config) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.
 			dataStoreSchema = tenantControlPlane.GetDefaultDatastoreSchema()
 		}
 
+		// The current NATS credentials are kept as long as they're still valid.
+		currentNATSCredentials := r.resource.Data[datastore.NATSCredentialsKey]
+		// PostgreSQL doesn't support dual passwords: the credentials alternate between two login roles, members of the tenant one,
+		// thus the previous login role keeps working until the Tenant Control Plane has been rolled out.
+		loginUser := username
+		if r.DataStore.Spec.Driver == kamajiv1alpha1.KinePostgreSQLDriver && !r.DataStore.UsesTenantCertificates() {
+			switch {
+			case isRotationRequested:
+				loginUser = []byte(datastore.PostgreSQLLoginRole(string(username), string(r.resource.Data["DB_USER"])))
+			case bytes.Equal(r.resource.Data[datastore.TenantRoleKey], username):
+				loginUser = r.resource.Data["DB_USER"]
+			}
+		}
+
 		r.resource.Data = map[string][]byte{
 			"DB_CONNECTION_STRING": []byte(r.ConnString),
 			"DB_SCHEMA":            []byte(dataStoreSchema),
-			"DB_USER":              username,
+			"DB_USER":              loginUser,
 			"DB_PASSWORD":          password,
 		}
 
+		if !bytes.Equal(loginUser, username) {
+			r.resource.Data[datastore.TenantRoleKey] = username
+		}
+		// The connection parameters are appended to the kine endpoint as query parameters,
+		// along with the TLS server name verified by kine, keeping the endpoints as they are.
+		if serverName := r.DataStore.KineTLSServerName(); len(r.DataStore.Spec.Parameters) > 0 || serverName != "" {
+			parameters := make(url.Values, len(r.DataStore.Spec.Parameters)+1)
+			for k, v := range r.DataStore.Spec.Parameters {
+				parameters.Set(k, v)
+			}
+
+			if serverName != "" {
+				parameters.Set(datastore.KineTLSServerNameParameter, serverName)
+			}
+
+			r.resource.Data["DB_PARAMETERS"] = []byte(parameters.Encode())
+		}
+
+		// The tenant user authenticates with the certificate issued by the datastore-certificate resource.
+		if r.DataStore.UsesTenantCertificates() {
+			delete(r.resource.Data, "DB_PASSWORD")
+		}
+
+		if r.DataStore.Spec.Driver == kamajiv1alpha1.KineNatsDriver && r.DataStore.Spec.NATSAccount != nil {
+			if err := r.natsCredentials(ctx, string(username), password, dataStoreSchema, currentNATSCredentials); err != nil {
+				return err
+			}
+		}
+
 		utilities.SetObjectChecksum(r.resource, r.resource.Data)
 
 		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
@@ -198,3 +269,41 @@ func (r *Config) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.
 		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
+
+// isCredentialsRotationCompleted returns true when the DataStore user has been updated with the current credentials,
+// and the password replaced by the last rotation is no longer accepted, since the Tenant Control Plane pods have been rolled out.
+func isCredentialsRotationCompleted(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Status.Storage.Setup.Checksum == tenantControlPlane.Status.Storage.Config.Checksum &&
+		!tenantControlPlane.Status.Storage.Setup.PreviousPasswordRetained
+}
+
+// natsCredentials issues the NATS user of the Tenant Control Plane from the DataStore account:
+// the password is replaced by the user NKey seed, and the credentials are stored along with the NATS context used by kine.
+func (r *Config) natsCredentials(ctx context.Context, username string, password []byte, bucket string, current []byte) error {
+	signingKey, err := r.DataStore.Spec.NATSAccount.SigningKey.GetContent(ctx, r.Client)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the signing key for the NATS account: %w", err)
+	}
+
+	if !datastore.IsNATSUserSeed(password) {
+		if password, err = datastore.NewNATSUserSeed(); err != nil {
+			return fmt.Errorf("failed to generate the NATS user: %w", err)
+		}
+	}
+
+	credentials, err := datastore.NATSTenantCredentials(signingKey, r.DataStore.Spec.NATSAccount.PublicKey, username, password, bucket, current)
+	if err != nil {
+		return err
+	}
+
+	natsContext, err := datastore.NATSTenantContext(path.Join(datastore.NATSCredentialsFolder, datastore.NATSCredentialsKey), bucket)
+	if err != nil {
+		return err
+	}
+
+	r.resource.Data["DB_PASSWORD"] = password
+	r.resource.Data[datastore.NATSCredentialsKey] = credentials
+	r.resource.Data[datastore.NATSContextKey] = natsContext
+
+	return nil
+}
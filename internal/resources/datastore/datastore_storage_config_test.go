@@ -6,6 +6,7 @@ package datastore_test
 import (
 	"context"
 
+	"github.com/nats-io/nkeys"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -15,8 +16,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajidatastore "github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
 )
 
 var _ = Describe("DatastoreStorageConfig", func() {
@@ -127,4 +130,220 @@ var _ = Describe("DatastoreStorageConfig", func() {
 			tcp.Spec.DataStoreUsername = "existing-username"
 		})
 	})
+
+	When("the rotation of the credentials is requested", func() {
+		It("should generate a new password, reporting the rotation timestamp", func() {
+			_, err := resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets := &corev1.SecretList{}
+			Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+
+			secret := secrets.Items[0]
+			secret.SetAnnotations(utilities.MergeMaps(secret.GetAnnotations(), map[string]string{utilities.RotateCredentialsRequestAnnotation: ""}))
+			Expect(fakeClient.Update(ctx, &secret)).To(Succeed())
+
+			_, err = resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			rotated := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&secret), rotated)).To(Succeed())
+			Expect(rotated.Data["DB_PASSWORD"]).ToNot(Equal(secret.Data["DB_PASSWORD"]))
+			Expect(rotated.Data["DB_USER"]).To(Equal(secret.Data["DB_USER"]))
+			Expect(utilities.IsCredentialsRotationRequested(rotated)).To(BeFalse())
+
+			_, ok := utilities.GetLastCredentialsRotation(rotated)
+			Expect(ok).To(BeTrue())
+
+			Expect(dsc.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+			Expect(tcp.Status.Storage.Config.LastCredentialsRotation).ToNot(BeNil())
+		})
+
+		It("should keep the request pending until the previous password has been discarded", func() {
+			_, err := resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsc.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+
+			tcp.Status.Storage.Setup.Checksum = tcp.Status.Storage.Config.Checksum
+			tcp.Status.Storage.Setup.PreviousPasswordRetained = true
+
+			secrets := &corev1.SecretList{}
+			Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+
+			secret := secrets.Items[0]
+			secret.SetAnnotations(utilities.MergeMaps(secret.GetAnnotations(), map[string]string{utilities.RotateCredentialsRequestAnnotation: ""}))
+			Expect(fakeClient.Update(ctx, &secret)).To(Succeed())
+
+			_, err = resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			pending := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&secret), pending)).To(Succeed())
+			Expect(pending.Data["DB_PASSWORD"]).To(Equal(secret.Data["DB_PASSWORD"]))
+			Expect(utilities.IsCredentialsRotationRequested(pending)).To(BeTrue())
+
+			By("discarding the previous password")
+			tcp.Status.Storage.Setup.PreviousPasswordRetained = false
+
+			_, err = resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			rotated := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&secret), rotated)).To(Succeed())
+			Expect(rotated.Data["DB_PASSWORD"]).ToNot(Equal(secret.Data["DB_PASSWORD"]))
+			Expect(utilities.IsCredentialsRotationRequested(rotated)).To(BeFalse())
+		})
+	})
+
+	When("the rotation of the PostgreSQL credentials is requested", func() {
+		BeforeEach(func() {
+			ds.Spec.Driver = kamajiv1alpha1.KinePostgreSQLDriver
+			tcp.Spec.DataStoreUsername = "tenant"
+		})
+
+		rotate := func() *corev1.Secret {
+			secrets := &corev1.SecretList{}
+			Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+
+			secret := secrets.Items[0]
+			secret.SetAnnotations(utilities.MergeMaps(secret.GetAnnotations(), map[string]string{utilities.RotateCredentialsRequestAnnotation: ""}))
+			Expect(fakeClient.Update(ctx, &secret)).To(Succeed())
+
+			_, err := resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			rotated := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&secret), rotated)).To(Succeed())
+			Expect(utilities.IsCredentialsRotationRequested(rotated)).To(BeFalse())
+
+			Expect(dsc.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+			tcp.Status.Storage.Setup.Checksum = tcp.Status.Storage.Config.Checksum
+
+			return rotated
+		}
+
+		It("should alternate between the login roles of the tenant role", func() {
+			_, err := resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dsc.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+			tcp.Status.Storage.Setup.Checksum = tcp.Status.Storage.Config.Checksum
+
+			rotated := rotate()
+			Expect(rotated.Data["DB_USER"]).To(Equal([]byte("tenant_1")))
+			Expect(rotated.Data[kamajidatastore.TenantRoleKey]).To(Equal([]byte("tenant")))
+
+			By("reconciling without a rotation request")
+			_, err = resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			current := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(rotated), current)).To(Succeed())
+			Expect(current.Data).To(Equal(rotated.Data))
+
+			rotated = rotate()
+			Expect(rotated.Data["DB_USER"]).To(Equal([]byte("tenant_2")))
+			Expect(rotated.Data[kamajidatastore.TenantRoleKey]).To(Equal([]byte("tenant")))
+
+			rotated = rotate()
+			Expect(rotated.Data["DB_USER"]).To(Equal([]byte("tenant_1")))
+		})
+	})
+
+	When("the rotation of the NATS credentials is requested", func() {
+		BeforeEach(func() {
+			ds.Spec.Driver = kamajiv1alpha1.KineNatsDriver
+			ds.Spec.BasicAuth = &kamajiv1alpha1.BasicAuth{
+				Username: kamajiv1alpha1.ContentRef{Content: []byte("nats")},
+				Password: kamajiv1alpha1.ContentRef{Content: []byte("secret")},
+			}
+		})
+
+		It("should reject the request, since the previous user cannot be revoked", func() {
+			_, err := resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets := &corev1.SecretList{}
+			Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+
+			secret := secrets.Items[0]
+			secret.SetAnnotations(utilities.MergeMaps(secret.GetAnnotations(), map[string]string{utilities.RotateCredentialsRequestAnnotation: ""}))
+			Expect(fakeClient.Update(ctx, &secret)).To(Succeed())
+
+			_, err = resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			rejected := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&secret), rejected)).To(Succeed())
+			Expect(utilities.IsCredentialsRotationRequested(rejected)).To(BeFalse())
+			Expect(rejected.Data).To(Equal(secret.Data))
+
+			_, ok := utilities.GetLastCredentialsRotation(rejected)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("the DataStore has connection parameters and a TLS server name", func() {
+		BeforeEach(func() {
+			ds.Spec.Driver = kamajiv1alpha1.KineMySQLDriver
+			ds.Spec.Endpoints = kamajiv1alpha1.Endpoints{"10.0.0.10:3306"}
+			ds.Spec.TLSConfig = &kamajiv1alpha1.TLSConfig{ServerName: "mysql.example.com"}
+			ds.Spec.Parameters = map[string]string{"timeout": "5s", "readTimeout": "30s"}
+		})
+
+		It("should store the parameters along with the TLS server name, keeping the endpoint", func() {
+			dsc.ConnString = "10.0.0.10:3306"
+
+			_, err := resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets := &corev1.SecretList{}
+			Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+			Expect(secrets.Items[0].Data["DB_CONNECTION_STRING"]).To(Equal([]byte("10.0.0.10:3306")))
+			Expect(secrets.Items[0].Data["DB_PARAMETERS"]).To(Equal([]byte("readTimeout=30s&timeout=5s&tls-server-name=mysql.example.com")))
+		})
+	})
+
+	When("the NATS DataStore has an account issuing the tenant users", func() {
+		BeforeEach(func() {
+			account, err := nkeys.CreateAccount()
+			Expect(err).ToNot(HaveOccurred())
+
+			seed, err := account.Seed()
+			Expect(err).ToNot(HaveOccurred())
+
+			ds.Spec.Driver = kamajiv1alpha1.KineNatsDriver
+			ds.Spec.NATSAccount = &kamajiv1alpha1.NATSAccount{SigningKey: kamajiv1alpha1.ContentRef{Content: seed}}
+		})
+
+		It("should store the tenant credentials, preserving them across reconciliations", func() {
+			op, err := resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(controllerutil.OperationResultCreated))
+
+			secrets := &corev1.SecretList{}
+			Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+
+			secret := secrets.Items[0]
+			Expect(secret.Data["DB_USER"]).To(Equal([]byte(tcp.UID)))
+			Expect(secret.Data["DB_PASSWORD"]).To(HavePrefix("SU"))
+
+			jwt, err := nkeys.ParseDecoratedJWT(secret.Data["NATS_CREDENTIALS"])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jwt).ToNot(BeEmpty())
+			Expect(secret.Data["NATS_CONTEXT"]).To(ContainSubstring("/nats/NATS_CREDENTIALS"))
+
+			_, err = resources.Handle(ctx, dsc, tcp)
+			Expect(err).ToNot(HaveOccurred())
+
+			reconciled := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&secret), reconciled)).To(Succeed())
+			Expect(reconciled.Data).To(Equal(secret.Data))
+		})
+	})
 })
@@ -40,7 +40,8 @@ func (r *FrontProxyClientCertificate) GetHistogram() prometheus.Histogram {
 }
 
 func (r *FrontProxyClientCertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Certificates.FrontProxyClient.Checksum != utilities.GetObjectChecksum(r.resource)
+	return tenantControlPlane.Status.Certificates.FrontProxyClient.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		IsCertificateInventoryChanged(tenantControlPlane.Status.Certificates.FrontProxyClient.CertificateInventory, r.certificateInventory())
 }
 
 func (r *FrontProxyClientCertificate) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -86,10 +87,15 @@ func (r *FrontProxyClientCertificate) UpdateTenantControlPlaneStatus(_ context.C
 	tenantControlPlane.Status.Certificates.FrontProxyClient.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Certificates.FrontProxyClient.SecretName = r.resource.GetName()
 	tenantControlPlane.Status.Certificates.FrontProxyClient.Checksum = utilities.GetObjectChecksum(r.resource)
+	SetCertificateInventory(tenantControlPlane, &tenantControlPlane.Status.Certificates.FrontProxyClient.CertificateInventory, r.certificateInventory())
 
 	return nil
 }
 
+func (r *FrontProxyClientCertificate) certificateInventory() kamajiv1alpha1.CertificateInventory {
+	return CertificateInventory(r.resource, r.resource.Data[kubeadmconstants.FrontProxyClientCertName], r.CertExpirationThreshold)
+}
+
 func (r *FrontProxyClientCertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
@@ -134,7 +140,12 @@ func (r *FrontProxyClientCertificate) mutate(ctx context.Context, tenantControlP
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.FrontProxyClientCertAndKeyBaseName, err.Error()))
 			}
 
-			if isValid && isCAValid {
+			isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[kubeadmconstants.FrontProxyClientKeyName], tenantControlPlane.KeyAlgorithm())
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", kubeadmconstants.FrontProxyClientCertAndKeyBaseName, err.Error()))
+			}
+
+			if isValid && isCAValid && isAlgorithmValid {
 				return nil
 			}
 		}
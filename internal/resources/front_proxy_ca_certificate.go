@@ -37,7 +37,8 @@ func (r *FrontProxyCACertificate) GetHistogram() prometheus.Histogram {
 }
 
 func (r *FrontProxyCACertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Certificates.FrontProxyCA.Checksum != utilities.GetObjectChecksum(r.resource)
+	return tenantControlPlane.Status.Certificates.FrontProxyCA.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		IsCertificateInventoryChanged(tenantControlPlane.Status.Certificates.FrontProxyCA.CertificateInventory, r.certificateInventory())
 }
 
 func (r *FrontProxyCACertificate) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -83,10 +84,15 @@ func (r *FrontProxyCACertificate) UpdateTenantControlPlaneStatus(_ context.Conte
 	tenantControlPlane.Status.Certificates.FrontProxyCA.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Certificates.FrontProxyCA.SecretName = r.resource.GetName()
 	tenantControlPlane.Status.Certificates.FrontProxyCA.Checksum = utilities.GetObjectChecksum(r.resource)
+	SetCertificateInventory(tenantControlPlane, &tenantControlPlane.Status.Certificates.FrontProxyCA.CertificateInventory, r.certificateInventory())
 
 	return nil
 }
 
+func (r *FrontProxyCACertificate) certificateInventory() kamajiv1alpha1.CertificateInventory {
+	return CertificateInventory(r.resource, r.resource.Data[kubeadmconstants.FrontProxyCACertName], r.CertExpirationThreshold)
+}
+
 func (r *FrontProxyCACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
@@ -102,7 +108,12 @@ func (r *FrontProxyCACertificate) mutate(ctx context.Context, tenantControlPlane
 			if err != nil {
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", kubeadmconstants.FrontProxyCACertAndKeyBaseName, err.Error()))
 			}
-			if isValid {
+
+			isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[kubeadmconstants.FrontProxyCAKeyName], tenantControlPlane.KeyAlgorithm())
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", kubeadmconstants.FrontProxyCACertAndKeyBaseName, err.Error()))
+			}
+			if isValid && isAlgorithmValid {
 				return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 			}
 		}
@@ -83,12 +83,14 @@ func (r *KubernetesDeploymentResource) GetName() string {
 }
 
 func (r *KubernetesDeploymentResource) computeStatus(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.KubernetesVersionStatus {
+	writePermissions := tenantControlPlane.EffectiveWritePermissions()
+
 	switch {
 	case ptr.Deref(tenantControlPlane.Spec.ControlPlane.Deployment.Replicas, 2) == 0:
 		return &kamajiv1alpha1.VersionSleeping
 	case r.isNotReady():
 		return &kamajiv1alpha1.VersionNotReady
-	case tenantControlPlane.Spec.WritePermissions.HasAnyLimitation():
+	case writePermissions.HasAnyLimitation():
 		return &kamajiv1alpha1.VersionWriteLimited
 	case !r.isProgressingUpgrade():
 		return &kamajiv1alpha1.VersionReady
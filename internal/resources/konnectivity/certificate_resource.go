@@ -139,7 +139,12 @@ func (r *CertificateResource) mutate(ctx context.Context, tenantControlPlane *ka
 			if err != nil {
 				logger.Info(fmt.Sprintf("%s certificate-private_key pair is not valid: %s", konnectivityCertAndKeyBaseName, err.Error()))
 			}
-			if isCAValid && isValid {
+
+			isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[corev1.TLSPrivateKeyKey], tenantControlPlane.KeyAlgorithm())
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", konnectivityCertAndKeyBaseName, err.Error()))
+			}
+			if isCAValid && isValid && isAlgorithmValid {
 				return nil
 			}
 		}
@@ -150,7 +155,7 @@ func (r *CertificateResource) mutate(ctx context.Context, tenantControlPlane *ka
 			PrivateKey:  secretCA.Data[kubeadmconstants.CAKeyName],
 		}
 
-		cert, privKey, err := crypto.GenerateCertificatePrivateKeyPair(crypto.NewCertificateTemplate(CertCommonName), ca.Certificate, ca.PrivateKey)
+		cert, privKey, err := crypto.GenerateCertificatePrivateKeyPair(crypto.NewCertificateTemplate(CertCommonName), ca.Certificate, ca.PrivateKey, tenantControlPlane.KeyAlgorithm())
 		if err != nil {
 			logger.Error(err, "unable to generate certificate and private key")
 
@@ -144,7 +144,7 @@ func createSignedKonnectivityCert(caCert, caKey []byte) ([]byte, []byte) {
 	template.NotBefore = time.Now().Add(-1 * time.Minute)
 	template.NotAfter = time.Now().Add(24 * time.Hour)
 
-	cert, key, err := crypto.GenerateCertificatePrivateKeyPair(template, caCert, caKey)
+	cert, key, err := crypto.GenerateCertificatePrivateKeyPair(template, caCert, caKey, "")
 	Expect(err).NotTo(HaveOccurred())
 
 	return cert.Bytes(), key.Bytes()
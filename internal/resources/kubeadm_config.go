@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -142,6 +143,7 @@ func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *
 			TenantControlPlaneVersion:       tenantControlPlane.Spec.Kubernetes.Version,
 			ETCDs:                           r.ETCDs,
 			CertificatesDir:                 r.TmpDirectory,
+			EncryptionAlgorithm:             kubeadmapi.EncryptionAlgorithmType(tenantControlPlane.KeyAlgorithm()),
 		}
 
 		config, err := kubeadm.CreateKubeadmInitConfiguration(params)
@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -106,6 +107,16 @@ func KubeadmBootstrap(ctx context.Context, r KubeadmPhaseResource, logger logr.L
 		}
 	}
 
+	var caSecret corev1.Secret
+	if err = r.GetClient().Get(ctx, types.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}, &caSecret); err != nil {
+		logger.Error(err, "cannot retrieve Certificate Authority Secret")
+
+		return controllerutil.OperationResultNone, err
+	}
+	// The trust bundle is part of the checksum, to republish the cluster-info ConfigMap
+	// upon any change of the trusted Certificate Authorities.
+	trustBundle := caSecret.Data[constants.CATrustBundleKeyName]
+
 	status, err := r.GetStatus(tenantControlPlane)
 	if err != nil {
 		logger.Error(err, "cannot retrieve status")
@@ -114,7 +125,7 @@ func KubeadmBootstrap(ctx context.Context, r KubeadmPhaseResource, logger logr.L
 	}
 
 	if status != nil {
-		checksum = utilities.CalculateMapChecksum(clusterInfo.Data)
+		checksum = utilities.CalculateMapChecksum(utilities.MergeMaps(clusterInfo.Data, map[string]string{constants.CATrustBundleKeyName: string(trustBundle)}))
 
 		if checksum == status.GetChecksum() {
 			r.SetKubeadmConfigChecksum(checksum)
@@ -138,6 +149,7 @@ func KubeadmBootstrap(ctx context.Context, r KubeadmPhaseResource, logger logr.L
 	}
 
 	config.Kubeconfig = *kubeconfig
+	config.TrustBundle = trustBundle
 
 	fun, err := r.GetKubeadmFunction(ctx, tenantControlPlane)
 	if err != nil {
@@ -51,12 +51,14 @@ func (r *KubeconfigResource) GetHistogram() prometheus.Histogram {
 func (r *KubeconfigResource) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
 	// an update is required only in case of missing status checksum, or name:
 	// this data is required by the following resource handlers.
+	// The certificate inventory is kept in sync too, refreshed upon the client certificate renewal.
 	status, err := r.getKubeconfigStatus(tcp)
 	if err != nil {
 		return false
 	}
 
-	return len(status.Checksum) == 0 || len(status.SecretName) == 0
+	return len(status.Checksum) == 0 || len(status.SecretName) == 0 ||
+		r.hasCertificateInventory() && IsCertificateInventoryChanged(status.CertificateInventory, r.certificateInventory())
 }
 
 func (r *KubeconfigResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -108,9 +110,29 @@ func (r *KubeconfigResource) UpdateTenantControlPlaneStatus(ctx context.Context,
 	status.SecretName = r.resource.GetName()
 	status.Checksum = utilities.GetObjectChecksum(r.resource)
 
+	if r.hasCertificateInventory() {
+		SetCertificateInventory(tenantControlPlane, &status.CertificateInventory, r.certificateInventory())
+	}
+
 	return nil
 }
 
+// hasCertificateInventory returns false for the super-admin kubeconfig: it shares the status with the admin one,
+// which is the only one reporting the inventory of its client certificate.
+func (r *KubeconfigResource) hasCertificateInventory() bool {
+	return r.KubeConfigFileName != kubeadmconstants.SuperAdminKubeConfigFileName
+}
+
+// certificateInventory returns the inventory of the kubeconfig client certificate.
+func (r *KubeconfigResource) certificateInventory() kamajiv1alpha1.CertificateInventory {
+	kubeconfig, err := utilities.DecodeKubeconfigYAML(r.resource.Data[r.KubeConfigFileName])
+	if err != nil || len(kubeconfig.AuthInfos) == 0 {
+		return kamajiv1alpha1.CertificateInventory{}
+	}
+
+	return CertificateInventory(r.resource, kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData, r.CertExpirationThreshold)
+}
+
 func (r *KubeconfigResource) getKubeconfigStatus(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*kamajiv1alpha1.KubeconfigStatus, error) {
 	switch r.KubeConfigFileName {
 	case kubeadmconstants.AdminKubeConfigFileName, kubeadmconstants.SuperAdminKubeConfigFileName:
@@ -137,6 +159,15 @@ func (r *KubeconfigResource) checksum(caCertificatesSecret *corev1.Secret, kubea
 	})
 }
 
+// getCABundle returns the bundle of the Certificate Authority issued by an external one, falling back to its certificate.
+func getCABundle(caCertificatesSecret *corev1.Secret) []byte {
+	if bundle := caCertificatesSecret.Data[constants.CABundleKeyName]; len(bundle) > 0 {
+		return bundle
+	}
+
+	return caCertificatesSecret.Data[kubeadmconstants.CACertName]
+}
+
 //nolint:gocognit
 func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
@@ -192,7 +223,7 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 		shouldCreate = shouldCreate || r.resource.Data == nil                          // Missing data key
 		shouldCreate = shouldCreate || len(r.resource.Data) == 0                       // Missing data key
 		shouldCreate = shouldCreate || len(r.resource.Data[r.KubeConfigFileName]) == 0 // Missing kubeconfig file, must be generated
-		shouldCreate = shouldCreate || !kubeadm.IsKubeconfigCAValid(r.resource.Data[r.KubeConfigFileName], caCertificatesSecret.Data[kubeadmconstants.CACertName])
+		shouldCreate = shouldCreate || !kubeadm.IsKubeconfigCAValid(r.resource.Data[r.KubeConfigFileName], getCABundle(caCertificatesSecret))
 		shouldCreate = shouldCreate || !kubeadm.IsKubeconfigValid(r.resource.Data[r.KubeConfigFileName], r.CertExpirationThreshold) // invalid kubeconfig, or expired client certificate
 		shouldCreate = shouldCreate || status.Checksum != checksum || len(r.resource.UID) == 0                                      // Wrong checksum
 
@@ -207,6 +238,7 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 			crtKeyPair := kubeadm.CertificatePrivateKeyPair{
 				Certificate: caCertificatesSecret.Data[kubeadmconstants.CACertName],
 				PrivateKey:  caCertificatesSecret.Data[kubeadmconstants.CAKeyName],
+				Bundle:      caCertificatesSecret.Data[constants.CABundleKeyName],
 			}
 
 			if r.resource.Data == nil {
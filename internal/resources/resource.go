@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	clientset "k8s.io/client-go/kubernetes"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -129,6 +130,10 @@ func getStoredKubeadmConfiguration(ctx context.Context, client client.Client, tm
 	if len(tmpDirectory) > 0 {
 		config.InitConfiguration.ClusterConfiguration.CertificatesDir = tmpDirectory
 	}
+	// The stored configuration could lag behind a change of the key algorithm.
+	if algorithm := tenantControlPlane.KeyAlgorithm(); len(algorithm) > 0 {
+		config.InitConfiguration.ClusterConfiguration.EncryptionAlgorithm = kubeadmapi.EncryptionAlgorithmType(algorithm)
+	}
 
 	return config, nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,7 +38,9 @@ func (r *SACertificate) GetHistogram() prometheus.Histogram {
 
 func (r *SACertificate) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
 	return tenantControlPlane.Status.Certificates.SA.SecretName != r.resource.GetName() ||
-		tenantControlPlane.Status.Certificates.SA.Checksum != utilities.GetObjectChecksum(r.resource)
+		tenantControlPlane.Status.Certificates.SA.Checksum != utilities.GetObjectChecksum(r.resource) ||
+		!equality.Semantic.DeepEqual(tenantControlPlane.Status.Certificates.SA.LastRotation, r.lastRotation()) ||
+		!equality.Semantic.DeepEqual(tenantControlPlane.Status.Certificates.SA.VerificationKeys, r.verificationKeys())
 }
 
 func (r *SACertificate) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -83,6 +86,8 @@ func (r *SACertificate) UpdateTenantControlPlaneStatus(_ context.Context, tenant
 	tenantControlPlane.Status.Certificates.SA.LastUpdate = metav1.Now()
 	tenantControlPlane.Status.Certificates.SA.SecretName = r.resource.GetName()
 	tenantControlPlane.Status.Certificates.SA.Checksum = utilities.GetObjectChecksum(r.resource)
+	tenantControlPlane.Status.Certificates.SA.LastRotation = r.lastRotation()
+	tenantControlPlane.Status.Certificates.SA.VerificationKeys = r.verificationKeys()
 
 	return nil
 }
@@ -91,14 +96,24 @@ func (r *SACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
 
-		isRotationRequested := utilities.IsRotationRequested(r.resource)
+		isRotationRequested := utilities.IsRotationRequested(r.resource) || r.isRotationScheduled(tenantControlPlane)
 
 		if checksum := tenantControlPlane.Status.Certificates.SA.Checksum; !isRotationRequested && (len(checksum) > 0 && checksum == utilities.GetObjectChecksum(r.resource) || len(r.resource.UID) > 0) {
 			isValid, err := crypto.CheckPublicAndPrivateKeyValidity(r.resource.Data[kubeadmconstants.ServiceAccountPublicKeyName], r.resource.Data[kubeadmconstants.ServiceAccountPrivateKeyName])
 			if err != nil {
 				logger.Info(fmt.Sprintf("%s public_key-private_key pair is not valid: %s", kubeadmconstants.ServiceAccountKeyBaseName, err.Error()))
 			}
-			if isValid {
+
+			isAlgorithmValid, err := crypto.CheckPrivateKeyAlgorithm(r.resource.Data[kubeadmconstants.ServiceAccountPrivateKeyName], tenantControlPlane.KeyAlgorithm())
+			if err != nil {
+				logger.Info(fmt.Sprintf("%s private_key algorithm check failed: %s", kubeadmconstants.ServiceAccountKeyBaseName, err.Error()))
+			}
+			if isValid && isAlgorithmValid {
+				// Dropping the retired public keys once their retention period elapsed.
+				r.setKeys(r.resource.Data[kubeadmconstants.ServiceAccountPublicKeyName], r.resource.Data[kubeadmconstants.ServiceAccountPrivateKeyName], r.retiredKeys(tenantControlPlane))
+
+				utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
 				return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 			}
 		}
@@ -117,14 +132,11 @@ func (r *SACertificate) mutate(ctx context.Context, tenantControlPlane *kamajiv1
 			return err
 		}
 
-		r.resource.Data = map[string][]byte{
-			kubeadmconstants.ServiceAccountPublicKeyName:  sa.PublicKey,
-			kubeadmconstants.ServiceAccountPrivateKeyName: sa.PrivateKey,
-		}
+		r.setKeys(sa.PublicKey, sa.PrivateKey, r.retireSigningKey(tenantControlPlane))
 
 		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
-
-		if isRotationRequested {
+		// Replacing an existing key is a rotation too, moving forward the scheduled one.
+		if isRotationRequested || len(r.resource.UID) > 0 {
 			utilities.SetLastRotationTimestamp(r.resource)
 		}
 
@@ -0,0 +1,193 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ServiceAccountKeyRotationRequeueAfter returns the remaining time before the scheduled rotation of the Service Account signing key,
+// or before the retention period of a retired key elapses, zero when none of them is expected.
+func ServiceAccountKeyRotationRequeueAfter(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) time.Duration {
+	interval, retentionPeriod, isEnabled := tenantControlPlane.ServiceAccountKeyRotation()
+	if !isEnabled {
+		return 0
+	}
+
+	var after time.Duration
+
+	next := func(at time.Time) {
+		if remaining := time.Until(at); remaining > 0 && (after == 0 || remaining < after) {
+			after = remaining
+		}
+	}
+
+	status := tenantControlPlane.Status.Certificates.SA
+
+	if lastRotation := status.LastRotation; interval > 0 && lastRotation != nil {
+		next(lastRotation.Add(interval))
+	}
+
+	for _, key := range status.VerificationKeys {
+		if key.RetiredAt != nil {
+			next(key.RetiredAt.Add(retentionPeriod))
+		}
+	}
+
+	return after
+}
+
+// lastRotation returns the time the signing key has been rotated at, falling back to its generation.
+func (r *SACertificate) lastRotation() *metav1.Time {
+	if value, ok := r.resource.GetAnnotations()[utilities.RotateCertificateRequestAnnotation]; ok {
+		if rotatedAt, err := time.Parse(time.RFC3339, value); err == nil {
+			return &metav1.Time{Time: rotatedAt}
+		}
+	}
+
+	if creation := r.resource.GetCreationTimestamp(); !creation.IsZero() {
+		return &creation
+	}
+
+	return nil
+}
+
+// isRotationScheduled returns true if the rotation interval of the existing signing key elapsed.
+func (r *SACertificate) isRotationScheduled(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	interval, _, _ := tenantControlPlane.ServiceAccountKeyRotation()
+	if interval == 0 || len(r.resource.UID) == 0 {
+		return false
+	}
+
+	lastRotation := r.lastRotation()
+
+	return lastRotation != nil && time.Since(lastRotation.Time) >= interval
+}
+
+// retiredKeys returns the retired public keys whose retention period didn't elapse yet,
+// none when they're not retained.
+func (r *SACertificate) retiredKeys(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string][]byte {
+	keys := make(map[string][]byte)
+
+	_, retentionPeriod, isEnabled := tenantControlPlane.ServiceAccountKeyRotation()
+	if !isEnabled {
+		return keys
+	}
+
+	for name, key := range r.resource.Data {
+		if retiredAt, ok := parseRetiredKeyName(name); ok && time.Since(retiredAt) < retentionPeriod {
+			keys[name] = key
+		}
+	}
+
+	return keys
+}
+
+// retireSigningKey returns the retired public keys, along with the one of the signing key being replaced.
+func (r *SACertificate) retireSigningKey(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string][]byte {
+	keys := r.retiredKeys(tenantControlPlane)
+
+	if _, _, isEnabled := tenantControlPlane.ServiceAccountKeyRotation(); !isEnabled {
+		return keys
+	}
+
+	if current := r.resource.Data[kubeadmconstants.ServiceAccountPublicKeyName]; len(current) > 0 {
+		if _, err := crypto.ParsePublicKeyBytes(current); err == nil {
+			keys[retiredKeyName(time.Now())] = current
+		}
+	}
+
+	return keys
+}
+
+// setKeys stores the signing key pair, and the retired public keys: all of them are verifying the tokens,
+// the most recent first.
+func (r *SACertificate) setKeys(publicKey, privateKey []byte, retired map[string][]byte) {
+	r.resource.Data = map[string][]byte{
+		kubeadmconstants.ServiceAccountPublicKeyName:  publicKey,
+		kubeadmconstants.ServiceAccountPrivateKeyName: privateKey,
+	}
+
+	verificationKeys := [][]byte{publicKey}
+
+	for _, name := range sortedRetiredKeyNames(retired) {
+		r.resource.Data[name] = retired[name]
+
+		verificationKeys = append(verificationKeys, retired[name])
+	}
+
+	r.resource.Data[constants.ServiceAccountVerificationKeysName] = concatPEMBlocks(verificationKeys...)
+}
+
+// verificationKeys returns the identifiers of the public keys verifying the tokens, as published in the JWKS document.
+func (r *SACertificate) verificationKeys() []kamajiv1alpha1.ServiceAccountVerificationKeyStatus {
+	var keys []kamajiv1alpha1.ServiceAccountVerificationKeyStatus
+
+	if keyID, err := crypto.PublicKeyID(r.resource.Data[kubeadmconstants.ServiceAccountPublicKeyName]); err == nil {
+		keys = append(keys, kamajiv1alpha1.ServiceAccountVerificationKeyStatus{KeyID: keyID})
+	}
+
+	for _, name := range sortedRetiredKeyNames(r.resource.Data) {
+		keyID, err := crypto.PublicKeyID(r.resource.Data[name])
+		if err != nil {
+			continue
+		}
+
+		retiredAt, _ := parseRetiredKeyName(name)
+
+		keys = append(keys, kamajiv1alpha1.ServiceAccountVerificationKeyStatus{KeyID: keyID, RetiredAt: &metav1.Time{Time: retiredAt}})
+	}
+
+	return keys
+}
+
+func retiredKeyName(retiredAt time.Time) string {
+	return fmt.Sprintf("%s%d.pub", constants.ServiceAccountRetiredKeyPrefix, retiredAt.Unix())
+}
+
+func parseRetiredKeyName(name string) (time.Time, bool) {
+	value, ok := strings.CutPrefix(name, constants.ServiceAccountRetiredKeyPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSuffix(value, ".pub"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(seconds, 0), true
+}
+
+// sortedRetiredKeyNames returns the names of the retired keys, the most recently retired first.
+func sortedRetiredKeyNames(data map[string][]byte) []string {
+	names := make([]string, 0, len(data))
+
+	for name := range data {
+		if _, ok := parseRetiredKeyName(name); ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		retiredA, _ := parseRetiredKeyName(a)
+		retiredB, _ := parseRetiredKeyName(b)
+
+		return retiredB.Compare(retiredA)
+	})
+
+	return names
+}
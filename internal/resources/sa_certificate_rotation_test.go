@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+var _ = Describe("SACertificate key rotation", func() {
+	var (
+		ctx       context.Context
+		tcp       *kamajiv1alpha1.TenantControlPlane
+		c         client.Client
+		reconcile func() *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "default", UID: "tenant-uid"},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+				PKI: &kamajiv1alpha1.PKISpec{
+					KeyAlgorithm: kamajiv1alpha1.KeyAlgorithmECDSAP256,
+					ServiceAccountKeyRotation: &kamajiv1alpha1.ServiceAccountKeyRotationSpec{
+						RetentionPeriod: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+		}
+		tcp.Status.KubeadmConfig.ConfigmapName = "tenant-kubeadmconfig"
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-kubeadmconfig", Namespace: tcp.Namespace},
+			Data: map[string]string{
+				kubeadmconstants.InitConfigurationKind:    "{}",
+				kubeadmconstants.ClusterConfigurationKind: "{}",
+			},
+		}
+
+		c = fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(tcp, configMap).Build()
+
+		reconcile = func() *corev1.Secret {
+			resource := &resources.SACertificate{Client: c, TmpDirectory: GinkgoT().TempDir()}
+			Expect(resource.Define(ctx, tcp)).To(Succeed())
+
+			_, err := resource.CreateOrUpdate(ctx, tcp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.UpdateTenantControlPlaneStatus(ctx, tcp)).To(Succeed())
+
+			var secret corev1.Secret
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tenant-sa-certificate"}, &secret)).To(Succeed())
+
+			return &secret
+		}
+	})
+
+	requestRotation := func() {
+		var secret corev1.Secret
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: tcp.Namespace, Name: "tenant-sa-certificate"}, &secret)).To(Succeed())
+
+		secret.SetAnnotations(map[string]string{utilities.RotateCertificateRequestAnnotation: ""})
+		Expect(c.Update(ctx, &secret)).To(Succeed())
+	}
+
+	It("should keep verifying the tokens with the retired public key", func() {
+		generated := reconcile()
+		Expect(generated.Data[constants.ServiceAccountVerificationKeysName]).To(Equal(generated.Data[kubeadmconstants.ServiceAccountPublicKeyName]))
+		Expect(tcp.Status.Certificates.SA.VerificationKeys).To(HaveLen(1))
+
+		requestRotation()
+
+		rotated := reconcile()
+		Expect(rotated.Data[kubeadmconstants.ServiceAccountPublicKeyName]).ToNot(Equal(generated.Data[kubeadmconstants.ServiceAccountPublicKeyName]))
+		Expect(rotated.Data[constants.ServiceAccountVerificationKeysName]).To(ContainSubstring(string(generated.Data[kubeadmconstants.ServiceAccountPublicKeyName])))
+		Expect(rotated.GetAnnotations()[utilities.RotateCertificateRequestAnnotation]).ToNot(BeEmpty())
+
+		retiredKeyID, err := crypto.PublicKeyID(generated.Data[kubeadmconstants.ServiceAccountPublicKeyName])
+		Expect(err).ToNot(HaveOccurred())
+
+		keys := tcp.Status.Certificates.SA.VerificationKeys
+		Expect(keys).To(HaveLen(2))
+		Expect(keys[0].RetiredAt).To(BeNil())
+		Expect(keys[1].KeyID).To(Equal(retiredKeyID))
+		Expect(keys[1].RetiredAt).ToNot(BeNil())
+		Expect(tcp.Status.Certificates.SA.LastRotation).ToNot(BeNil())
+
+		Expect(resources.ServiceAccountKeyRotationRequeueAfter(tcp)).To(BeNumerically("~", time.Hour, time.Minute))
+	})
+
+	It("should drop the retired public keys once the retention period elapsed", func() {
+		generated := reconcile()
+
+		requestRotation()
+		reconcile()
+
+		tcp.Spec.PKI.ServiceAccountKeyRotation.RetentionPeriod = metav1.Duration{}
+
+		pruned := reconcile()
+		Expect(pruned.Data[constants.ServiceAccountVerificationKeysName]).ToNot(ContainSubstring(string(generated.Data[kubeadmconstants.ServiceAccountPublicKeyName])))
+		Expect(tcp.Status.Certificates.SA.VerificationKeys).To(HaveLen(1))
+	})
+})
@@ -12,6 +12,9 @@ import (
 
 const (
 	RotateCertificateRequestAnnotation = "certs.kamaji.clastix.io/rotate"
+	// ConfirmRotationPhaseAnnotation ends the given phase of the Graceful Certificate Authority rotation
+	// before the expiration of the soak period, when set on the Certificate Authority Secret.
+	ConfirmRotationPhaseAnnotation = "certs.kamaji.clastix.io/confirm-rotation-phase"
 
 	CertificateX509Label       = "x509"
 	CertificateKubeconfigLabel = "kubeconfig"
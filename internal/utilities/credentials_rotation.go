@@ -0,0 +1,61 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RotateCredentialsRequestAnnotation requests the rotation of the DataStore credentials when set
+	// on the Tenant Control Plane DataStore Secret, regardless of its value: it's removed once the credentials have been rotated.
+	// When set on the Tenant Control Plane, the request is moved to its DataStore Secret.
+	RotateCredentialsRequestAnnotation = "storage.kamaji.clastix.io/rotate"
+	// LastCredentialsRotationAnnotation reports the timestamp of the last rotation of the DataStore credentials.
+	LastCredentialsRotationAnnotation = "storage.kamaji.clastix.io/last-rotation"
+)
+
+func IsCredentialsRotationRequested(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[RotateCredentialsRequestAnnotation]
+
+	return ok
+}
+
+// SetLastCredentialsRotationTimestamp reports the rotation timestamp of the DataStore credentials, removing the request.
+func SetLastCredentialsRotationTimestamp(obj client.Object, now time.Time) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	delete(annotations, RotateCredentialsRequestAnnotation)
+	annotations[LastCredentialsRotationAnnotation] = now.Format(time.RFC3339)
+
+	obj.SetAnnotations(annotations)
+}
+
+// RemoveCredentialsRotationRequest removes the rotation request of the DataStore credentials, if any, without rotating them.
+func RemoveCredentialsRotationRequest(obj client.Object) {
+	annotations := obj.GetAnnotations()
+	delete(annotations, RotateCredentialsRequestAnnotation)
+
+	obj.SetAnnotations(annotations)
+}
+
+// GetLastCredentialsRotation returns the last rotation timestamp of the DataStore credentials, if any.
+func GetLastCredentialsRotation(obj client.Object) (time.Time, bool) {
+	v, ok := obj.GetAnnotations()[LastCredentialsRotationAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
@@ -5,15 +5,18 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/webhook/handlers"
 )
 
@@ -62,7 +65,7 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 			for _, routeHandler := range routeHandlers {
 				handlerPatches, err := fnInvoker(routeHandler.OnCreate)
 				if err != nil {
-					return admission.Denied(err.Error())
+					return denied(err)
 				}
 
 				patches = append(patches, handlerPatches...)
@@ -75,7 +78,7 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 			for _, routeHandler := range routeHandlers {
 				handlerPatches, err := routeHandler.OnUpdate(decodedObj, oldDecodedObj)(ctx, req)
 				if err != nil {
-					return admission.Denied(err.Error())
+					return denied(err)
 				}
 
 				patches = append(patches, handlerPatches...)
@@ -84,7 +87,7 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 			for _, routeHandler := range routeHandlers {
 				handlerPatches, err := fnInvoker(routeHandler.OnDelete)
 				if err != nil {
-					return admission.Denied(err.Error())
+					return denied(err)
 				}
 
 				patches = append(patches, handlerPatches...)
@@ -100,3 +103,14 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 		return admission.Allowed(fmt.Sprintf("%s operation allowed", strings.ToLower(string(req.Operation))))
 	}
 }
+
+// denied returns the response denying the request: the status of the freeze webhook is returned as it is,
+// allowing the clients to match its reason, rather than its message.
+func denied(err error) admission.Response {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Reason != constants.FreezeStatusReason {
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{Allowed: false, Result: &statusErr.ErrStatus}}
+}
@@ -5,11 +5,15 @@ package handlers
 
 import (
 	"context"
-	"fmt"
+	"net/http"
 
 	"gomodules.xyz/jsonpatch/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/clastix/kamaji/internal/constants"
 )
 
 type Freeze struct{}
@@ -26,7 +30,13 @@ func (f Freeze) OnUpdate(runtime.Object, runtime.Object) AdmissionResponse {
 	return f.response
 }
 
+// response denies the request with the freeze reason, used to check the enforcement of the webhook.
 func (f Freeze) response(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
-	return nil, fmt.Errorf("the current Control Plane is in freezing mode due to a maintenance mode, all the changes are blocked: " +
-		"removing the webhook may lead to an inconsistent state upon its completion")
+	return nil, &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusForbidden,
+		Reason: constants.FreezeStatusReason,
+		Message: "the current Control Plane is in freezing mode due to a maintenance mode, all the changes are blocked: " +
+			"removing the webhook may lead to an inconsistent state upon its completion",
+	}}
 }
@@ -0,0 +1,24 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("Freeze Webhook", func() {
+	It("should deny the changes with the freeze reason", func() {
+		_, err := handlers.Freeze{}.OnCreate(nil)(context.Background(), admission.Request{})
+		Expect(err).To(HaveOccurred())
+		Expect(apierrors.ReasonForError(err)).To(Equal(constants.FreezeStatusReason))
+	})
+})
@@ -9,12 +9,15 @@ import (
 
 	"gomodules.xyz/jsonpatch/v2"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
@@ -27,10 +30,18 @@ func (t TenantControlPlaneDataStore) OnCreate(object runtime.Object) AdmissionRe
 		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
 		if tcp.Spec.DataStore != "" {
-			return nil, t.check(ctx, tcp.Spec.DataStore)
+			if err := t.check(ctx, tcp.Spec.DataStore); err != nil {
+				return nil, err
+			}
+
+			if err := t.checkCapacity(ctx, tcp); err != nil {
+				return nil, err
+			}
+		} else if err := t.checkDataStoreOverrides(ctx, tcp); err != nil {
+			return nil, err
 		}
 
-		return nil, t.checkDataStoreOverrides(ctx, tcp)
+		return nil, t.checkUniqueness(ctx, tcp, tcp.UsedDataStores())
 	}
 }
 
@@ -38,15 +49,26 @@ func (t TenantControlPlaneDataStore) OnDelete(runtime.Object) AdmissionResponse
 	return utils.NilOp()
 }
 
-func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
 	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
-		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+		tcp, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
 		if tcp.Spec.DataStore != "" {
-			return nil, t.check(ctx, tcp.Spec.DataStore)
+			if err := t.check(ctx, tcp.Spec.DataStore); err != nil {
+				return nil, err
+			}
+			// Capacity is enforced only when moving to another DataStore.
+			if tcp.Spec.DataStore != oldTCP.Spec.DataStore {
+				if err := t.checkCapacity(ctx, tcp); err != nil {
+					return nil, err
+				}
+			}
 		}
+		// Uniqueness is enforced only on the newly used DataStore objects, such as the migration target, or the added overrides:
+		// the clashes prior to the enforcement don't block the updates.
+		added := sets.New(tcp.UsedDataStores()...).Difference(sets.New(oldTCP.UsedDataStores()...))
 
-		return nil, nil
+		return nil, t.checkUniqueness(ctx, tcp, sets.List(added))
 	}
 }
 
@@ -62,6 +84,77 @@ func (t TenantControlPlaneDataStore) check(ctx context.Context, dataStoreName st
 	return nil
 }
 
+func (t TenantControlPlaneDataStore) checkCapacity(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	var ds kamajiv1alpha1.DataStore
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, &ds); err != nil {
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane DataStore capacity check, %w", err)
+	}
+
+	// Counting the Tenant Control Planes referring to the DataStore too, since it's listed in the status upon provisioning only.
+	assigned, err := datastore.AssignedTenantControlPlanes(ctx, t.Client, ds.GetName())
+	if err != nil {
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane DataStore capacity check, %w", err)
+	}
+
+	if ds.IsUsedBy(client.ObjectKeyFromObject(tcp).String()) || assigned.Has(client.ObjectKeyFromObject(tcp).String()) {
+		return nil
+	}
+
+	if ds.Spec.Cordoned {
+		return fmt.Errorf("%s DataStore is cordoned, and it doesn't accept further Tenant Control Planes", ds.GetName())
+	}
+
+	if ds.HasCapacity(assigned.Len()) {
+		return nil
+	}
+
+	return fmt.Errorf("%s DataStore has reached its maximum capacity of %d Tenant Control Planes", ds.GetName(), *ds.Spec.MaxTenants)
+}
+
+// checkUniqueness ensures the schema and the username of the Tenant Control Plane
+// are not used by other ones on the given DataStore objects.
+func (t TenantControlPlaneDataStore) checkUniqueness(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, dataStoreNames []string) error {
+	for _, dataStoreName := range dataStoreNames {
+		if err := t.checkUniqueField(ctx, tcp, kamajiv1alpha1.TenantControlPlaneDataStoreSchemaKey, dataStoreName, "schema", tcp.EffectiveDataStoreSchema()); err != nil {
+			return err
+		}
+
+		var ds kamajiv1alpha1.DataStore
+		if err := t.Client.Get(ctx, types.NamespacedName{Name: dataStoreName}, &ds); err != nil {
+			return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane DataStore uniqueness check, %w", err)
+		}
+		// Without an account, the NATS DataStore credentials are shared by its single Tenant Control Plane.
+		if ds.Spec.Driver == kamajiv1alpha1.KineNatsDriver && ds.Spec.NATSAccount == nil {
+			continue
+		}
+
+		if err := t.checkUniqueField(ctx, tcp, kamajiv1alpha1.TenantControlPlaneDataStoreUsernameKey, dataStoreName, "username", tcp.EffectiveDataStoreUsername()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneDataStore) checkUniqueField(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, key, dataStoreName, field, value string) error {
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := t.Client.List(ctx, &tcpList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(key, kamajiv1alpha1.DataStoreScopedIndexValue(dataStoreName, value)),
+	}); err != nil {
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane DataStore %s uniqueness check, %w", field, err)
+	}
+
+	for _, item := range tcpList.Items {
+		if item.GetNamespace() == tcp.GetNamespace() && item.GetName() == tcp.GetName() {
+			continue
+		}
+
+		return fmt.Errorf("the DataStore %s %s is already used by the Tenant Control Plane %s on the %s DataStore", field, value, client.ObjectKeyFromObject(&item).String(), dataStoreName)
+	}
+
+	return nil
+}
+
 func (t TenantControlPlaneDataStore) checkDataStoreOverrides(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
 	overrideCheck := make(map[string]struct{}, 0)
 	for _, ds := range tcp.Spec.DataStoreOverrides {
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
@@ -91,4 +92,124 @@ var _ = Describe("TCP Datastore webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Describe("capacity", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+			used, assigned := &kamajiv1alpha1.TenantControlPlaneStatusDataStore{}, &kamajiv1alpha1.TenantControlPlaneSpecDataStore{}
+			// The Tenant Control Plane is referring to the DataStore, although not provisioned yet.
+			t.Client = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&kamajiv1alpha1.DataStore{
+					ObjectMeta: metav1.ObjectMeta{Name: "full"},
+					Spec:       kamajiv1alpha1.DataStoreSpec{MaxTenants: ptr.To(int32(1))},
+				},
+				&kamajiv1alpha1.TenantControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "tcp", Namespace: "default"},
+					Spec:       kamajiv1alpha1.TenantControlPlaneSpec{DataStore: "full"},
+				},
+			).
+				WithIndex(used.Object(), used.Field(), used.ExtractValue()).
+				WithIndex(assigned.Object(), assigned.Field(), assigned.ExtractValue()).
+				Build()
+			tcp.Spec.DataStore = "full"
+		})
+
+		It("should allow a Tenant Control Plane already assigned to the DataStore", func() {
+			Expect(t.checkCapacity(ctx, tcp)).To(Succeed())
+		})
+
+		It("should reject a further Tenant Control Plane", func() {
+			tcp.SetName("another")
+
+			Expect(t.checkCapacity(ctx, tcp)).ToNot(Succeed())
+		})
+	})
+
+	Describe("uniqueness", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+			existing := &kamajiv1alpha1.TenantControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"},
+				Spec:       kamajiv1alpha1.TenantControlPlaneSpec{DataStore: "mysql"},
+				Status: kamajiv1alpha1.TenantControlPlaneStatus{Storage: kamajiv1alpha1.StorageStatus{
+					DataStoreName: "mysql",
+					Setup:         kamajiv1alpha1.DataStoreSetupStatus{Schema: "shared", User: "shared"},
+				}},
+			}
+			schema, username := &kamajiv1alpha1.TenantControlPlaneDataStoreSchema{}, &kamajiv1alpha1.TenantControlPlaneDataStoreUsername{}
+
+			t.Client = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&kamajiv1alpha1.DataStore{
+					ObjectMeta: metav1.ObjectMeta{Name: "mysql"},
+					Spec:       kamajiv1alpha1.DataStoreSpec{Driver: kamajiv1alpha1.KineMySQLDriver},
+				},
+				existing,
+			).
+				WithIndex(schema.Object(), schema.Field(), schema.ExtractValue()).
+				WithIndex(username.Object(), username.Field(), username.ExtractValue()).
+				Build()
+			tcp.Spec.DataStore = "mysql"
+			tcp.Spec.DataStoreSchema = "tcp"
+			tcp.Spec.DataStoreUsername = "tcp"
+		})
+
+		It("should allow unique schema and username", func() {
+			Expect(t.checkUniqueness(ctx, tcp, tcp.UsedDataStores())).To(Succeed())
+		})
+
+		It("should reject a clashing schema", func() {
+			tcp.Spec.DataStoreSchema = "shared"
+
+			Expect(t.checkUniqueness(ctx, tcp, tcp.UsedDataStores())).To(MatchError(ContainSubstring("default/existing")))
+		})
+
+		It("should reject a clashing username on a DataStore override", func() {
+			tcp.Spec.DataStore = "postgresql"
+			tcp.Spec.DataStoreUsername = "shared"
+			tcp.Spec.DataStoreOverrides = []kamajiv1alpha1.DataStoreOverride{{Resource: "/events", DataStore: "mysql"}}
+
+			Expect(t.checkUniqueness(ctx, tcp, []string{"mysql"})).To(MatchError(ContainSubstring("username shared")))
+		})
+
+		It("should not consider the Tenant Control Plane itself", func() {
+			tcp.SetName("existing")
+			tcp.Spec.DataStoreSchema = "shared"
+			tcp.Spec.DataStoreUsername = "shared"
+
+			Expect(t.checkUniqueness(ctx, tcp, tcp.UsedDataStores())).To(Succeed())
+		})
+	})
+
+	Describe("cordon", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+			used, assigned := &kamajiv1alpha1.TenantControlPlaneStatusDataStore{}, &kamajiv1alpha1.TenantControlPlaneSpecDataStore{}
+
+			t.Client = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(&kamajiv1alpha1.DataStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "cordoned"},
+				Spec:       kamajiv1alpha1.DataStoreSpec{Cordoned: true},
+				Status:     kamajiv1alpha1.DataStoreStatus{UsedBy: []string{"default/tcp"}},
+			}).
+				WithIndex(used.Object(), used.Field(), used.ExtractValue()).
+				WithIndex(assigned.Object(), assigned.Field(), assigned.ExtractValue()).
+				Build()
+			tcp.Spec.DataStore = "cordoned"
+		})
+
+		It("should allow a Tenant Control Plane already using the DataStore", func() {
+			Expect(t.checkCapacity(ctx, tcp)).To(Succeed())
+		})
+
+		It("should reject a further Tenant Control Plane", func() {
+			tcp.SetName("another")
+
+			Expect(t.checkCapacity(ctx, tcp)).ToNot(Succeed())
+		})
+	})
 })
@@ -9,25 +9,35 @@ import (
 	"net"
 
 	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	pointer "k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
 type TenantControlPlaneDefaults struct {
+	Client           client.Client
 	DefaultDatastore string
 }
 
 func (t TenantControlPlaneDefaults) OnCreate(object runtime.Object) AdmissionResponse {
-	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		original := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
 		defaulted := original.DeepCopy()
 		t.defaultUnsetFields(defaulted)
 
+		if err := t.placeDataStore(ctx, defaulted); err != nil {
+			return nil, err
+		}
+
 		if len(defaulted.Spec.NetworkProfile.DNSServiceIPs) == 0 {
 			var cidrs []string
 
@@ -78,7 +88,7 @@ func (t TenantControlPlaneDefaults) OnUpdate(runtime.Object, runtime.Object) Adm
 }
 
 func (t TenantControlPlaneDefaults) defaultUnsetFields(tcp *kamajiv1alpha1.TenantControlPlane) {
-	if len(tcp.Spec.DataStore) == 0 && t.DefaultDatastore != "" {
+	if len(tcp.Spec.DataStore) == 0 && len(tcp.Spec.DataStorePool) == 0 && t.DefaultDatastore != "" {
 		tcp.Spec.DataStore = t.DefaultDatastore
 	}
 
@@ -103,3 +113,42 @@ func (t TenantControlPlaneDefaults) defaultUnsetFields(tcp *kamajiv1alpha1.Tenan
 		tcp.Spec.NetworkProfile.PodCIDRs = []string{tcp.Spec.NetworkProfile.PodCIDR}
 	}
 }
+
+// placeDataStore assigns the DataStore to Tenant Control Planes without one, according to the policy of the referenced DataStorePool:
+// the Tenant Control Planes referencing no DataStorePool are left unassigned, as without the pools.
+func (t TenantControlPlaneDefaults) placeDataStore(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if len(tcp.Spec.DataStore) > 0 || len(tcp.Spec.DataStorePool) == 0 || t.Client == nil {
+		return nil
+	}
+
+	var pool kamajiv1alpha1.DataStorePool
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStorePool}, &pool); err != nil {
+		return fmt.Errorf("cannot retrieve the %s DataStorePool: %w", tcp.Spec.DataStorePool, err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&pool.Spec.DataStoreSelector)
+	if err != nil {
+		return fmt.Errorf("cannot parse the %s DataStorePool selector: %w", pool.GetName(), err)
+	}
+
+	var dataStores kamajiv1alpha1.DataStoreList
+	if err = t.Client.List(ctx, &dataStores, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("cannot list DataStore objects for the placement: %w", err)
+	}
+
+	request := datastore.PlacementRequest{Policy: pool.Spec.Policy, SpreadLabel: pool.Spec.SpreadLabel, Candidates: dataStores.Items}
+
+	if request.Tenants, err = datastore.AssignedTenants(ctx, t.Client, request.Candidates); err != nil {
+		return err
+	}
+
+	ds, reason, err := datastore.Place(request)
+	if err != nil {
+		return fmt.Errorf("cannot place the Tenant Control Plane: %w", err)
+	}
+
+	tcp.Spec.DataStore = ds.GetName()
+	tcp.SetAnnotations(utilities.MergeMaps(tcp.GetAnnotations(), map[string]string{kamajiv1alpha1.DataStorePlacementAnnotation: reason}))
+
+	return nil
+}
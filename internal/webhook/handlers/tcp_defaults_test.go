@@ -10,8 +10,11 @@ import (
 	. "github.com/onsi/gomega"
 	"gomodules.xyz/jsonpatch/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
@@ -168,4 +171,138 @@ var _ = Describe("TCP Defaulting Webhook", func() {
 			}
 		})
 	})
+
+	Describe("automatic DataStore placement", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+			dataStore := func(name, zone string, maxTenants *int32) *kamajiv1alpha1.DataStore {
+				return &kamajiv1alpha1.DataStore{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tier": "gold", "zone": zone}},
+					Spec:       kamajiv1alpha1.DataStoreSpec{MaxTenants: maxTenants},
+					Status:     kamajiv1alpha1.DataStoreStatus{Ready: true},
+				}
+			}
+			// The Tenant Control Planes not provisioned yet are referring to the DataStore, without using it.
+			tenant := func(name, dataStore string, provisioned bool) *kamajiv1alpha1.TenantControlPlane {
+				tcp := &kamajiv1alpha1.TenantControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec:       kamajiv1alpha1.TenantControlPlaneSpec{DataStore: dataStore},
+				}
+				if provisioned {
+					tcp.Status.Storage.DataStoreName = dataStore
+				}
+
+				return tcp
+			}
+
+			used, assigned := &kamajiv1alpha1.TenantControlPlaneStatusDataStore{}, &kamajiv1alpha1.TenantControlPlaneSpecDataStore{}
+
+			t = handlers.TenantControlPlaneDefaults{
+				Client: fakeclient.NewClientBuilder().WithScheme(scheme).
+					WithIndex(used.Object(), used.Field(), used.ExtractValue()).
+					WithIndex(assigned.Object(), assigned.Field(), assigned.ExtractValue()).
+					WithObjects(
+						dataStore("pg-a", "a", ptr.To(int32(2))),
+						dataStore("pg-b", "a", nil),
+						dataStore("pg-c", "b", nil),
+						tenant("tcp-1", "pg-a", true),
+						tenant("tcp-2", "pg-a", false),
+						tenant("tcp-3", "pg-b", true),
+						tenant("tcp-4", "pg-c", true),
+						tenant("tcp-5", "pg-c", false),
+						&kamajiv1alpha1.DataStorePool{
+							ObjectMeta: metav1.ObjectMeta{Name: "leastused"},
+							Spec: kamajiv1alpha1.DataStorePoolSpec{
+								DataStoreSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+							},
+						},
+						&kamajiv1alpha1.DataStorePool{
+							ObjectMeta: metav1.ObjectMeta{Name: "binpacking"},
+							Spec: kamajiv1alpha1.DataStorePoolSpec{
+								DataStoreSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+								Policy:            kamajiv1alpha1.BinPackingPlacementPolicy,
+							},
+						},
+						&kamajiv1alpha1.DataStorePool{
+							ObjectMeta: metav1.ObjectMeta{Name: "spread"},
+							Spec: kamajiv1alpha1.DataStorePoolSpec{
+								DataStoreSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+								Policy:            kamajiv1alpha1.SpreadPlacementPolicy,
+								SpreadLabel:       "zone",
+							},
+						},
+						&kamajiv1alpha1.DataStorePool{
+							ObjectMeta: metav1.ObjectMeta{Name: "empty"},
+							Spec: kamajiv1alpha1.DataStorePoolSpec{
+								DataStoreSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "silver"}},
+							},
+						},
+					).Build(),
+			}
+		})
+
+		It("should leave the DataStore unassigned when no pool is specified", func() {
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).ToNot(ContainElement(HaveField("Path", "/spec/dataStore")))
+			Expect(ops).ToNot(ContainElement(HaveField("Path", "/metadata/annotations")))
+		})
+
+		It("should pick the least used DataStore using the LeastUsed policy", func() {
+			tcp.Spec.DataStorePool = "leastused"
+
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).To(ContainElement(
+				jsonpatch.Operation{Operation: "add", Path: "/spec/dataStore", Value: "pg-b"},
+			))
+			Expect(ops).To(ContainElement(HaveField("Path", "/metadata/annotations")))
+		})
+
+		It("should count the Tenant Control Planes not provisioned yet", func() {
+			tcp.Spec.DataStorePool = "leastused"
+
+			for _, name := range []string{"tcp-6", "tcp-7"} {
+				Expect(t.Client.Create(ctx, &kamajiv1alpha1.TenantControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec:       kamajiv1alpha1.TenantControlPlaneSpec{DataStore: "pg-b"},
+				})).To(Succeed())
+			}
+
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).To(ContainElement(
+				jsonpatch.Operation{Operation: "add", Path: "/spec/dataStore", Value: "pg-c"},
+			))
+		})
+
+		It("should fill the most used DataStore with capacity using the BinPacking policy", func() {
+			tcp.Spec.DataStorePool = "binpacking"
+
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).To(ContainElement(
+				jsonpatch.Operation{Operation: "add", Path: "/spec/dataStore", Value: "pg-c"},
+			))
+		})
+
+		It("should pick the least used group using the Spread policy", func() {
+			tcp.Spec.DataStorePool = "spread"
+
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).To(ContainElement(
+				jsonpatch.Operation{Operation: "add", Path: "/spec/dataStore", Value: "pg-c"},
+			))
+		})
+
+		It("should fail when the pool has no available DataStore", func() {
+			tcp.Spec.DataStorePool = "empty"
+
+			_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -9,9 +9,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/clastix/kamaji/cmd"
+	"github.com/clastix/kamaji/cmd/backup"
+	"github.com/clastix/kamaji/cmd/clone"
 	kubeconfig_generator "github.com/clastix/kamaji/cmd/kubeconfig-generator"
 	"github.com/clastix/kamaji/cmd/manager"
 	"github.com/clastix/kamaji/cmd/migrate"
+	"github.com/clastix/kamaji/cmd/restore"
 )
 
 func main() {
@@ -21,6 +24,9 @@ func main() {
 	root.AddCommand(mgr)
 	root.AddCommand(migrator)
 	root.AddCommand(kubeconfigGenerator)
+	root.AddCommand(backup.NewCmd(scheme))
+	root.AddCommand(restore.NewCmd(scheme))
+	root.AddCommand(clone.NewCmd(scheme))
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)